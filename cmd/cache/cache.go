@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cache
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/listendev/lstn/cmd/cache/clear"
+	"github.com/listendev/lstn/cmd/cache/ls"
+	"github.com/listendev/lstn/cmd/cache/prune"
+	"github.com/listendev/lstn/internal/project"
+	"github.com/listendev/lstn/pkg/cmd/options"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/spf13/cobra"
+)
+
+var _, filename, _, _ = runtime.Caller(0)
+
+func New(ctx context.Context) (*cobra.Command, error) {
+	c := &cobra.Command{
+		Use:                   "cache",
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Short:                 "Manage the verdicts cache",
+		Long: `Manage the on-disk cache of the verdicts.
+
+The lstn CLI caches the verdicts of the exact package versions, and of the lock files it analyses,
+under the lstn directory of the user cache directory (eg., ~/.cache/lstn on Linux).
+It reuses them for the number of hours the --cache-ttl flag tells.
+
+Use the --refresh flag to ignore the cached verdicts while caching the fresh ones,
+or the --no-cache flag to not use the cache at all.`,
+		Annotations: map[string]string{
+			"source": project.GetSourceURL(filename),
+		},
+		Args: func(c *cobra.Command, args []string) error {
+			if err := cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs)(c, args); err != nil {
+				_ = c.Help()
+
+				return err
+			}
+
+			return nil
+		},
+		ValidArgs: []string{"ls", "prune", "clear"},
+		Run:       func(_ *cobra.Command, _ []string) {},
+	}
+
+	// Attach `ls` child command
+	lsCmd, err := ls.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.AddCommand(lsCmd)
+
+	// Attach `prune` child command
+	pruneCmd, err := prune.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.AddCommand(pruneCmd)
+
+	// Attach `clear` child command
+	clearCmd, err := clear.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.AddCommand(clearCmd)
+
+	// Create the local options
+	emptyOpts, err := options.NewEmpty()
+	if err != nil {
+		return nil, err
+	}
+	emptyOpts.Attach(c, []string{})
+
+	// Pass the options through the context
+	ctx = context.WithValue(ctx, pkgcontext.EmptyKey, emptyOpts)
+	c.SetContext(ctx)
+
+	return c, nil
+}
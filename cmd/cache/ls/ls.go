@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package ls
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/cli/cli/utils"
+	"github.com/listendev/lstn/internal/project"
+	"github.com/listendev/lstn/pkg/cache"
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	"github.com/listendev/lstn/pkg/cmd/options"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/spf13/cobra"
+)
+
+var _, filename, _, _ = runtime.Caller(0)
+
+// entry is the JSON form of a cache entry.
+type entry struct {
+	cache.Key
+	CreatedAt time.Time `json:"created_at"`
+	Expired   bool      `json:"expired"`
+	Size      int64     `json:"size"`
+}
+
+func New(ctx context.Context) (*cobra.Command, error) {
+	c := &cobra.Command{
+		Use:                   "ls",
+		DisableFlagsInUseLine: true,
+		Short:                 "List the cached verdicts",
+		Long: `List the cached verdicts, from the most recent ones.
+
+The expired entries are the ones older than the number of hours the --cache-ttl flag tells.`,
+		Example: `  lstn cache ls
+  lstn cache ls --cache-ttl 1
+  lstn cache ls --json --jq '.[] | select(.expired) | .name'`,
+		Annotations: map[string]string{
+			"source": project.GetSourceURL(filename),
+		},
+		RunE: func(c *cobra.Command, _ []string) error {
+			ctx = c.Context()
+
+			// Obtain the local options from the context
+			opts, err := pkgcontext.GetOptionsFromContext(ctx, pkgcontext.CacheLsKey)
+			if err != nil {
+				return err
+			}
+			lsOpts, ok := opts.(*options.CacheLs)
+			if !ok {
+				return fmt.Errorf("couldn't obtain options for the current child command")
+			}
+
+			cfgOpts, ok := ctx.Value(pkgcontext.ConfigKey).(*flags.ConfigFlags)
+			if !ok {
+				return fmt.Errorf("couldn't obtain configuration options")
+			}
+			// The config options carry the cache flags values coming from environment variables and config file
+			lsOpts.Cache = cfgOpts.Cache
+
+			if lsOpts.DebugOptions {
+				c.Println(lsOpts.AsJSON())
+
+				return nil
+			}
+
+			verdictsCache, err := cache.NewFromConfig(cfgOpts)
+			if err != nil {
+				return fmt.Errorf("couldn't find the cache directory: %w", err)
+			}
+			entries, err := verdictsCache.Entries()
+			if err != nil {
+				return err
+			}
+
+			io := ctx.Value(pkgcontext.IOStreamsKey).(*iostreams.IOStreams)
+
+			if lsOpts.JSON {
+				ret := make([]entry, len(entries))
+				for i, e := range entries {
+					ret[i] = entry{Key: e.Key, CreatedAt: e.CreatedAt, Expired: verdictsCache.Expired(e), Size: e.Size()}
+				}
+				allJSON := new(bytes.Buffer)
+				if err := json.NewEncoder(allJSON).Encode(ret); err != nil {
+					return fmt.Errorf("couldn't JSON encode the cache entries")
+				}
+
+				return lsOpts.GetOutput(ctx, allJSON, io.Out)
+			}
+
+			if len(entries) == 0 {
+				fmt.Fprintf(io.Out, "The cache in %s is empty\n", verdictsCache.Dir())
+
+				return nil
+			}
+
+			cs := io.ColorScheme()
+			tab := utils.NewTablePrinter(io)
+			for _, e := range entries {
+				tab.AddField(e.Key.String(), nil, cs.Bold)
+				tab.AddField(e.Key.Digest, nil, cs.Gray)
+				tab.AddField(utils.FuzzyAgo(time.Since(e.CreatedAt)), nil, nil)
+				if verdictsCache.Expired(e) {
+					tab.AddField("expired", nil, cs.Yellow)
+				} else {
+					tab.AddField("", nil, nil)
+				}
+				tab.EndRow()
+			}
+
+			return tab.Render()
+		},
+	}
+
+	lsOpts, err := options.NewCacheLs()
+	if err != nil {
+		return nil, err
+	}
+
+	// Local flags will only run when this command is called directly
+	lsOpts.Attach(c, []string{"no-cache", "refresh"})
+
+	// Pass the options through the context
+	ctx = context.WithValue(ctx, pkgcontext.CacheLsKey, lsOpts)
+	c.SetContext(ctx)
+
+	return c, nil
+}
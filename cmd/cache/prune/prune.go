@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package prune
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/listendev/lstn/internal/project"
+	"github.com/listendev/lstn/pkg/cache"
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	"github.com/listendev/lstn/pkg/cmd/options"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/spf13/cobra"
+)
+
+var _, filename, _, _ = runtime.Caller(0)
+
+func New(ctx context.Context) (*cobra.Command, error) {
+	c := &cobra.Command{
+		Use:                   "prune",
+		DisableFlagsInUseLine: true,
+		Short:                 "Remove the expired verdicts from the cache",
+		Long: `Remove the cached verdicts older than the number of hours the --cache-ttl flag tells.
+
+It also removes the files of the cache directory that are not valid cache entries.`,
+		Example: `  lstn cache prune
+  lstn cache prune --cache-ttl 168`,
+		Annotations: map[string]string{
+			"source": project.GetSourceURL(filename),
+		},
+		RunE: func(c *cobra.Command, _ []string) error {
+			ctx = c.Context()
+
+			// Obtain the local options from the context
+			opts, err := pkgcontext.GetOptionsFromContext(ctx, pkgcontext.CachePruneKey)
+			if err != nil {
+				return err
+			}
+			pruneOpts, ok := opts.(*options.CachePrune)
+			if !ok {
+				return fmt.Errorf("couldn't obtain options for the current child command")
+			}
+
+			cfgOpts, ok := ctx.Value(pkgcontext.ConfigKey).(*flags.ConfigFlags)
+			if !ok {
+				return fmt.Errorf("couldn't obtain configuration options")
+			}
+			// The config options carry the cache flags values coming from environment variables and config file
+			pruneOpts.Cache = cfgOpts.Cache
+
+			if pruneOpts.DebugOptions {
+				c.Println(pruneOpts.AsJSON())
+
+				return nil
+			}
+
+			verdictsCache, err := cache.NewFromConfig(cfgOpts)
+			if err != nil {
+				return fmt.Errorf("couldn't find the cache directory: %w", err)
+			}
+			count, err := verdictsCache.Prune()
+			if err != nil {
+				return err
+			}
+
+			io := ctx.Value(pkgcontext.IOStreamsKey).(*iostreams.IOStreams)
+			noun := "entries"
+			if count == 1 {
+				noun = "entry"
+			}
+			fmt.Fprintf(io.Out, "Removed %d cache %s from %s\n", count, noun, verdictsCache.Dir())
+
+			return nil
+		},
+	}
+
+	pruneOpts, err := options.NewCachePrune()
+	if err != nil {
+		return nil, err
+	}
+
+	// Local flags will only run when this command is called directly
+	pruneOpts.Attach(c, []string{"no-cache", "refresh"})
+
+	// Pass the options through the context
+	ctx = context.WithValue(ctx, pkgcontext.CachePruneKey, pruneOpts)
+	c.SetContext(ctx)
+
+	return c, nil
+}
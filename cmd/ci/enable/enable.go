@@ -32,6 +32,7 @@ import (
 	"github.com/listendev/lstn/pkg/cmd/flags"
 	"github.com/listendev/lstn/pkg/cmd/options"
 	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/listendev/lstn/pkg/transport"
 	"github.com/listendev/lstn/pkg/validate"
 	"github.com/listendev/pkg/apispec"
 	"github.com/spf13/cobra"
@@ -138,6 +139,7 @@ func New(ctx context.Context) (*cobra.Command, error) {
 
 			coreClient, coreClientErr := apispec.NewClientWithResponses(
 				opts.Endpoint.Core,
+				apispec.WithHTTPClient(transport.Client(ctx)),
 				apispec.WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
 					if req == nil {
 						io.StopProgressIndicator()
@@ -273,7 +275,7 @@ func New(ctx context.Context) (*cobra.Command, error) {
 		return nil, err
 	}
 	// Local flags will only run when this command is called directly
-	enableOpts.Attach(c, []string{"--ignore-packages", "--ignore-deptypes", "--select", "lockfiles", "npm-endpoint", "pypi-endpoint", "reporter", "npm-registry", "gh-owner", "gh-pull-id", "gh-repo"})
+	enableOpts.Attach(c, []string{"--ignore-packages", "--ignore-deptypes", "--ignore-groups", "--select", "lockfiles", "npm-endpoint", "pypi-endpoint", "reporter", "npm-registry", "pypi-registry", "gh-owner", "gh-pull-id", "gh-repo", "cache-ttl", "no-cache", "refresh", "offline", "verdicts-bundle", "fail-on", "fail-on-codes", "fail-on-problems"})
 
 	// Pass the options through the context
 	ctx = context.WithValue(ctx, pkgcontext.CiEnableKey, enableOpts)
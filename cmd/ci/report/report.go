@@ -34,6 +34,7 @@ import (
 	"github.com/listendev/lstn/pkg/cmd/options"
 	pkgcontext "github.com/listendev/lstn/pkg/context"
 	"github.com/listendev/lstn/pkg/reporter/factory"
+	"github.com/listendev/lstn/pkg/transport"
 	"github.com/listendev/lstn/pkg/validate"
 	"github.com/spf13/cobra"
 )
@@ -173,7 +174,7 @@ func New(ctx context.Context) (*cobra.Command, error) {
 		return nil, err
 	}
 	// Local flags will only run when this command is called directly
-	reportOpts.Attach(c, []string{"npm-registry", "select", "ignore-deptypes", "ignore-packages", "pypi-endpoint", "npm-endpoint", "lockfiles", "reporter"})
+	reportOpts.Attach(c, []string{"npm-registry", "pypi-registry", "select", "ignore-deptypes", "ignore-packages", "ignore-groups", "pypi-endpoint", "npm-endpoint", "lockfiles", "reporter", "cache-ttl", "no-cache", "refresh", "offline", "verdicts-bundle", "fail-on", "fail-on-codes", "fail-on-problems"})
 
 	// Pass the options through the context
 	ctx = context.WithValue(ctx, pkgcontext.CiReportKey, reportOpts)
@@ -341,8 +342,7 @@ func getLinkOfDashboard(ctx context.Context, baseURL, token string, ghCtx GHctx)
 	qp.Add("run_attempt", ghCtx.RunAttempt)
 	request.URL.RawQuery = qp.Encode()
 
-	client := http.Client{}
-	response, err := client.Do(request)
+	response, err := transport.Client(ctx).Do(request)
 	if err != nil {
 		return "", err
 	}
@@ -388,8 +388,7 @@ func events(ctx context.Context, baseURL, token string, ghCtx GHctx) ([]NetPolic
 	qp.Add("run_attempt", ghCtx.RunAttempt)
 	request.URL.RawQuery = qp.Encode()
 
-	client := http.Client{}
-	response, err := client.Do(request)
+	response, err := transport.Client(ctx).Do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -466,8 +465,7 @@ func triggerWebhook(ctx context.Context, token string, baseURL string, ghCtx GHc
 	request.Header.Add("Authorization", "Bearer "+token)
 	request.Header.Add("Content-Type", "application/json")
 
-	client := http.Client{}
-	response, err := client.Do(request)
+	response, err := transport.Client(ctx).Do(request)
 	if err != nil {
 		return err
 	}
@@ -40,6 +40,31 @@ func TestChildCommands(t *testing.T) {
 	cwd, _ := os.Getwd()
 
 	cases := []testCase{
+		// lstn cache
+		{
+			name:    "lstn cache",
+			cmdline: []string{"cache"},
+			stdout:  "Manage the on-disk cache of the verdicts.\n\nThe lstn CLI caches the verdicts of the exact package versions, and of the lock files it analyses,\nunder the lstn directory of the user cache directory (eg., ~/.cache/lstn on Linux).\nIt reuses them for the number of hours the --cache-ttl flag tells.\n\nUse the --refresh flag to ignore the cached verdicts while caching the fresh ones,\nor the --no-cache flag to not use the cache at all.\n\nUsage:\n  lstn cache\n  lstn cache [command]\n\nAvailable Commands:\n  clear       Remove all the verdicts from the cache\n  ls          List the cached verdicts\n  prune       Remove the expired verdicts from the cache\n\nGlobal Flags:\n      --config string   config file (default is $HOME/.lstn.yaml)\n\nUse \"lstn cache [command] --help\" for more information about a command.\n",
+			stderr:  "Error: accepts 1 arg(s), received 0\n",
+			errstr:  "accepts 1 arg(s), received 0",
+		},
+		// LSTN_CACHE_TTL=168 lstn cache prune --debug-options
+		{
+			name: "LSTN_CACHE_TTL=168 lstn cache prune --debug-options",
+			envvar: map[string]string{
+				"LSTN_CACHE_TTL": "168",
+			},
+			cmdline: []string{"cache", "prune", "--debug-options"},
+			stdout: heredoc.Doc(`{
+				"cache-ttl": 168,
+				"debug-options": true,
+				"no-cache": false,
+				"refresh": false
+			}
+			`),
+			stderr: "",
+			errstr: "",
+		},
 		// lstn ci
 		{
 			name:    "lstn ci",
@@ -91,12 +116,20 @@ func TestChildCommands(t *testing.T) {
 			},
 			cmdline: []string{"ci", "report", "--jwt-token", "12345", "--gh-token", "54321", "--debug-options"},
 			stdout: heredoc.Doc(`{
+		"ca-cert": "",
+		"cache-ttl": 24,
+		"client-cert": "",
+		"client-key": "",
+		"concurrency": 8,
 		"debug-options": true,
 		"endpoint": {
 			"core": "https://core.listen.dev",
 			"npm": "https://npm.listen.dev",
 			"pypi": "https://pypi.listen.dev"
 		},
+		"fail-on": "",
+		"fail-on-codes": null,
+		"fail-on-problems": false,
 		"gh-owner": "reviewdog",
 		"gh-pull-id": 285,
 		"gh-repo": "reviewdog",
@@ -104,17 +137,27 @@ func TestChildCommands(t *testing.T) {
 		"ignore-deptypes": [
 			110
 		],
+		"ignore-groups": null,
 		"ignore-packages": null,
 		"jwt-token": "12345",
 		"lockfiles": [
 			"package-lock.json",
+			"pnpm-lock.yaml",
 			"poetry.lock"
 		],
 		"loglevel": "info",
+		"no-cache": false,
 		"npm-registry": "https://registry.npmjs.org",
+		"offline": false,
+		"proxy": "",
+		"pypi-registry": "https://pypi.org",
+		"rate-limit": 0,
+		"refresh": false,
 		"reporter": [],
+		"retries": 3,
 		"select": "",
-		"timeout": 60
+		"timeout": 60,
+		"verdicts-bundle": ""
 	}
 `),
 		},
@@ -143,7 +186,7 @@ func TestChildCommands(t *testing.T) {
 			name:    "lstn in",
 			cmdline: []string{"in"},
 			stdout:  "",
-			stderr:  "Running without a configuration file\n! _CWD_/package-lock.json not found\n! _CWD_/poetry.lock not found\nError: directory _CWD_ does not contain any lock file\n",
+			stderr:  "Running without a configuration file\n! _CWD_/package-lock.json not found\n! _CWD_/pnpm-lock.yaml not found\n! _CWD_/poetry.lock not found\nError: directory _CWD_ does not contain any lock file\n",
 			errstr:  "directory _CWD_ does not contain any lock file",
 		},
 		// lstn to
@@ -170,6 +213,31 @@ Specifying the package name is mandatory.
 
 It lists out the verdicts of all the versions of the input package name.
 
+You can also reference the package with a package URL (purl), in which case it must be the only argument.
+The ecosystem, the name, the version, and the checksum qualifier (sha1 for npm, sha256 for PyPi) come from it.
+
+The package is an npm one, unless you ask for the PyPi ecosystem with the --ecosystem flag or with the pypi: prefix on its name.
+For PyPi packages, the version constraints are PEP 440 version specifiers (or Poetry ones) resolved through the PyPi JSON API,
+while the shasum is the SHA256 digest of a distribution file.
+
+For npm packages, the version constraints are resolved against the registry, and with the credentials,
+configured in the .npmrc file of the current directory and in the user one.
+
+When it couldn't get the verdicts of some of the versions, it lists them as not analysed and exits with status code 3.
+
+Use the --offline flag to answer from a verdicts bundle (see lstn export) rather than from listen.dev.
+In such a case, the exact versions need no registry, and the versions the bundle does not cover are listed as not analysed.
+
+Use the --fail-on, --fail-on-codes, and --fail-on-problems flags to exit with a non-zero status code (see lstn help exit)
+when some verdicts have a severity, or some codes, or when some packages have problems.
+
+The rules in the .lstnignore.yaml file of the current directory suppress the verdicts they match, with a reason, an owner, and an optional expiry date.
+The suppressed verdicts are listed apart, and they do not make the --fail-on flags fail.
+
+Use the --compare flag to get which verdicts appear or disappear between the consecutive versions, from the lowest one,
+rather than the verdicts of every version. It also recommends the closest version to the lowest one without high severity verdicts.
+This is handy to choose the version to upgrade to, within a version constraint.
+
 Usage:
   lstn to <name> [[version] [shasum] | [version constraint]]
 
@@ -188,13 +256,41 @@ Examples:
   # Get the verdicts for prettier versions >= 2.7.0 <= 3.0.0
   lstn to prettier ">=2.7.0 <=3.0.0"
 
+  # Get the verdicts for the PyPi requests package versions >= 2.31 and < 3
+  lstn to --ecosystem pypi requests ">=2.31,<3"
+  lstn to pypi:requests 2.32.3
+
+  # Get the verdicts for the package a package URL references
+  lstn to pkg:npm/%40vue/devtools@6.5.0
+  lstn to pkg:pypi/requests@2.31.0
+
+  # Get the verdicts for a package version from a verdicts bundle
+  lstn to debug 4.3.4 --offline --verdicts-bundle lstn-verdicts.json
+
+  # Fail when a package version has high severity verdicts
+  lstn to react 18.0.0 --fail-on high
+
+  # Compare the verdicts of the debug versions >= 4.3.1 and < 5, to choose the one to upgrade 4.3.1 to
+  lstn to debug "^4.3.1" --compare
+  lstn to --ecosystem pypi requests ">=2.28,<3" --compare --json
+
 Flags:
-      --json   output the verdicts (if any) in JSON form
+      --compare            compare the verdicts of the consecutive versions, and recommend the closest one without high severity verdicts
+      --ecosystem string   the ecosystem of the package (npm, pypi) (default "npm")
+      --json               output the verdicts (if any) in JSON form
+
+Cache Flags:
+      --cache-ttl int   set for how many hours to reuse the cached verdicts (default 24)
+      --no-cache        do not use the verdicts cache
+      --refresh         ignore the cached verdicts, and cache the fresh ones
 
 Config Flags:
+      --concurrency int        set the maximum number of concurrent requests (default 8)
       --loglevel string        set the logging level (default "info")
       --npm-endpoint string    the listen.dev endpoint emitting the NPM verdicts (default "https://npm.listen.dev")
       --pypi-endpoint string   the listen.dev endpoint emitting the PyPi verdicts (default "https://pypi.listen.dev")
+      --rate-limit int         set the maximum number of requests per second (0 means no limit)
+      --retries int            set how many times to retry the failed API requests (default 3)
       --timeout int            set the timeout, in seconds (default 60)
 
 Debug Flags:
@@ -204,8 +300,24 @@ Filtering Flags:
   -q, --jq string       filter the output verdicts using a jq expression (requires --json)
   -s, --select string   filter the output verdicts using a jsonpath script expression (server-side)
 
+Network Flags:
+      --ca-cert string       set a PEM file with the additional CA certificates to trust
+      --client-cert string   set a PEM file with the client certificate for mutual TLS
+      --client-key string    set a PEM file with the private key of the client certificate
+      --proxy string         set the proxy URL of the outgoing requests (defaults to the HTTPS_PROXY environment variable)
+
+Offline Flags:
+      --offline                  answer from a verdicts bundle, without querying listen.dev
+      --verdicts-bundle string   set the verdicts bundle (see lstn export) to answer from offline
+
+Policy Flags:
+      --fail-on string          fail when some verdicts have the given severity (low, medium, high) or a higher one
+      --fail-on-codes strings   fail when some verdicts have the given codes or code groups (eg., STN, TSN01)
+      --fail-on-problems        fail when some packages have problems
+
 Registry Flags:
-      --npm-registry string   set a custom NPM registry (default "https://registry.npmjs.org")
+      --npm-registry string    set a custom NPM registry (default "https://registry.npmjs.org")
+      --pypi-registry string   set a custom PyPi registry (default "https://pypi.org")
 
 Global Flags:
       --config string   config file (default is $HOME/.lstn.yaml)
@@ -224,10 +336,36 @@ Global Flags:
 			stdout: heredoc.Doc(`Query listen.dev for the verdicts of the dependencies in your project.
 
 Using this command, you can audit the first-level dependencies configured for a project and obtain their verdicts.
-This requires a package.json file to fetch the package name and version of the project dependencies.
+This requires a package.json or a pyproject.toml file to fetch the package name and version of the project dependencies.
+The version constraints of the Python dependencies (both the PEP 621 and the Poetry ones) get resolved against the PyPi registry.
+
+For npm projects, it also scans the package.json of the workspaces, it applies the overrides, and it resolves the aliases (eg., npm:foo@^1) and the distribution tags (eg., latest).
+
+The dependencies it cannot resolve to a package version in the registry (eg., git repositories, tarball URLs, local and workspace packages,
+or packages missing from the registry) are listed as not analysed: in the table, or on stderr in JSON mode (so that the --jq query only gets the verdicts).
+So are the packages whose verdicts it couldn't get from the listen.dev API: in such a case, it exits with status code 3.
+Use the --strict flag to fail when some dependencies cannot be resolved against the registry instead.
+
+By default, it resolves every dependency to the version the lock file next to the manifest pins (eg., package-lock.json, poetry.lock),
+falling back to the highest version satisfying its constraints when there's no lock file or the lock file does not pin it.
+Use the --resolution flag to always resolve to the highest or to the lowest version satisfying the constraints instead.
+
+It queries the npm registries, and uses the credentials, configured in the .npmrc file of the project and in the user one.
 
 The verdicts it returns are listed by the name of each package and its specified version.
 
+Use the --offline flag to answer from a verdicts bundle (see lstn export) rather than from listen.dev.
+In such a case, it lists the packages the bundle does not cover as not analysed, while it still resolves the dependencies against the registries.
+
+Use the --fail-on, --fail-on-codes, and --fail-on-problems flags to exit with a non-zero status code (see lstn help exit)
+when some verdicts have a severity, or some codes, or when some packages have problems.
+
+The rules in the .lstnignore.yaml file of the target directory suppress the verdicts they match, with a reason, an owner, and an optional expiry date.
+The suppressed verdicts are listed apart, and they do not make the --fail-on flags fail.
+
+Use the --write-baseline flag to save a snapshot of the verdicts, and the --baseline flag to report only the packages and the verdicts
+that such a snapshot does not have. The verdicts already in the baseline do not make the --fail-on flags fail.
+
 Usage:
   lstn scan [path]
 
@@ -241,14 +379,35 @@ Examples:
   lstn scan /we/snitch --ignore-deptypes dev --ignore-deptypes peer
   lstn scan /we/snitch --ignore-packages react,glob --ignore-deptypes peer
   lstn scan /we/snitch --ignore-packages react --ignore-packages glob,@vue/devtools
+  lstn scan /pyproj --ignore-groups dev,docs
+  lstn scan /we/snitch --resolution highest
+  lstn scan /we/snitch --strict
+  lstn scan /we/snitch --offline --verdicts-bundle lstn-verdicts.json
+  lstn scan /we/snitch --fail-on medium --fail-on-problems
+  lstn scan --write-baseline lstn-baseline.json
+  lstn scan --baseline lstn-baseline.json --reporter gh-pull-comment
 
 Flags:
-      --json   output the verdicts (if any) in JSON form
+      --json                output the verdicts (if any) in JSON form
+      --resolution string   how to resolve the version constraints (lockfile, highest, lowest) (default "lockfile")
+      --strict              fail when some dependencies cannot be resolved against the registry
+
+Baseline Flags:
+      --baseline string         report only the packages and the verdicts that the given baseline file does not have
+      --write-baseline string   write the verdicts into the given baseline file
+
+Cache Flags:
+      --cache-ttl int   set for how many hours to reuse the cached verdicts (default 24)
+      --no-cache        do not use the verdicts cache
+      --refresh         ignore the cached verdicts, and cache the fresh ones
 
 Config Flags:
+      --concurrency int        set the maximum number of concurrent requests (default 8)
       --loglevel string        set the logging level (default "info")
       --npm-endpoint string    the listen.dev endpoint emitting the NPM verdicts (default "https://npm.listen.dev")
       --pypi-endpoint string   the listen.dev endpoint emitting the PyPi verdicts (default "https://pypi.listen.dev")
+      --rate-limit int         set the maximum number of requests per second (0 means no limit)
+      --retries int            set how many times to retry the failed API requests (default 3)
       --timeout int            set the timeout, in seconds (default 60)
 
 Debug Flags:
@@ -256,12 +415,29 @@ Debug Flags:
 
 Filtering Flags:
       --ignore-deptypes (dep,dev,optional,peer)   the list of dependencies types to not process (default [bundle])
+      --ignore-groups strings                     the list of dependency groups (eg., poetry groups) to not process
       --ignore-packages strings                   the list of packages to not process
   -q, --jq string                                 filter the output verdicts using a jq expression (requires --json)
   -s, --select string                             filter the output verdicts using a jsonpath script expression (server-side)
 
+Network Flags:
+      --ca-cert string       set a PEM file with the additional CA certificates to trust
+      --client-cert string   set a PEM file with the client certificate for mutual TLS
+      --client-key string    set a PEM file with the private key of the client certificate
+      --proxy string         set the proxy URL of the outgoing requests (defaults to the HTTPS_PROXY environment variable)
+
+Offline Flags:
+      --offline                  answer from a verdicts bundle, without querying listen.dev
+      --verdicts-bundle string   set the verdicts bundle (see lstn export) to answer from offline
+
+Policy Flags:
+      --fail-on string          fail when some verdicts have the given severity (low, medium, high) or a higher one
+      --fail-on-codes strings   fail when some verdicts have the given codes or code groups (eg., STN, TSN01)
+      --fail-on-problems        fail when some packages have problems
+
 Registry Flags:
-      --npm-registry string   set a custom NPM registry (default "https://registry.npmjs.org")
+      --npm-registry string    set a custom NPM registry (default "https://registry.npmjs.org")
+      --pypi-registry string   set a custom PyPi registry (default "https://pypi.org")
 
 Reporting Flags:
       --gh-owner string                                               set the GitHub owner name (org|user)
@@ -287,12 +463,22 @@ Global Flags:
 			},
 			cmdline: []string{"to", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"compare": false,
+	"concurrency": 8,
 	"debug-options": true,
+	"ecosystem": "npm",
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -300,19 +486,29 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"timeout": 60,
+	"verdicts-bundle": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -327,12 +523,22 @@ Global Flags:
 			},
 			cmdline: []string{"to", "--debug-options", "--npm-registry", "https://some.io", "--timeout", "2222"},
 			stdout: heredoc.Doc(`{
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"compare": false,
+	"concurrency": 8,
 	"debug-options": true,
+	"ecosystem": "npm",
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -340,24 +546,225 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://some.io",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
+	"reporter": [],
+	"retries": 3,
+	"select": "",
+	"timeout": 2222,
+	"verdicts-bundle": ""
+}
+`),
+			stderr: "Running without a configuration file\n",
+			errstr: "",
+		},
+		// lstn to --debug-options --ecosystem pypi --pypi-registry https://pypi.example.org
+		{
+			name: "lstn to --debug-options --ecosystem pypi --pypi-registry https://pypi.example.org",
+			envvar: map[string]string{
+				// Temporarily pretend not to be in a GitHub Action (to make test work in a GitHub Action workflow)
+				"GITHUB_ACTIONS": "",
+			},
+			cmdline: []string{"to", "--debug-options", "--ecosystem", "pypi", "--pypi-registry", "https://pypi.example.org"},
+			stdout: heredoc.Doc(`{
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"compare": false,
+	"concurrency": 8,
+	"debug-options": true,
+	"ecosystem": "pypi",
+	"endpoint": {
+		"core": "https://core.listen.dev",
+		"npm": "https://npm.listen.dev",
+		"pypi": "https://pypi.listen.dev"
+	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
+	"gh-owner": "",
+	"gh-pull-id": 0,
+	"gh-repo": "",
+	"gh-token": "",
+	"ignore-deptypes": [
+		110
+	],
+	"ignore-groups": null,
+	"ignore-packages": null,
+	"jq": "",
+	"json": false,
+	"jwt-token": "",
+	"lockfiles": [
+		"package-lock.json",
+		"pnpm-lock.yaml",
+		"poetry.lock"
+	],
+	"loglevel": "info",
+	"no-cache": false,
+	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.example.org",
+	"rate-limit": 0,
+	"refresh": false,
+	"reporter": [],
+	"retries": 3,
+	"select": "",
+	"timeout": 60,
+	"verdicts-bundle": ""
+}
+`),
+			stderr: "Running without a configuration file\n",
+			errstr: "",
+		},
+		// lstn to pypi:invalid_
+		{
+			name: "lstn to pypi:invalid_",
+			envvar: map[string]string{
+				// Temporarily pretend not to be in a GitHub Action (to make test work in a GitHub Action workflow)
+				"GITHUB_ACTIONS": "",
+			},
+			cmdline: []string{"to", "pypi:invalid_"},
+			stdout:  "",
+			stderr:  "Error: invalid arguments\n       invalid_ is not a valid PyPi package name\n",
+			errstr:  "invalid arguments\n       invalid_ is not a valid PyPi package name",
+		},
+		// lstn to --ecosystem pypi requests ~=2
+		{
+			name: "lstn to --ecosystem pypi requests ~=2",
+			envvar: map[string]string{
+				// Temporarily pretend not to be in a GitHub Action (to make test work in a GitHub Action workflow)
+				"GITHUB_ACTIONS": "",
+			},
+			cmdline: []string{"to", "--ecosystem", "pypi", "requests", "~=2"},
+			stdout:  "",
+			stderr:  "Error: invalid arguments\n       ~=2 is neither a valid version specifier nor an exact valid PEP 440 version\n",
+			errstr:  "invalid arguments\n       ~=2 is neither a valid version specifier nor an exact valid PEP 440 version",
+		},
+		// lstn to pkg:cargo/rand@0.8.5
+		{
+			name: "lstn to pkg:cargo/rand@0.8.5",
+			envvar: map[string]string{
+				// Temporarily pretend not to be in a GitHub Action (to make test work in a GitHub Action workflow)
+				"GITHUB_ACTIONS": "",
+			},
+			cmdline: []string{"to", "pkg:cargo/rand@0.8.5"},
+			stdout:  "",
+			stderr:  "Error: invalid arguments\n       pkg:cargo/rand@0.8.5 is not a package URL of the npm or pypi type\n",
+			errstr:  "invalid arguments\n       pkg:cargo/rand@0.8.5 is not a package URL of the npm or pypi type",
+		},
+		// lstn diff --debug-options old/package-lock.json package-lock.json
+		{
+			name: "lstn diff --debug-options old/package-lock.json package-lock.json",
+			envvar: map[string]string{
+				// Temporarily pretend not to be in a GitHub Action (to make test work in a GitHub Action workflow)
+				"GITHUB_ACTIONS": "",
+			},
+			cmdline: []string{"diff", "--debug-options", "old/package-lock.json", "package-lock.json"},
+			stdout: heredoc.Doc(`{
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
+	"debug-options": true,
+	"endpoint": {
+		"core": "https://core.listen.dev",
+		"npm": "https://npm.listen.dev",
+		"pypi": "https://pypi.listen.dev"
+	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
+	"gh-owner": "",
+	"gh-pull-id": 0,
+	"gh-repo": "",
+	"gh-token": "",
+	"ignore-deptypes": [
+		110
+	],
+	"ignore-groups": null,
+	"ignore-packages": null,
+	"jq": "",
+	"json": false,
+	"jwt-token": "",
+	"lockfiles": [
+		"package-lock.json",
+		"pnpm-lock.yaml",
+		"poetry.lock"
+	],
+	"loglevel": "info",
+	"markdown": false,
+	"no-cache": false,
+	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"retries": 3,
 	"select": "",
-	"timeout": 2222
+	"timeout": 60,
+	"verdicts-bundle": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
 			errstr: "",
 		},
+		// lstn diff package-lock.json
+		{
+			name: "lstn diff package-lock.json",
+			envvar: map[string]string{
+				// Temporarily pretend not to be in a GitHub Action (to make test work in a GitHub Action workflow)
+				"GITHUB_ACTIONS": "",
+			},
+			cmdline: []string{"diff", "package-lock.json"},
+			stdout:  "",
+			stderr:  "Error: accepts 2 arg(s), received 1\n",
+			errstr:  "accepts 2 arg(s), received 1",
+		},
+		// lstn diff --json --markdown main:package-lock.json package-lock.json
+		{
+			name: "lstn diff --json --markdown main:package-lock.json package-lock.json",
+			envvar: map[string]string{
+				// Temporarily pretend not to be in a GitHub Action (to make test work in a GitHub Action workflow)
+				"GITHUB_ACTIONS": "",
+			},
+			cmdline: []string{"diff", "--json", "--markdown", "main:package-lock.json", "package-lock.json"},
+			stdout:  "",
+			stderr:  "Running without a configuration file\nError: invalid options\n       cannot use --markdown with --json\n",
+			errstr:  "invalid options\n       cannot use --markdown with --json",
+		},
+		// lstn diff old.txt new.txt
+		{
+			name: "lstn diff old.txt new.txt",
+			envvar: map[string]string{
+				// Temporarily pretend not to be in a GitHub Action (to make test work in a GitHub Action workflow)
+				"GITHUB_ACTIONS": "",
+			},
+			cmdline: []string{"diff", "old.txt", "new.txt"},
+			stdout:  "",
+			stderr:  "Running without a configuration file\nError: old.txt is not a supported lock file\n",
+			errstr:  "old.txt is not a supported lock file",
+		},
 		// lstn in --help
 		{
 			name: "lstn in --help",
@@ -369,11 +776,23 @@ Global Flags:
 			stdout: heredoc.Doc(`Query listen.dev for the verdicts of all the dependencies in your project.
 
 Using this command, you can audit all the dependencies of a project and obtain their verdicts.
-Given a project directory containing manifest files (package-lock.json, poetry.lock, etc),
+Given a project directory containing manifest files (package-lock.json, yarn.lock, pnpm-lock.yaml, poetry.lock, uv.lock, etc),
 it fetches the package names and versions of the project dependencies.
 
 The verdicts it returns are listed by the name of each package and its specified version.
 
+Use the --offline flag to answer from a verdicts bundle (see lstn export) rather than from listen.dev.
+In such a case, it lists the packages the bundle does not cover as not analysed, and it exits with status code 3.
+
+Use the --fail-on, --fail-on-codes, and --fail-on-problems flags to exit with a non-zero status code (see lstn help exit)
+when some verdicts have a severity, or some codes, or when some packages have problems.
+
+The rules in the .lstnignore.yaml file of the project directory suppress the verdicts they match, with a reason, an owner, and an optional expiry date.
+The suppressed verdicts are listed apart, and they do not make the --fail-on flags fail.
+
+Use the --write-baseline flag to save a snapshot of the verdicts, and the --baseline flag to report only the packages and the verdicts
+that such a snapshot does not have. The verdicts already in the baseline do not make the --fail-on flags fail.
+
 Usage:
   lstn in [path]
 
@@ -384,25 +803,63 @@ Examples:
   lstn in sub/dir
   lstn in --lockfiles poetry.lock,package-lock.json
   lstn in /pyproj --lockfiles poetry.lock
+  lstn in /pyproj --lockfiles poetry.lock --ignore-groups dev,docs
+  lstn in --lockfiles yarn.lock
+  lstn in --lockfiles npm-shrinkwrap.json,bun.lock
+  lstn in /pyproj --lockfiles uv.lock,pdm.lock,Pipfile.lock
+  lstn in /pyproj --lockfiles requirements.txt
+  lstn in --offline --verdicts-bundle lstn-verdicts.json
+  lstn in --fail-on high --fail-on-codes TSN,DDN
+  lstn in --write-baseline lstn-baseline.json
+  lstn in --baseline lstn-baseline.json --reporter gh-pull-comment
 
 Flags:
       --json                output the verdicts (if any) in JSON form
-  -l, --lockfiles strings   set one or more lock file paths (relative to the working dir) to lookup for (default [package-lock.json,poetry.lock])
+  -l, --lockfiles strings   set one or more lock file paths (relative to the working dir) to lookup for (default [package-lock.json,pnpm-lock.yaml,poetry.lock])
+
+Baseline Flags:
+      --baseline string         report only the packages and the verdicts that the given baseline file does not have
+      --write-baseline string   write the verdicts into the given baseline file
+
+Cache Flags:
+      --cache-ttl int   set for how many hours to reuse the cached verdicts (default 24)
+      --no-cache        do not use the verdicts cache
+      --refresh         ignore the cached verdicts, and cache the fresh ones
 
 Config Flags:
+      --concurrency int        set the maximum number of concurrent requests (default 8)
       --loglevel string        set the logging level (default "info")
       --npm-endpoint string    the listen.dev endpoint emitting the NPM verdicts (default "https://npm.listen.dev")
       --pypi-endpoint string   the listen.dev endpoint emitting the PyPi verdicts (default "https://pypi.listen.dev")
+      --rate-limit int         set the maximum number of requests per second (0 means no limit)
+      --retries int            set how many times to retry the failed API requests (default 3)
       --timeout int            set the timeout, in seconds (default 60)
 
 Debug Flags:
       --debug-options   output the options, then exit
 
 Filtering Flags:
-  -q, --jq string   filter the output verdicts using a jq expression (requires --json)
+      --ignore-groups strings   the list of dependency groups (eg., poetry groups) to not process
+  -q, --jq string               filter the output verdicts using a jq expression (requires --json)
+
+Network Flags:
+      --ca-cert string       set a PEM file with the additional CA certificates to trust
+      --client-cert string   set a PEM file with the client certificate for mutual TLS
+      --client-key string    set a PEM file with the private key of the client certificate
+      --proxy string         set the proxy URL of the outgoing requests (defaults to the HTTPS_PROXY environment variable)
+
+Offline Flags:
+      --offline                  answer from a verdicts bundle, without querying listen.dev
+      --verdicts-bundle string   set the verdicts bundle (see lstn export) to answer from offline
+
+Policy Flags:
+      --fail-on string          fail when some verdicts have the given severity (low, medium, high) or a higher one
+      --fail-on-codes strings   fail when some verdicts have the given codes or code groups (eg., STN, TSN01)
+      --fail-on-problems        fail when some packages have problems
 
 Registry Flags:
-      --npm-registry string   set a custom NPM registry (default "https://registry.npmjs.org")
+      --npm-registry string    set a custom NPM registry (default "https://registry.npmjs.org")
+      --pypi-registry string   set a custom PyPi registry (default "https://pypi.org")
 
 Reporting Flags:
       --gh-owner string                                               set the GitHub owner name (org|user)
@@ -429,12 +886,21 @@ Global Flags:
 			},
 			cmdline: []string{"in", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -442,19 +908,30 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -470,12 +947,21 @@ Global Flags:
 			},
 			cmdline: []string{"in", "--debug-options", "--timeout", "8888"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -483,19 +969,30 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"retries": 3,
 	"select": "",
-	"timeout": 8888
+	"timeout": 8888,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -511,12 +1008,21 @@ Global Flags:
 			},
 			cmdline: []string{"in", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm-staging.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -524,19 +1030,30 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -552,12 +1069,21 @@ Global Flags:
 			},
 			cmdline: []string{"in", "--debug-options"},
 			stdout: heredoc.Doc(`{
+			"baseline": "",
+			"ca-cert": "",
+			"cache-ttl": 24,
+			"client-cert": "",
+			"client-key": "",
+			"concurrency": 8,
 			"debug-options": true,
 			"endpoint": {
 				"core": "https://core.listen.dev",
 				"npm": "https://npm.listen.dev",
 				"pypi": "https://pypi-stage.listen.dev"
 			},
+			"fail-on": "",
+			"fail-on-codes": null,
+			"fail-on-problems": false,
 			"gh-owner": "",
 			"gh-pull-id": 0,
 			"gh-repo": "",
@@ -565,19 +1091,30 @@ Global Flags:
 			"ignore-deptypes": [
 				110
 			],
+			"ignore-groups": null,
 			"ignore-packages": null,
 			"jq": "",
 			"json": false,
 			"jwt-token": "",
 			"lockfiles": [
 				"package-lock.json",
+				"pnpm-lock.yaml",
 				"poetry.lock"
 			],
 			"loglevel": "info",
+			"no-cache": false,
 			"npm-registry": "https://registry.npmjs.org",
+			"offline": false,
+			"proxy": "",
+			"pypi-registry": "https://pypi.org",
+			"rate-limit": 0,
+			"refresh": false,
 			"reporter": [],
+			"retries": 3,
 			"select": "",
-			"timeout": 60
+			"timeout": 60,
+			"verdicts-bundle": "",
+			"write-baseline": ""
 		}
 		`),
 			stderr: "Running without a configuration file\n",
@@ -593,12 +1130,21 @@ Global Flags:
 			cmdline: []string{"in", "--debug-options", "--config", path.Join(cwd, "testdata", "config_lockfiles.yaml")},
 			stdout: heredoc.Doc(`Using config file: _CWD_/testdata/config_lockfiles.yaml
 		{
+			"baseline": "",
+			"ca-cert": "",
+			"cache-ttl": 24,
+			"client-cert": "",
+			"client-key": "",
+			"concurrency": 8,
 			"debug-options": true,
 			"endpoint": {
 				"core": "https://core.listen.dev",
 				"npm": "https://npm.listen.dev",
 				"pypi": "https://pypi.listen.dev"
 			},
+			"fail-on": "",
+			"fail-on-codes": null,
+			"fail-on-problems": false,
 			"gh-owner": "leodido",
 			"gh-pull-id": 78991,
 			"gh-repo": "go-urn",
@@ -606,6 +1152,7 @@ Global Flags:
 			"ignore-deptypes": [
 				110
 			],
+			"ignore-groups": null,
 			"ignore-packages": null,
 			"jq": "",
 			"json": false,
@@ -616,12 +1163,21 @@ Global Flags:
 				"monorepo/sub/poetry.lock"
 			],
 			"loglevel": "info",
+			"no-cache": false,
 			"npm-registry": "https://registry.npmjs.org",
+			"offline": false,
+			"proxy": "",
+			"pypi-registry": "https://pypi.org",
+			"rate-limit": 0,
+			"refresh": false,
 			"reporter": [
 				33
 			],
+			"retries": 3,
 			"select": "",
-			"timeout": 2223
+			"timeout": 2223,
+			"verdicts-bundle": "",
+			"write-baseline": ""
 		}
 		`),
 			stderr: "",
@@ -637,12 +1193,21 @@ Global Flags:
 			cmdline: []string{"in", path.Join(cwd, "testdata", "monorepo"), "--debug-options"},
 			stdout: heredoc.Doc(`Using config file: _CWD_/testdata/monorepo/.lstn.yaml
 		{
+			"baseline": "",
+			"ca-cert": "",
+			"cache-ttl": 24,
+			"client-cert": "",
+			"client-key": "",
+			"concurrency": 8,
 			"debug-options": true,
 			"endpoint": {
 				"core": "https://core.listen.dev",
 				"npm": "https://npm.listen.dev",
 				"pypi": "https://pypi.listen.dev"
 			},
+			"fail-on": "",
+			"fail-on-codes": null,
+			"fail-on-problems": false,
 			"gh-owner": "",
 			"gh-pull-id": 0,
 			"gh-repo": "",
@@ -650,6 +1215,7 @@ Global Flags:
 			"ignore-deptypes": [
 				110
 			],
+			"ignore-groups": null,
 			"ignore-packages": null,
 			"jq": "",
 			"json": false,
@@ -658,10 +1224,19 @@ Global Flags:
 				"sub/poetry.lock"
 			],
 			"loglevel": "info",
+			"no-cache": false,
 			"npm-registry": "https://registry.npmjs.org",
+			"offline": false,
+			"proxy": "",
+			"pypi-registry": "https://pypi.org",
+			"rate-limit": 0,
+			"refresh": false,
 			"reporter": [],
+			"retries": 3,
 			"select": "",
-			"timeout": 60
+			"timeout": 60,
+			"verdicts-bundle": "",
+			"write-baseline": ""
 		}
 		`),
 			stderr: "",
@@ -677,12 +1252,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -690,21 +1274,34 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [
 		44
 	],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -728,12 +1325,21 @@ Global Flags:
 				"111",
 			},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "leodido",
 	"gh-pull-id": 111,
 	"gh-repo": "go-urn",
@@ -741,22 +1347,35 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [
 		33,
 		22
 	],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -786,12 +1405,21 @@ Global Flags:
 				"111",
 			},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "leodido",
 	"gh-pull-id": 111,
 	"gh-repo": "go-urn",
@@ -799,22 +1427,35 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [
 		33,
 		44
 	],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -833,12 +1474,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "fntlnz",
 	"gh-pull-id": 654,
 	"gh-repo": "",
@@ -846,19 +1496,32 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.com",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -898,8 +1561,11 @@ Global Flags:
 		// ],
 		// 	"loglevel": "info",
 		// 	"npm-registry": "https://registry.npmjs.org",
+		// 	"pypi-registry": "https://pypi.org",
 		// 	"reporter": [],
+		// 	"resolution": "lockfile",
 		// 	"select": "",
+		// 	"strict": false,
 		// 	"timeout": 60
 		// }
 		// `),
@@ -916,12 +1582,21 @@ Global Flags:
 			},
 			cmdline: []string{"in", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -929,19 +1604,30 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "some123jwt.aaa.xxx",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -957,12 +1643,21 @@ Global Flags:
 			cmdline: []string{"scan", "--debug-options", "--config", path.Join(cwd, "testdata", "config_reporting.yaml")},
 			stdout: heredoc.Doc(`Using config file: _CWD_/testdata/config_reporting.yaml
 {
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "leodido",
 	"gh-pull-id": 78999,
 	"gh-repo": "go-urn",
@@ -970,21 +1665,34 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://some.io",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [
 		33
 	],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 2222
+	"strict": false,
+	"timeout": 2222,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "",
@@ -1004,12 +1712,21 @@ Global Flags:
 			cmdline: []string{"scan", "--debug-options", "--config", path.Join(cwd, "testdata", "config_reporting.yaml")},
 			stdout: heredoc.Doc(`Using config file: _CWD_/testdata/config_reporting.yaml
 {
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm-stage.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "leodido",
 	"gh-pull-id": 887755,
 	"gh-repo": "go-conventionalcommits",
@@ -1017,21 +1734,34 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://some.io",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [
 		33
 	],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 33331
+	"strict": false,
+	"timeout": 33331,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "",
@@ -1052,12 +1782,21 @@ Global Flags:
 			cmdline: []string{"scan", "--debug-options", "--config", path.Join(cwd, "testdata", "config_reporting.yaml")},
 			stdout: heredoc.Doc(`Using config file: _CWD_/testdata/config_reporting.yaml
 {
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm-stage.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "leodido",
 	"gh-pull-id": 887755,
 	"gh-repo": "go-conventionalcommits",
@@ -1065,21 +1804,34 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://some.io",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [
 		44
 	],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 33331
+	"strict": false,
+	"timeout": 33331,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "",
@@ -1094,12 +1846,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options", "--reporter", "gh-pull-comment,gh-pull-comment", "-r", "gh-pull-check,gh-pull-comment"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1107,22 +1868,35 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [
 		22,
 		44
 	],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1137,12 +1911,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options", "--reporter", "pro"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1150,21 +1933,34 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [
 		55
 	],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1180,12 +1976,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options", "--ignore-deptypes", "dev,dev", "--ignore-deptypes", "optional,dev"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1195,19 +2000,32 @@ Global Flags:
 		66,
 		132
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1274,12 +2092,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "reviewdog",
 	"gh-pull-id": 285,
 	"gh-repo": "reviewdog",
@@ -1287,19 +2114,32 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1315,12 +2155,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options", "--ignore-packages", "@vue/devtools,anotherpackage"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1328,6 +2177,7 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"@vue/devtools",
 		"anotherpackage"
@@ -1337,13 +2187,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1359,12 +2221,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options", "--ignore-packages", "@vue/devtools", "--ignore-packages", "anotherpackage"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1372,6 +2243,7 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"@vue/devtools",
 		"anotherpackage"
@@ -1381,13 +2253,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1403,12 +2287,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options", "--ignore-packages", "@vue/devtools"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1416,6 +2309,7 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"@vue/devtools"
 	],
@@ -1424,13 +2318,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1446,12 +2352,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1459,6 +2374,7 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"@vue/devtools"
 	],
@@ -1467,13 +2383,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1489,12 +2417,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1502,6 +2439,7 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"@vue/devtools"
 	],
@@ -1510,13 +2448,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1532,12 +2482,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1545,6 +2504,7 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"@vue/devtools",
 		"anotherpackage"
@@ -1554,13 +2514,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1576,12 +2548,21 @@ Global Flags:
 			cmdline: []string{"scan", "--debug-options", "--ignore-packages", "aaaaa", "--config", path.Join(cwd, "testdata", "config_filtering.yaml")},
 			stdout: heredoc.Doc(`Using config file: _CWD_/testdata/config_filtering.yaml
 {
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1590,6 +2571,7 @@ Global Flags:
 		110,
 		88
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"aaaaa"
 	],
@@ -1598,13 +2580,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://smtg.io",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 1111
+	"strict": false,
+	"timeout": 1111,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "",
@@ -1620,12 +2614,21 @@ Global Flags:
 			cmdline: []string{"scan", "--debug-options", "--config", path.Join(cwd, "testdata", "config_filtering.yaml")},
 			stdout: heredoc.Doc(`Using config file: _CWD_/testdata/config_filtering.yaml
 {
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1634,6 +2637,7 @@ Global Flags:
 		110,
 		88
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"donotprocessme",
 		"metoo"
@@ -1643,13 +2647,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://smtg.io",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 1111
+	"strict": false,
+	"timeout": 1111,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "",
@@ -1666,12 +2682,21 @@ Global Flags:
 			cmdline: []string{"scan", "--debug-options", "--config", path.Join(cwd, "testdata", "config_filtering.yaml")},
 			stdout: heredoc.Doc(`Using config file: _CWD_/testdata/config_filtering.yaml
 {
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1680,6 +2705,7 @@ Global Flags:
 		110,
 		88
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"overrideThoseFromConfig"
 	],
@@ -1688,13 +2714,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://smtg.io",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 1111
+	"strict": false,
+	"timeout": 1111,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "",
@@ -1713,6 +2751,44 @@ Global Flags:
 			stderr:  "Running without a configuration file\nError: reporter must be 'gh-pull-check', 'gh-pull-comment', 'gh-pull-review', 'pro'; got wrong\n",
 			errstr:  "reporter must be 'gh-pull-check', 'gh-pull-comment', 'gh-pull-review', 'pro'; got wrong",
 		},
+		// LSTN_OFFLINE=true lstn scan --debug-options
+		{
+			name: "LSTN_OFFLINE=true lstn scan --debug-options",
+			envvar: map[string]string{
+				"LSTN_OFFLINE": "true",
+			},
+			cmdline: []string{"scan", "--debug-options"},
+			stdout:  "",
+			stderr:  "Running without a configuration file\nError: invalid configuration options/flags\n       verdicts bundle is mandatory when using --offline\n",
+			errstr:  "invalid configuration options/flags\n       verdicts bundle is mandatory when using --offline",
+		},
+		// LSTN_CLIENT_KEY=... lstn in --debug-options
+		{
+			name: "LSTN_CLIENT_KEY=... lstn in --debug-options",
+			envvar: map[string]string{
+				"LSTN_CLIENT_KEY": "commands_integration_test.go",
+			},
+			cmdline: []string{"in", "--debug-options"},
+			stdout:  "",
+			stderr:  "Running without a configuration file\nError: invalid configuration options/flags\n       client certificate is mandatory when using --client-key\n",
+			errstr:  "invalid configuration options/flags\n       client certificate is mandatory when using --client-key",
+		},
+		// lstn to react --proxy invalid --debug-options
+		{
+			name:    "lstn to react --proxy invalid --debug-options",
+			cmdline: []string{"to", "react", "--proxy", "invalid", "--debug-options"},
+			stdout:  "",
+			stderr:  "Running without a configuration file\nError: invalid configuration options/flags\n       proxy must be a valid URL\n",
+			errstr:  "invalid configuration options/flags\n       proxy must be a valid URL",
+		},
+		// lstn scan --offline --verdicts-bundle testdata/missing.json --debug-options
+		{
+			name:    "lstn scan --offline --verdicts-bundle testdata/missing.json --debug-options",
+			cmdline: []string{"scan", "--offline", "--verdicts-bundle", "testdata/missing.json", "--debug-options"},
+			stdout:  "",
+			stderr:  "Running without a configuration file\nError: couldn't read the verdicts bundle: open testdata/missing.json: no such file or directory\n",
+			errstr:  "couldn't read the verdicts bundle: open testdata/missing.json: no such file or directory",
+		},
 		// lstn scan --ignore-deptypes dev,peer,dev --debug-options
 		{
 			name: "lstn scan --ignore-deptypes dev,peer,dev --debug-options",
@@ -1722,12 +2798,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--ignore-deptypes", "dev,peer,dev", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1737,19 +2822,32 @@ Global Flags:
 		66,
 		88
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1765,12 +2863,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1780,19 +2887,32 @@ Global Flags:
 		66,
 		88
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1810,12 +2930,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options", "--ignore-deptypes", "optional"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1826,19 +2955,32 @@ Global Flags:
 		66,
 		88
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -1854,12 +2996,21 @@ Global Flags:
 			cmdline: []string{"scan", "--debug-options", "--config", path.Join(cwd, "testdata", "config_filtering.yaml")},
 			stdout: heredoc.Doc(`Using config file: _CWD_/testdata/config_filtering.yaml
 {
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1868,6 +3019,7 @@ Global Flags:
 		110,
 		88
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"donotprocessme",
 		"metoo"
@@ -1877,13 +3029,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://smtg.io",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 1111
+	"strict": false,
+	"timeout": 1111,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "",
@@ -1900,12 +3064,21 @@ Global Flags:
 			cmdline: []string{"scan", "--debug-options", "--config", path.Join(cwd, "testdata", "config_filtering.yaml")},
 			stdout: heredoc.Doc(`Using config file: _CWD_/testdata/config_filtering.yaml
 {
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1914,6 +3087,7 @@ Global Flags:
 		110,
 		66
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"donotprocessme",
 		"metoo"
@@ -1923,13 +3097,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://smtg.io",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 1111
+	"strict": false,
+	"timeout": 1111,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "",
@@ -1947,12 +3133,21 @@ Global Flags:
 			cmdline: []string{"scan", "--debug-options", "--config", path.Join(cwd, "testdata", "config_filtering.yaml")},
 			stdout: heredoc.Doc(`Using config file: _CWD_/testdata/config_filtering.yaml
 {
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -1961,6 +3156,7 @@ Global Flags:
 		110,
 		66
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"overrideThoseFromConfig"
 	],
@@ -1969,13 +3165,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://smtg.io",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 1111
+	"strict": false,
+	"timeout": 1111,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "",
@@ -1993,12 +3201,21 @@ Global Flags:
 			cmdline: []string{"scan", "--debug-options", "--config", path.Join(cwd, "testdata", "config_filtering.yaml"), "--ignore-deptypes", "dev,optional"},
 			stdout: heredoc.Doc(`Using config file: _CWD_/testdata/config_filtering.yaml
 {
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -2009,6 +3226,7 @@ Global Flags:
 		132,
 		88
 	],
+	"ignore-groups": null,
 	"ignore-packages": [
 		"donotprocessme",
 		"metoo"
@@ -2018,13 +3236,25 @@ Global Flags:
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://smtg.io",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "",
-	"timeout": 1111
+	"strict": false,
+	"timeout": 1111,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "",
@@ -2039,12 +3269,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options", "--select", `@.severity == "high"`},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -2052,19 +3291,32 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "@.severity == \"high\"",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -2080,12 +3332,21 @@ Global Flags:
 			},
 			cmdline: []string{"scan", "--debug-options"},
 			stdout: heredoc.Doc(`{
+	"baseline": "",
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"concurrency": 8,
 	"debug-options": true,
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -2093,19 +3354,32 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"resolution": "lockfile",
+	"retries": 3,
 	"select": "\"network\" in @.categories",
-	"timeout": 60
+	"strict": false,
+	"timeout": 60,
+	"verdicts-bundle": "",
+	"write-baseline": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -2121,12 +3395,22 @@ Global Flags:
 			},
 			cmdline: []string{"to", "--debug-options", "-s", `(@.file !~ "^advisory" && @.message != "")`},
 			stdout: heredoc.Doc(`{
+	"ca-cert": "",
+	"cache-ttl": 24,
+	"client-cert": "",
+	"client-key": "",
+	"compare": false,
+	"concurrency": 8,
 	"debug-options": true,
+	"ecosystem": "npm",
 	"endpoint": {
 		"core": "https://core.listen.dev",
 		"npm": "https://npm.listen.dev",
 		"pypi": "https://pypi.listen.dev"
 	},
+	"fail-on": "",
+	"fail-on-codes": null,
+	"fail-on-problems": false,
 	"gh-owner": "",
 	"gh-pull-id": 0,
 	"gh-repo": "",
@@ -2134,19 +3418,29 @@ Global Flags:
 	"ignore-deptypes": [
 		110
 	],
+	"ignore-groups": null,
 	"ignore-packages": null,
 	"jq": "",
 	"json": false,
 	"jwt-token": "",
 	"lockfiles": [
 		"package-lock.json",
+		"pnpm-lock.yaml",
 		"poetry.lock"
 	],
 	"loglevel": "info",
+	"no-cache": false,
 	"npm-registry": "https://registry.npmjs.org",
+	"offline": false,
+	"proxy": "",
+	"pypi-registry": "https://pypi.org",
+	"rate-limit": 0,
+	"refresh": false,
 	"reporter": [],
+	"retries": 3,
 	"select": "(@.file !~ \"^advisory\" \u0026\u0026 @.message != \"\")",
-	"timeout": 60
+	"timeout": 60,
+	"verdicts-bundle": ""
 }
 `),
 			stderr: "Running without a configuration file\n",
@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package diff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/listendev/lstn/internal/project"
+	"github.com/listendev/lstn/pkg/cmd/arguments"
+	"github.com/listendev/lstn/pkg/cmd/groups"
+	"github.com/listendev/lstn/pkg/cmd/options"
+	"github.com/listendev/lstn/pkg/cmd/packagesprinter"
+	"github.com/listendev/lstn/pkg/cmd/report"
+	"github.com/listendev/lstn/pkg/cmd/verdicts"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/listendev/lstn/pkg/diff"
+	"github.com/listendev/lstn/pkg/listen"
+	"github.com/listendev/lstn/pkg/policy"
+	reporterfactory "github.com/listendev/lstn/pkg/reporter/factory"
+	"github.com/listendev/lstn/pkg/suppress"
+	"github.com/spf13/cobra"
+)
+
+var _, filename, _, _ = runtime.Caller(0)
+
+func New(ctx context.Context) (*cobra.Command, error) {
+	diffCmd := &cobra.Command{
+		Use:                   "diff <old> <new>",
+		GroupID:               groups.Core.ID,
+		DisableFlagsInUseLine: true,
+		Short:                 "Inspect the verdicts for the dependencies that changed between two lock files",
+		Long: `Query listen.dev for the verdicts of the dependencies that changed between two lock files.
+
+Each side is either the path of a lock file (package-lock.json, yarn.lock, pnpm-lock.yaml, poetry.lock, uv.lock, etc),
+or a git revision and the path of a lock file, relative to the root of the git repository, separated by a colon (eg., main:package-lock.json).
+The git revisions are read from the git repository containing the current working directory, without checking them out.
+
+It lists the packages that the new lock file adds, removes, upgrades, or downgrades,
+and it gets the verdicts of the new package versions only.
+
+Use the --markdown flag to output the changes as a markdown report (eg., for the description of a pull request).
+
+Use the --fail-on, --fail-on-codes, and --fail-on-problems flags to exit with a non-zero status code (see lstn help exit)
+when some new package versions have verdicts with a severity, or with some codes, or when they have problems.
+
+The rules in the .lstnignore.yaml file of the current working directory suppress the verdicts they match.`,
+		Example: `  lstn diff old/package-lock.json package-lock.json
+  lstn diff main:package-lock.json package-lock.json
+  lstn diff HEAD~1:poetry.lock HEAD:poetry.lock
+  lstn diff origin/main:web/yarn.lock web/yarn.lock --markdown
+  lstn diff main:package-lock.json package-lock.json --fail-on high --reporter gh-pull-comment`,
+		Args: cobra.ExactArgs(2), // Executes before RunE
+		Annotations: map[string]string{
+			"source": project.GetSourceURL(filename),
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			ctx = c.Context()
+
+			// Obtain the local options from the context
+			opts, err := pkgcontext.GetOptionsFromContext(ctx, pkgcontext.DiffKey)
+			if err != nil {
+				return err
+			}
+			diffOpts, ok := opts.(*options.Diff)
+			if !ok {
+				return fmt.Errorf("couldn't obtain options for the current child command")
+			}
+
+			if diffOpts.DebugOptions {
+				c.Println(diffOpts.AsJSON())
+
+				return nil
+			}
+
+			io := c.Context().Value(pkgcontext.IOStreamsKey).(*iostreams.IOStreams)
+			cs := io.ColorScheme()
+
+			cwd, err := arguments.GetDirectory([]string{})
+			if err != nil {
+				return fmt.Errorf("couldn't get to know the current working directory")
+			}
+
+			// Read the package versions that the two lock files pin
+			oldSide := diff.ParseSide(args[0])
+			newSide := diff.ParseSide(args[1])
+			oldEco, before, err := oldSide.Read(cwd)
+			if err != nil {
+				return err
+			}
+			eco, after, err := newSide.Read(cwd)
+			if err != nil {
+				return err
+			}
+			if oldEco != eco {
+				return fmt.Errorf("cannot compare %s with %s: they belong to different ecosystems", oldSide, newSide)
+			}
+
+			// Drop the verdicts that the suppression rules of the project match
+			rules, err := suppress.Load(filepath.Join(cwd, suppress.Filename))
+			if err != nil {
+				return err
+			}
+			for _, warning := range rules.ExpiredWarnings(time.Now()) {
+				c.PrintErrln(cs.WarningIcon(), warning)
+			}
+
+			changes := diff.Compute(eco, before, after)
+
+			// Query for the verdicts of the new package versions only
+			notAnalysed := []listen.NotAnalysed{}
+			failures := &listen.PartialResultsError{}
+			names, versions := changes.NewVersions()
+			if len(names) > 0 {
+				reqs, reqsErr := listen.NewBulkVerdictsRequestsFromStrings(names, versions, diffOpts.Expression)
+				if reqsErr != nil {
+					return reqsErr
+				}
+
+				io.StartProgressIndicator()
+				res, _, resErr := listen.BulkPackages(reqs, listen.WithContext(ctx), listen.WithEcosystem(eco))
+				io.StopProgressIndicator()
+
+				failures.Total = len(reqs)
+				var partialErr *listen.PartialResultsError
+				switch {
+				case errors.As(resErr, &partialErr):
+					// Render the verdicts of the other packages anyway
+					failures.Errors = partialErr.Errors
+					for _, n := range partialErr.NotAnalysed() {
+						n.Source = newSide.String()
+						notAnalysed = append(notAnalysed, n)
+					}
+				case resErr != nil:
+					return resErr
+				}
+
+				if res != nil {
+					changes.Attach(verdicts.Suppress(rules, eco, *res))
+				}
+			}
+
+			suppressed := rules.Take()
+			switch {
+			case diffOpts.JSON:
+				changesJSON := new(bytes.Buffer)
+				if err := json.NewEncoder(changesJSON).Encode(map[string]diff.Changes{"changes": changes}); err != nil {
+					return fmt.Errorf("couldn't JSON encode the changes")
+				}
+				if err := diffOpts.GetOutput(ctx, changesJSON, io.Out); err != nil {
+					return err
+				}
+				if err := verdicts.WriteSummary(io.ErrOut, verdicts.Summary{Suppressed: suppressed, NotAnalysed: notAnalysed}); err != nil {
+					return err
+				}
+			case diffOpts.Markdown:
+				markdownReport := report.NewChangesMarkdownReport()
+				markdownReport.WithOutput(io.Out)
+				markdownReport.WithSuppressed(suppressed)
+				if err := markdownReport.Render(changes); err != nil {
+					return err
+				}
+			default:
+				tablePrinter := packagesprinter.NewTablePrinter(io, packagesprinter.WithSuppressed(suppressed), packagesprinter.WithNotAnalysed(notAnalysed))
+				if err := tablePrinter.RenderChanges(changes); err != nil {
+					return err
+				}
+			}
+
+			// Let the reporters tell how many verdicts the suppression rules dropped
+			c.SetContext(context.WithValue(c.Context(), pkgcontext.SuppressedKey, suppressed))
+			src := newSide.String()
+			if err := reporterfactory.Exec(c, diffOpts.Reporting, changes, &src); err != nil {
+				return err
+			}
+
+			var failuresErr error
+			if len(failures.Errors) > 0 {
+				failuresErr = failures
+			}
+
+			// Fail on the new package versions violating the policy, once reported
+			verdicts.Check(policy.FromContext(ctx), changes.Response())
+
+			return policy.Result(ctx, failuresErr)
+		},
+	}
+
+	// Obtain the local options
+	diffOpts, err := options.NewDiff()
+	if err != nil {
+		return nil, err
+	}
+
+	// Local flags will only run when this command is called directly
+	diffOpts.Attach(diffCmd, []string{"--ignore-packages", "--ignore-deptypes", "--ignore-groups", "--lockfiles", "jwt-token", "core-endpoint"})
+
+	// Pass the options through the context
+	ctx = context.WithValue(ctx, pkgcontext.DiffKey, diffOpts)
+	diffCmd.SetContext(ctx)
+
+	return diffCmd, nil
+}
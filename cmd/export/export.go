@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package export
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/XANi/goneric"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/listendev/lstn/internal/project"
+	"github.com/listendev/lstn/pkg/cache"
+	"github.com/listendev/lstn/pkg/cmd/arguments"
+	"github.com/listendev/lstn/pkg/cmd/groups"
+	"github.com/listendev/lstn/pkg/cmd/options"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/listendev/lstn/pkg/listen"
+	listentype "github.com/listendev/lstn/pkg/listen/type"
+	"github.com/listendev/lstn/pkg/lockfile"
+	"github.com/listendev/lstn/pkg/npm"
+	"github.com/listendev/lstn/pkg/pypi"
+	"github.com/spf13/cobra"
+)
+
+var _, filename, _, _ = runtime.Caller(0)
+
+func New(ctx context.Context) (*cobra.Command, error) {
+	exportCmd := &cobra.Command{
+		Use:                   "export [path]",
+		GroupID:               groups.Core.ID,
+		DisableFlagsInUseLine: true,
+		Short:                 "Export the verdicts for your dependencies tree into a bundle",
+		Long: `Query listen.dev for the verdicts of all the dependencies in your project, and write them into a verdicts bundle.
+
+Given a project directory containing lock files (package-lock.json, yarn.lock, pnpm-lock.yaml, poetry.lock, uv.lock, etc),
+it writes the verdicts of every package version they pin into a single file, along with its SHA256 checksum.
+
+The in, scan, and to commands answer from such a bundle, without querying listen.dev, when using the --offline and --verdicts-bundle flags.
+This is handy for the build environments without access to the internet.
+In offline mode, they list the packages the bundle does not cover as not analysed, and exit with status code 3.`,
+		Example: `  lstn export
+  lstn export --output /mnt/share/verdicts.json
+  lstn export /we/snitch --lockfiles package-lock.json,poetry.lock
+
+  # Then, in the offline environment
+  lstn in /we/snitch --offline --verdicts-bundle /mnt/share/verdicts.json`,
+		Args:              arguments.SingleDirectory, // Executes before RunE
+		ValidArgsFunction: arguments.SingleDirectoryActiveHelp,
+		Annotations: map[string]string{
+			"source":   project.GetSourceURL(filename),
+			"subgroup": groups.WithDirectory.String(),
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			ctx = c.Context()
+
+			// Obtain the local options from the context
+			opts, err := pkgcontext.GetOptionsFromContext(ctx, pkgcontext.ExportKey)
+			if err != nil {
+				return err
+			}
+			exportOpts, ok := opts.(*options.Export)
+			if !ok {
+				return fmt.Errorf("couldn't obtain options for the current child command")
+			}
+
+			if exportOpts.DebugOptions {
+				c.Println(exportOpts.AsJSON())
+
+				return nil
+			}
+
+			if exportOpts.Offline {
+				return fmt.Errorf("cannot export the verdicts in offline mode")
+			}
+
+			io := c.Context().Value(pkgcontext.IOStreamsKey).(*iostreams.IOStreams)
+			cs := io.ColorScheme()
+
+			// Obtain the target directory that we want to export the verdicts of
+			targetDir, err := arguments.GetDirectory(args)
+			if err != nil {
+				return fmt.Errorf("couldn't get to know which directory you want me to export the verdicts of")
+			}
+
+			// Lookup the lock files (relative to the working directory)
+			foundLockfiles, notFoundLockfiles := arguments.GetLockfiles(targetDir, exportOpts.Lockfiles)
+			if len(notFoundLockfiles) > 0 {
+				notFoundErrors := []string{}
+				for _, errs := range notFoundLockfiles {
+					notFoundErrors = append(notFoundErrors, goneric.MapSlice(func(e error) string {
+						return e.Error()
+					}, errs)...)
+				}
+				sort.Strings(notFoundErrors)
+				c.PrintErrln(cs.WarningIcon(), strings.Join(notFoundErrors, fmt.Sprintf("\n%s ", cs.WarningIcon())))
+			}
+			if len(foundLockfiles) == 0 {
+				return fmt.Errorf("directory %s does not contain any lock file", targetDir)
+			}
+
+			paths := goneric.MapSliceKey(foundLockfiles)
+			sort.Strings(paths)
+
+			bundle := cache.NewBundle()
+			exported := 0
+			for _, lp := range paths {
+				lf := foundLockfiles[lp]
+				eco := lockfile.Ecosystem(lf)
+				prefix := cs.Blue(fmt.Sprintf("[%s ecosystem]", eco.Case()))
+
+				toAnalyse, err := readLockfile(filepath.Dir(lp), lf)
+				if err != nil {
+					if len(paths) == 1 {
+						return err
+					}
+					c.PrintErrln(cs.FailureIcon(), prefix, err.Error())
+
+					continue
+				}
+
+				io.StartProgressIndicator()
+				req, err := listen.NewAnalysisRequest(toAnalyse, listen.WithRequestContext())
+				if err != nil {
+					io.StopProgressIndicator()
+					if len(paths) == 1 {
+						return err
+					}
+					c.PrintErrln(cs.FailureIcon(), prefix, fmt.Sprintf("got an error requesting the analysis: %s", cs.Red(err.Error())))
+
+					continue
+				}
+
+				res, _, err := listen.Packages(req, listen.WithContext(ctx), listen.WithEcosystem(eco))
+				io.StopProgressIndicator()
+				if err != nil {
+					if len(paths) == 1 {
+						return err
+					}
+					c.PrintErrln(cs.FailureIcon(), prefix, fmt.Sprintf("got an error from the analysis endpoint: %s", cs.Red(err.Error())))
+
+					continue
+				}
+
+				if err := listen.AddToBundle(bundle, req, res, listen.WithEcosystem(eco)); err != nil {
+					return err
+				}
+				exported++
+				c.PrintErrln(cs.SuccessIcon(), prefix, fmt.Sprintf("exported the verdicts of %d packages in %s", len(*res), lp))
+			}
+			if exported == 0 {
+				return fmt.Errorf("couldn't export the verdicts of any lock file")
+			}
+
+			if err := bundle.WriteFile(exportOpts.Output); err != nil {
+				return fmt.Errorf("couldn't write the verdicts bundle: %w", err)
+			}
+			fmt.Fprintf(io.Out, "Wrote the verdicts bundle to %s\n", exportOpts.Output)
+
+			return nil
+		},
+	}
+
+	// Obtain the local options
+	exportOpts, err := options.NewExport()
+	if err != nil {
+		return nil, err
+	}
+
+	// Local flags will only run when this command is called directly
+	exportOpts.Attach(exportCmd, []string{"--ignore-packages", "--ignore-deptypes", "--ignore-groups", "--select", "--reporter", "--gh-owner", "--gh-repo", "--gh-pull-id", "--gh-token", "--jwt-token", "core-endpoint", "offline", "verdicts-bundle", "fail-on", "fail-on-codes", "fail-on-problems"})
+
+	// Pass the options through the context
+	ctx = context.WithValue(ctx, pkgcontext.ExportKey, exportOpts)
+	exportCmd.SetContext(ctx)
+
+	return exportCmd, nil
+}
+
+// readLockfile reads the input lock file in the input directory.
+func readLockfile(dir string, lf lockfile.Lockfile) (listentype.AnalysisRequester, error) {
+	var ret listentype.AnalysisRequester
+	var err error
+	switch lf {
+	case lockfile.PackageLockJSON:
+		ret, err = npm.GetPackageLockJSONFromDir(dir)
+	case lockfile.YarnLock:
+		ret, err = npm.GetYarnLockFromDir(dir)
+	case lockfile.PnpmLock:
+		ret, err = npm.GetPnpmLockFromDir(dir)
+	case lockfile.NpmShrinkwrapJSON:
+		ret, err = npm.GetNpmShrinkwrapJSONFromDir(dir)
+	case lockfile.BunLock:
+		ret, err = npm.GetBunLockFromDir(dir)
+	case lockfile.PoetryLock:
+		ret, err = pypi.GetPoetryLockFromDir(dir)
+	case lockfile.UvLock:
+		ret, err = pypi.GetUvLockFromDir(dir)
+	case lockfile.PdmLock:
+		ret, err = pypi.GetPdmLockFromDir(dir)
+	case lockfile.PipfileLock:
+		ret, err = pypi.GetPipfileLockFromDir(dir)
+	case lockfile.RequirementsTxt:
+		ret, err = pypi.GetRequirementsTxtFromDir(dir)
+	default:
+		return nil, fmt.Errorf("could not process %s yet", filepath.Join(dir, lf.String()))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not process %s yet: %w", filepath.Join(dir, lf.String()), err)
+	}
+
+	return ret, nil
+}
@@ -17,27 +17,35 @@ package in
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/XANi/goneric"
 	"github.com/cli/cli/pkg/iostreams"
 	"github.com/listendev/lstn/internal/project"
+	"github.com/listendev/lstn/pkg/baseline"
 	"github.com/listendev/lstn/pkg/cmd/arguments"
 	"github.com/listendev/lstn/pkg/cmd/groups"
 	"github.com/listendev/lstn/pkg/cmd/options"
 	"github.com/listendev/lstn/pkg/cmd/packagesprinter"
+	"github.com/listendev/lstn/pkg/cmd/verdicts"
 	pkgcontext "github.com/listendev/lstn/pkg/context"
 	"github.com/listendev/lstn/pkg/listen"
 	listentype "github.com/listendev/lstn/pkg/listen/type"
+	"github.com/listendev/lstn/pkg/lockfile"
 	"github.com/listendev/lstn/pkg/npm"
+	"github.com/listendev/lstn/pkg/policy"
 	"github.com/listendev/lstn/pkg/pypi"
+	"github.com/listendev/lstn/pkg/reporter"
 	reporterfactory "github.com/listendev/lstn/pkg/reporter/factory"
+	"github.com/listendev/lstn/pkg/suppress"
 	"github.com/listendev/pkg/ecosystem"
-	"github.com/listendev/pkg/lockfile"
 	"github.com/spf13/cobra"
 )
 
@@ -53,16 +61,37 @@ func New(ctx context.Context) (*cobra.Command, error) {
 		Long: `Query listen.dev for the verdicts of all the dependencies in your project.
 
 Using this command, you can audit all the dependencies of a project and obtain their verdicts.
-Given a project directory containing manifest files (package-lock.json, poetry.lock, etc),
+Given a project directory containing manifest files (package-lock.json, yarn.lock, pnpm-lock.yaml, poetry.lock, uv.lock, etc),
 it fetches the package names and versions of the project dependencies.
 
-The verdicts it returns are listed by the name of each package and its specified version.`,
+The verdicts it returns are listed by the name of each package and its specified version.
+
+Use the --offline flag to answer from a verdicts bundle (see lstn export) rather than from listen.dev.
+In such a case, it lists the packages the bundle does not cover as not analysed, and it exits with status code 3.
+
+Use the --fail-on, --fail-on-codes, and --fail-on-problems flags to exit with a non-zero status code (see lstn help exit)
+when some verdicts have a severity, or some codes, or when some packages have problems.
+
+The rules in the .lstnignore.yaml file of the project directory suppress the verdicts they match, with a reason, an owner, and an optional expiry date.
+The suppressed verdicts are listed apart, and they do not make the --fail-on flags fail.
+
+Use the --write-baseline flag to save a snapshot of the verdicts, and the --baseline flag to report only the packages and the verdicts
+that such a snapshot does not have. The verdicts already in the baseline do not make the --fail-on flags fail.`,
 		Example: `  lstn in
   lstn in .
   lstn in /we/snitch
   lstn in sub/dir
   lstn in --lockfiles poetry.lock,package-lock.json
-  lstn in /pyproj --lockfiles poetry.lock`,
+  lstn in /pyproj --lockfiles poetry.lock
+  lstn in /pyproj --lockfiles poetry.lock --ignore-groups dev,docs
+  lstn in --lockfiles yarn.lock
+  lstn in --lockfiles npm-shrinkwrap.json,bun.lock
+  lstn in /pyproj --lockfiles uv.lock,pdm.lock,Pipfile.lock
+  lstn in /pyproj --lockfiles requirements.txt
+  lstn in --offline --verdicts-bundle lstn-verdicts.json
+  lstn in --fail-on high --fail-on-codes TSN,DDN
+  lstn in --write-baseline lstn-baseline.json
+  lstn in --baseline lstn-baseline.json --reporter gh-pull-comment`,
 		Args:              arguments.SingleDirectory, // Executes before RunE
 		ValidArgsFunction: arguments.SingleDirectoryActiveHelp,
 		Annotations: map[string]string{
@@ -98,6 +127,21 @@ The verdicts it returns are listed by the name of each package and its specified
 				return fmt.Errorf("couldn't get to know on which directory you want me to listen in")
 			}
 
+			// Drop the verdicts that the suppression rules of the project match
+			rules, err := suppress.Load(filepath.Join(targetDir, suppress.Filename))
+			if err != nil {
+				return err
+			}
+			for _, warning := range rules.ExpiredWarnings(time.Now()) {
+				c.PrintErrln(cs.WarningIcon(), warning)
+			}
+
+			// Keep only what the baseline does not have, while recording the verdicts into the new one
+			base, err := baseline.New(inOpts.Baseline, inOpts.WriteBaseline)
+			if err != nil {
+				return err
+			}
+
 			// Lookup the lock files (relative to the working directory)
 			foundLockfiles, notFoundLockfiles := arguments.GetLockfiles(targetDir, inOpts.Lockfiles)
 			if len(notFoundLockfiles) > 0 {
@@ -117,6 +161,10 @@ The verdicts it returns are listed by the name of each package and its specified
 			}
 
 			numIterations := len(foundLockfiles)
+			// failures collects the packages the verdicts bundle does not cover
+			failures := &listen.PartialResultsError{}
+			// The verdicts the policy checks once the command reported them
+			checked := listen.Response{}
 			for lp, lf := range foundLockfiles {
 				// TODO: check that targetDir == filepath.Dir(lp) for extra safety?
 				dir := filepath.Dir(lp)
@@ -124,11 +172,20 @@ The verdicts it returns are listed by the name of each package and its specified
 
 				var toAnalyse listentype.AnalysisRequester
 				var lockfileErr error
+				var groups map[string][]string
 				switch eco {
 				case ecosystem.Npm:
 					switch lf {
 					case lockfile.PackageLockJSON:
 						toAnalyse, lockfileErr = npm.GetPackageLockJSONFromDir(dir)
+					case lockfile.YarnLock:
+						toAnalyse, lockfileErr = npm.GetYarnLockFromDir(dir)
+					case lockfile.PnpmLock:
+						toAnalyse, lockfileErr = npm.GetPnpmLockFromDir(dir)
+					case lockfile.NpmShrinkwrapJSON:
+						toAnalyse, lockfileErr = npm.GetNpmShrinkwrapJSONFromDir(dir)
+					case lockfile.BunLock:
+						toAnalyse, lockfileErr = npm.GetBunLockFromDir(dir)
 
 					default:
 						err := fmt.Errorf("could not process %s yet", lp)
@@ -141,9 +198,31 @@ The verdicts it returns are listed by the name of each package and its specified
 					}
 
 				case ecosystem.Pypi:
+					var pyLock pypi.PoetryLock
 					switch lf {
 					case lockfile.PoetryLock:
-						toAnalyse, lockfileErr = pypi.GetPoetryLockFromDir(dir)
+						pyLock, lockfileErr = pypi.GetPoetryLockFromDir(dir)
+					case lockfile.UvLock:
+						pyLock, lockfileErr = pypi.GetUvLockFromDir(dir)
+					case lockfile.PdmLock:
+						pyLock, lockfileErr = pypi.GetPdmLockFromDir(dir)
+					case lockfile.PipfileLock:
+						pyLock, lockfileErr = pypi.GetPipfileLockFromDir(dir)
+					case lockfile.RequirementsTxt:
+						var requirements pypi.RequirementsTxt
+						requirements, lockfileErr = pypi.GetRequirementsTxtFromDir(dir)
+						if lockfileErr != nil {
+							break
+						}
+						for _, warning := range requirements.Warnings() {
+							c.PrintErrln(cs.WarningIcon(), cs.Blue(fmt.Sprintf("[%s ecosystem]", eco.Case())), warning)
+						}
+						if len(requirements.Packages()) == 0 {
+							c.PrintErrln(cs.WarningIcon(), cs.Blue(fmt.Sprintf("[%s ecosystem]", eco.Case())), fmt.Sprintf("nothing to analyse in %s: no pinned requirements", lp))
+
+							continue
+						}
+						pyLock = requirements
 
 					default:
 						err := fmt.Errorf("could not process %s yet", lp)
@@ -154,6 +233,15 @@ The verdicts it returns are listed by the name of each package and its specified
 
 						continue
 					}
+					if lockfileErr != nil {
+						break
+					}
+					if len(inOpts.Ignore.Groups) > 0 && !pyLock.HasGroups() {
+						c.PrintErrln(cs.WarningIcon(), cs.Blue(fmt.Sprintf("[%s ecosystem]", eco.Case())), fmt.Sprintf("%s does not contain the dependency groups: not ignoring any", lp))
+					}
+					lockfileErr = pyLock.FilterOutByGroups(inOpts.Ignore.Groups...)
+					groups = pyLock.Groups()
+					toAnalyse = pyLock
 
 				case ecosystem.None:
 					err := fmt.Errorf("couldn't retrieve the ecosystem relative to the %s lock file", lp)
@@ -190,12 +278,25 @@ The verdicts it returns are listed by the name of each package and its specified
 				}
 
 				// Ask listen.dev to analyze the lockfile
-				res, resJSON, err := listen.Packages(
+				res, _, err := listen.Packages(
 					req,
 					listen.WithContext(ctx),
 					listen.WithEcosystem(eco),
-					listen.WithJSONOptions(inOpts.JSONFlags),
 				)
+				// The verdicts bundle may lack the analysis of this lock file while covering its packages
+				notAnalysed := []listen.NotAnalysed{}
+				if errors.Is(err, listen.ErrNotInBundle) {
+					var partialErr *listen.PartialResultsError
+					res, partialErr, err = bundledPackages(ctx, toAnalyse, eco)
+					if partialErr != nil {
+						failures.Total += partialErr.Total
+						failures.Errors = append(failures.Errors, partialErr.Errors...)
+						for _, n := range partialErr.NotAnalysed() {
+							n.Source = lp
+							notAnalysed = append(notAnalysed, n)
+						}
+					}
+				}
 				if err != nil {
 					io.StopProgressIndicator()
 					if numIterations == 1 {
@@ -205,24 +306,36 @@ The verdicts it returns are listed by the name of each package and its specified
 
 					continue
 				}
-				if resJSON != nil {
-					fmt.Fprintf(io.Out, "%s", resJSON)
-				}
 				if res == nil {
 					io.StopProgressIndicator()
 					c.PrintErrln(cs.WarningIcon(), cs.Blue(fmt.Sprintf("[%s ecosystem]", eco.Case())), "couldn't obtain the verdicts but got no error")
 
-					if numIterations == 1 {
-						return nil
+					continue
+				}
+				*res = verdicts.Suppress(rules, eco, *res)
+				suppressed := rules.Take()
+				*res = verdicts.CompareBaseline(base, eco, *res)
+				known := base.TakeKnown()
+				checked = append(checked, *res...)
+				io.StopProgressIndicator()
+
+				if inOpts.JSON {
+					resJSON, err := verdicts.JSON(ctx, inOpts.JSONFlags, res)
+					if err != nil {
+						return err
+					}
+					fmt.Fprintf(io.Out, "%s", resJSON)
+
+					if err := verdicts.WriteSummary(io.ErrOut, verdicts.Summary{Suppressed: suppressed, NotAnalysed: notAnalysed}); err != nil {
+						return err
 					}
 
 					continue
 				}
-				io.StopProgressIndicator()
 
 				c.Println(cs.SuccessIcon(), cs.Blue(fmt.Sprintf("[%s ecosystem]", eco.Case())), fmt.Sprintf("showing verdicts for %s...\n", lp))
 
-				tablePrinter := packagesprinter.NewTablePrinter(io)
+				tablePrinter := packagesprinter.NewTablePrinter(io, packagesprinter.WithGroups(groups), packagesprinter.WithSuppressed(suppressed), packagesprinter.WithKnown(known), packagesprinter.WithNotAnalysed(notAnalysed))
 				err = tablePrinter.RenderPackages(res)
 				if err != nil {
 					if numIterations == 1 {
@@ -233,7 +346,13 @@ The verdicts it returns are listed by the name of each package and its specified
 					continue
 				}
 
-				errExec := reporterfactory.Exec(c, inOpts.Reporting, *res, &lp)
+				// Let the reporters tell how many verdicts the suppression rules, and the baseline, dropped
+				c.SetContext(context.WithValue(c.Context(), pkgcontext.SuppressedKey, suppressed))
+				reportOpts := []reporter.Option{}
+				if base.Comparing() {
+					reportOpts = append(reportOpts, reporter.WithBaseline(known))
+				}
+				errExec := reporterfactory.Exec(c, inOpts.Reporting, *res, &lp, reportOpts...)
 				if errExec != nil {
 					if numIterations == 1 {
 						return errExec
@@ -244,7 +363,22 @@ The verdicts it returns are listed by the name of each package and its specified
 				}
 			}
 
-			return nil
+			if err := base.Write(); err != nil {
+				return err
+			}
+			if inOpts.WriteBaseline != "" {
+				c.PrintErrln(cs.SuccessIcon(), fmt.Sprintf("wrote the baseline into %s", inOpts.WriteBaseline))
+			}
+
+			var failuresErr error
+			if len(failures.Errors) > 0 {
+				failuresErr = failures
+			}
+
+			// Fail on the package versions violating the policy, once reported
+			verdicts.Check(policy.FromContext(ctx), checked)
+
+			return policy.Result(ctx, failuresErr)
 		},
 	}
 
@@ -266,3 +400,90 @@ The verdicts it returns are listed by the name of each package and its specified
 
 	return inCmd, nil
 }
+
+// lockedPackages returns the package versions the input lock file pins, sorted by name and version, once.
+func lockedPackages(lock listentype.AnalysisRequester) []npm.LockedVersion {
+	switch l := lock.(type) {
+	case interface {
+		Deps() map[string]npm.PackageLockDependency
+	}:
+		// The npm lock files key the dependencies by their node_modules path
+		return npm.LockedVersions(l.Deps())
+	case pypi.PoetryLock:
+		ret := []npm.LockedVersion{}
+		for _, pkg := range l.Packages() {
+			ret = append(ret, npm.LockedVersion{Name: pkg.Name, Version: pkg.Version})
+		}
+		sort.Slice(ret, func(i, j int) bool {
+			return ret[i].Name < ret[j].Name
+		})
+
+		return ret
+	}
+
+	return nil
+}
+
+// bundledPackages gets the verdicts of the packages the input lock file pins from the verdicts bundle.
+//
+// The returned error tells the packages the bundle does not cover.
+func bundledPackages(ctx context.Context, lock listentype.AnalysisRequester, eco ecosystem.Ecosystem) (*listen.Response, *listen.PartialResultsError, error) {
+	locked := lockedPackages(lock)
+	if len(locked) == 0 {
+		return nil, nil, listen.ErrNotInBundle
+	}
+
+	partialErr := &listen.PartialResultsError{Total: len(locked)}
+	reqs := []*listen.VerdictsRequest{}
+	for _, pkg := range locked {
+		if !registryVersion(eco, pkg.Version) {
+			// Eg., the packages coming from git repositories or tarballs
+			partialErr.Errors = append(partialErr.Errors, &listen.RequestError{Name: pkg.Name, Version: pkg.Version, Err: fmt.Errorf("not a %s registry version", eco.Case())})
+
+			continue
+		}
+		req, err := listen.NewVerdictsRequest([]string{pkg.Name, pkg.Version})
+		if err != nil {
+			partialErr.Errors = append(partialErr.Errors, &listen.RequestError{Name: pkg.Name, Version: pkg.Version, Err: err})
+
+			continue
+		}
+		reqs = append(reqs, req)
+	}
+	if len(reqs) == 0 {
+		return &listen.Response{}, partialErr, nil
+	}
+
+	res, _, err := listen.BulkPackages(
+		reqs,
+		listen.WithContext(ctx),
+		listen.WithEcosystem(eco),
+	)
+	bulkErr := &listen.PartialResultsError{}
+	switch {
+	case errors.As(err, &bulkErr):
+		partialErr.Errors = append(partialErr.Errors, bulkErr.Errors...)
+	case err != nil:
+		return nil, nil, err
+	}
+
+	return res, partialErr, nil
+}
+
+// registryVersion tells whether the input version is one the registry of the input ecosystem publishes.
+//
+// Eg., the npm lock files pin the packages coming from git repositories to their URLs.
+func registryVersion(eco ecosystem.Ecosystem, version string) bool {
+	switch eco {
+	case ecosystem.Npm:
+		_, err := semver.StrictNewVersion(version)
+
+		return err == nil
+	case ecosystem.Pypi:
+		_, err := pypi.NewVersion(version)
+
+		return err == nil
+	}
+
+	return false
+}
@@ -18,6 +18,7 @@ package root
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
@@ -27,12 +28,16 @@ import (
 
 	"github.com/XANi/goneric"
 	"github.com/cli/cli/pkg/iostreams"
+	cachecmd "github.com/listendev/lstn/cmd/cache"
 	"github.com/listendev/lstn/cmd/ci"
+	"github.com/listendev/lstn/cmd/diff"
+	"github.com/listendev/lstn/cmd/export"
 	"github.com/listendev/lstn/cmd/in"
 	"github.com/listendev/lstn/cmd/scan"
 	"github.com/listendev/lstn/cmd/to"
 	"github.com/listendev/lstn/cmd/version"
 	"github.com/listendev/lstn/internal/project"
+	"github.com/listendev/lstn/pkg/cache"
 	"github.com/listendev/lstn/pkg/cmd"
 	"github.com/listendev/lstn/pkg/cmd/arguments"
 	"github.com/listendev/lstn/pkg/cmd/flags"
@@ -43,7 +48,11 @@ import (
 	lstnviper "github.com/listendev/lstn/pkg/cmd/viper"
 	pkgcontext "github.com/listendev/lstn/pkg/context"
 	"github.com/listendev/lstn/pkg/jq"
+	"github.com/listendev/lstn/pkg/listen"
 	npmdeptype "github.com/listendev/lstn/pkg/npm/deptype"
+	"github.com/listendev/lstn/pkg/policy"
+	"github.com/listendev/lstn/pkg/ratelimit"
+	"github.com/listendev/lstn/pkg/transport"
 	lstnversion "github.com/listendev/lstn/pkg/version"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/cobra"
@@ -149,6 +158,17 @@ func New(ctx context.Context) (*Command, error) {
 							if (!hasDefault && flagValue != 0) || (hasDefault && fmt.Sprintf("%d", flagValue) != defaultVal) {
 								v.SetInt(int64(flagValue))
 							}
+						case bool:
+							// Store the flag value (it equals to false when no flag)
+							flagValue, _ := c.Flags().GetBool(flagName)
+							// Set the value coming from environment variable or config file (viper)
+							if viper.GetBool(flagName) {
+								v.SetBool(true)
+							}
+							// Flag value takes precedence nevertheless
+							if f.Changed {
+								v.SetBool(flagValue)
+							}
 						case string:
 							// Store the flag value (it equals to the default when no flag)
 							flagValue, _ := c.Flags().GetString(flagName)
@@ -233,6 +253,34 @@ func New(ctx context.Context) (*Command, error) {
 			var cancel context.CancelFunc
 			ctx, cancel = context.WithTimeout(c.Context(), time.Second*time.Duration(cfgOpts.Timeout))
 			ctx = context.WithValue(ctx, pkgcontext.ContextCancelFuncKey, cancel)
+			// Share the requests per second limit among all the HTTP clients
+			ctx = context.WithValue(ctx, pkgcontext.RateLimiterKey, ratelimit.New(cfgOpts.RateLimit))
+			// Share the HTTP transport (proxy, CA certificates, client certificate) among all the HTTP clients
+			httpTransport, err := transport.New(cfgOpts)
+			if err != nil {
+				return err
+			}
+			ctx = context.WithValue(ctx, pkgcontext.HTTPTransportKey, httpTransport)
+			// Collect the package versions violating the fail-on options
+			ctx = context.WithValue(ctx, pkgcontext.PolicyKey, policy.New(cfgOpts))
+			switch {
+			case cfgOpts.Offline:
+				// Answer from the verdicts bundle, never querying listen.dev
+				// The bundle cannot filter the verdicts server-side
+				if cfgOpts.Expression != "" {
+					return fmt.Errorf("cannot use --select in offline mode")
+				}
+				bundle, bundleErr := cache.ReadBundle(cfgOpts.Bundle)
+				if bundleErr != nil {
+					return bundleErr
+				}
+				ctx = context.WithValue(ctx, pkgcontext.VerdictsBundleKey, bundle)
+			case !cfgOpts.NoCache:
+				// Reuse the verdicts lstn already got for the same package versions
+				if verdictsCache, cacheErr := cache.NewFromConfig(cfgOpts); cacheErr == nil {
+					ctx = context.WithValue(ctx, pkgcontext.VerdictsCacheKey, verdictsCache)
+				}
+			}
 
 			io := iostreams.System()
 			ctx = context.WithValue(ctx, pkgcontext.IOStreamsKey, io)
@@ -282,6 +330,13 @@ func New(ctx context.Context) (*Command, error) {
 	// Setup the core group
 	rootCmd.AddGroup(&groups.Core)
 
+	// Setup the `cache` subcommand
+	cacheCmd, err := cachecmd.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rootCmd.AddCommand(cacheCmd)
+
 	// Setup the `ci` subcommand
 	ciCmd, err := ci.New(ctx)
 	if err != nil {
@@ -289,6 +344,20 @@ func New(ctx context.Context) (*Command, error) {
 	}
 	rootCmd.AddCommand(ciCmd)
 
+	// Setup the `diff` subcommand
+	diffCmd, err := diff.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rootCmd.AddCommand(diffCmd)
+
+	// Setup the `export` subcommand
+	exportCmd, err := export.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rootCmd.AddCommand(exportCmd)
+
 	// Setup the `in` subcommand
 	inCmd, err := in.New(ctx)
 	if err != nil {
@@ -365,10 +434,15 @@ func New(ctx context.Context) (*Command, error) {
 type ExitCode int
 
 const (
-	exitOK     ExitCode = 0
-	exitError  ExitCode = 1
-	exitCancel ExitCode = 2
-	exitAuth   ExitCode = 4
+	exitOK      ExitCode = 0
+	exitError   ExitCode = 1
+	exitCancel  ExitCode = 2
+	exitPartial ExitCode = 3
+	exitAuth    ExitCode = 4
+	// NOTE > The jq halt errors use 5 by default
+	exitSeverity ExitCode = 6
+	exitCodes    ExitCode = 7
+	exitProblems ExitCode = 8
 )
 
 // Go is called by main.main().
@@ -387,6 +461,25 @@ func (c *Command) Go() ExitCode {
 			return ExitCode(err.ExitCode())
 		}
 
+		// Some package versions violate the fail-on options
+		var violationErr *policy.ViolationError
+		if errors.As(err, &violationErr) {
+			switch violationErr.Rule {
+			case policy.SeverityRule:
+				return exitSeverity
+			case policy.CodesRule:
+				return exitCodes
+			case policy.ProblemsRule:
+				return exitProblems
+			}
+		}
+
+		// Some verdicts requests failed while other ones succeeded
+		var partialErr *listen.PartialResultsError
+		if errors.As(err, &partialErr) {
+			return exitPartial
+		}
+
 		return exitError
 	}
 
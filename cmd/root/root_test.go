@@ -63,13 +63,13 @@ func (suite *CmdAdditionalHelpSuite) SetupSuite() {
 	}
 
 	suite.expectedOuts = make(expectedOutsMap)
-	suite.expectedOuts[Config] = "# lstn configuration file\n\nThe `lstn` CLI looks for a configuration file `.lstn.yaml` in your `$HOME` or into the current working directory from which `lstn` is getting called.\n\nWhen invoking `lstn in <dir>` it also looks for `.lstn.yaml` into `<dir>`.\n\nIn this file you can set the values for the global `lstn` configurations.\nAnyways, notice that environment variables, and flags (if any) override the values in your configuration file.\n\nHere's an example of a configuration file (with the default values):\n\n```yaml\nendpoint: \n  core: \"https://core.listen.dev\"\n  npm: \"https://npm.listen.dev\"\n  pypi: \"https://pypi.listen.dev\"\nfiltering: \n  expression: \"...\"\n  ignore: \n    deptypes: \n      - \"...\"\n      - \"...\"\n    packages: \n      - \"...\"\n      - \"...\"\nlockfiles: \n  - \"...\"\n  - \"...\"\nloglevel: \"info\"\nregistry: \n  npm: \"https://registry.npmjs.org\"\nreporting: \n  github: \n    owner: \"...\"\n    pull: \n      id: 0\n    repo: \"...\"\n  types: \n    - \"...\"\n    - \"...\"\ntimeout: 60\ntoken: \n  github: \"...\"\n  jwt: \"...\"\n```\n"
+	suite.expectedOuts[Config] = "# lstn configuration file\n\nThe `lstn` CLI looks for a configuration file `.lstn.yaml` in your `$HOME` or into the current working directory from which `lstn` is getting called.\n\nWhen invoking `lstn in <dir>` it also looks for `.lstn.yaml` into `<dir>`.\n\nIn this file you can set the values for the global `lstn` configurations.\nAnyways, notice that environment variables, and flags (if any) override the values in your configuration file.\n\nHere's an example of a configuration file (with the default values):\n\n```yaml\ncache: \n  nocache: ...\n  refresh: ...\n  ttl: 24\nconcurrency: 8\nendpoint: \n  core: \"https://core.listen.dev\"\n  npm: \"https://npm.listen.dev\"\n  pypi: \"https://pypi.listen.dev\"\nfiltering: \n  expression: \"...\"\n  ignore: \n    deptypes: \n      - \"...\"\n      - \"...\"\n    groups: \n      - \"...\"\n      - \"...\"\n    packages: \n      - \"...\"\n      - \"...\"\nlockfiles: \n  - \"...\"\n  - \"...\"\nloglevel: \"info\"\nnetwork: \n  cacert: \"...\"\n  clientcert: \"...\"\n  clientkey: \"...\"\n  proxy: \"...\"\nofflinemode: \n  bundle: \"...\"\n  offline: ...\npolicy: \n  failon: \"...\"\n  failoncodes: \n    - \"...\"\n    - \"...\"\n  failonproblems: ...\nratelimit: 0\nregistry: \n  npm: \"https://registry.npmjs.org\"\n  pypi: \"https://pypi.org\"\nreporting: \n  github: \n    owner: \"...\"\n    pull: \n      id: 0\n    repo: \"...\"\n  types: \n    - \"...\"\n    - \"...\"\nretries: 3\ntimeout: 60\ntoken: \n  github: \"...\"\n  jwt: \"...\"\n```\n"
 
-	suite.expectedOuts[Environment] = "# lstn environment variables\n\nThe environment variables override any corresponding configuration setting.\n\nBut flags override them.\n\n`LSTN_CORE_ENDPOINT`: the listen.dev Core API endpoint\n\n`LSTN_GH_OWNER`: set the GitHub owner name (org|user)\n\n`LSTN_GH_PULL_ID`: set the GitHub pull request ID\n\n`LSTN_GH_REPO`: set the GitHub repository name\n\n`LSTN_GH_TOKEN`: set the GitHub token\n\n`LSTN_IGNORE_DEPTYPES`: the list of dependencies types to not process\n\n`LSTN_IGNORE_PACKAGES`: the list of packages to not process\n\n`LSTN_JWT_TOKEN`: set the listen.dev auth token\n\n`LSTN_LOCKFILES`: set one or more lock file paths (relative to the working dir) to lookup for\n\n`LSTN_LOGLEVEL`: set the logging level\n\n`LSTN_NPM_ENDPOINT`: the listen.dev endpoint emitting the NPM verdicts\n\n`LSTN_NPM_REGISTRY`: set a custom NPM registry\n\n`LSTN_PYPI_ENDPOINT`: the listen.dev endpoint emitting the PyPi verdicts\n\n`LSTN_REPORTER`: set one or more reporters to use\n\n`LSTN_SELECT`: filter the output verdicts using a jsonpath script expression (server-side)\n\n`LSTN_TIMEOUT`: set the timeout, in seconds\n\n"
+	suite.expectedOuts[Environment] = "# lstn environment variables\n\nThe environment variables override any corresponding configuration setting.\n\nBut flags override them.\n\n`LSTN_CA_CERT`: set a PEM file with the additional CA certificates to trust\n\n`LSTN_CACHE_TTL`: set for how many hours to reuse the cached verdicts\n\n`LSTN_CLIENT_CERT`: set a PEM file with the client certificate for mutual TLS\n\n`LSTN_CLIENT_KEY`: set a PEM file with the private key of the client certificate\n\n`LSTN_CONCURRENCY`: set the maximum number of concurrent requests\n\n`LSTN_CORE_ENDPOINT`: the listen.dev Core API endpoint\n\n`LSTN_FAIL_ON`: fail when some verdicts have the given severity (low, medium, high) or a higher one\n\n`LSTN_FAIL_ON_CODES`: fail when some verdicts have the given codes or code groups (eg., STN, TSN01)\n\n`LSTN_FAIL_ON_PROBLEMS`: fail when some packages have problems\n\n`LSTN_GH_OWNER`: set the GitHub owner name (org|user)\n\n`LSTN_GH_PULL_ID`: set the GitHub pull request ID\n\n`LSTN_GH_REPO`: set the GitHub repository name\n\n`LSTN_GH_TOKEN`: set the GitHub token\n\n`LSTN_IGNORE_DEPTYPES`: the list of dependencies types to not process\n\n`LSTN_IGNORE_GROUPS`: the list of dependency groups (eg., poetry groups) to not process\n\n`LSTN_IGNORE_PACKAGES`: the list of packages to not process\n\n`LSTN_JWT_TOKEN`: set the listen.dev auth token\n\n`LSTN_LOCKFILES`: set one or more lock file paths (relative to the working dir) to lookup for\n\n`LSTN_LOGLEVEL`: set the logging level\n\n`LSTN_NO_CACHE`: do not use the verdicts cache\n\n`LSTN_NPM_ENDPOINT`: the listen.dev endpoint emitting the NPM verdicts\n\n`LSTN_NPM_REGISTRY`: set a custom NPM registry\n\n`LSTN_OFFLINE`: answer from a verdicts bundle, without querying listen.dev\n\n`LSTN_PROXY`: set the proxy URL of the outgoing requests (defaults to the HTTPS_PROXY environment variable)\n\n`LSTN_PYPI_ENDPOINT`: the listen.dev endpoint emitting the PyPi verdicts\n\n`LSTN_PYPI_REGISTRY`: set a custom PyPi registry\n\n`LSTN_RATE_LIMIT`: set the maximum number of requests per second (0 means no limit)\n\n`LSTN_REFRESH`: ignore the cached verdicts, and cache the fresh ones\n\n`LSTN_REPORTER`: set one or more reporters to use\n\n`LSTN_RETRIES`: set how many times to retry the failed API requests\n\n`LSTN_SELECT`: filter the output verdicts using a jsonpath script expression (server-side)\n\n`LSTN_TIMEOUT`: set the timeout, in seconds\n\n`LSTN_VERDICTS_BUNDLE`: set the verdicts bundle (see lstn export) to answer from offline\n\n"
 
-	suite.expectedOuts[Manual] = "# lstn cheatsheet\n\n## Global Flags\n\nEvery child command inherits the following flags:\n\n```\n--config string   config file (default is $HOME/.lstn.yaml)\n```\n\n## `lstn ci`\n\nListen in on what your CI does.\n\n### `lstn ci enable`\n\nEnable the CI eavesdropping.\n\n#### Flags\n\n```\n--dir string   the directory where the jibril binary is\n```\n\n#### Config Flags\n\n```\n--core-endpoint string   the listen.dev Core API endpoint (default \"https://core.listen.dev\")\n--loglevel string        set the logging level (default \"info\")\n--timeout int            set the timeout, in seconds (default 60)\n```\n\n#### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n#### Token Flags\n\n```\n--gh-token string    set the GitHub token\n--jwt-token string   set the listen.dev auth token\n```\n\n### `lstn ci report`\n\nReport the most critical findings into GitHub pull requests.\n\n#### Config Flags\n\n```\n--core-endpoint string   the listen.dev Core API endpoint (default \"https://core.listen.dev\")\n--loglevel string        set the logging level (default \"info\")\n--timeout int            set the timeout, in seconds (default 60)\n```\n\n#### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n#### Reporting Flags\n\n```\n--gh-owner string   set the GitHub owner name (org|user)\n--gh-pull-id int    set the GitHub pull request ID\n--gh-repo string    set the GitHub repository name\n```\n\n#### Token Flags\n\n```\n--gh-token string    set the GitHub token\n--jwt-token string   set the listen.dev auth token\n```\n\n## `lstn completion <bash|fish|powershell|zsh>`\n\nGenerate the autocompletion script for the specified shell.\n\n### `lstn completion bash`\n\nGenerate the autocompletion script for bash.\n\n#### Flags\n\n```\n--no-descriptions   disable completion descriptions\n```\n\n### `lstn completion fish [flags]`\n\nGenerate the autocompletion script for fish.\n\n#### Flags\n\n```\n--no-descriptions   disable completion descriptions\n```\n\n### `lstn completion powershell [flags]`\n\nGenerate the autocompletion script for powershell.\n\n#### Flags\n\n```\n--no-descriptions   disable completion descriptions\n```\n\n### `lstn completion zsh [flags]`\n\nGenerate the autocompletion script for zsh.\n\n#### Flags\n\n```\n--no-descriptions   disable completion descriptions\n```\n\n## `lstn config`\n\nDetails about the ~/.lstn.yaml config file.\n\n## `lstn environment`\n\nWhich environment variables you can use with lstn.\n\n## `lstn exit`\n\nDetails about the lstn exit codes.\n\n## `lstn help [command]`\n\nHelp about any command.\n\n## `lstn in [path]`\n\nInspect the verdicts for your dependencies tree.\n\n### Flags\n\n```\n    --json                output the verdicts (if any) in JSON form\n-l, --lockfiles strings   set one or more lock file paths (relative to the working dir) to lookup for (default [package-lock.json,poetry.lock])\n```\n\n### Config Flags\n\n```\n--loglevel string        set the logging level (default \"info\")\n--npm-endpoint string    the listen.dev endpoint emitting the NPM verdicts (default \"https://npm.listen.dev\")\n--pypi-endpoint string   the listen.dev endpoint emitting the PyPi verdicts (default \"https://pypi.listen.dev\")\n--timeout int            set the timeout, in seconds (default 60)\n```\n\n### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n### Filtering Flags\n\n```\n-q, --jq string   filter the output verdicts using a jq expression (requires --json)\n```\n\n### Registry Flags\n\n```\n--npm-registry string   set a custom NPM registry (default \"https://registry.npmjs.org\")\n```\n\n### Reporting Flags\n\n```\n    --gh-owner string                                               set the GitHub owner name (org|user)\n    --gh-pull-id int                                                set the GitHub pull request ID\n    --gh-repo string                                                set the GitHub repository name\n-r, --reporter (gh-pull-check,gh-pull-comment,gh-pull-review,pro)   set one or more reporters to use (default [])\n```\n\n### Token Flags\n\n```\n--gh-token string    set the GitHub token\n--jwt-token string   set the listen.dev auth token\n```\n\nFor example:\n\n```bash\nlstn in\nlstn in .\nlstn in /we/snitch\nlstn in sub/dir\nlstn in --lockfiles poetry.lock,package-lock.json\nlstn in /pyproj --lockfiles poetry.lock\n```\n\n## `lstn manual`\n\nA comprehensive reference of all the lstn commands.\n\n## `lstn reporters`\n\nA comprehensive guide to the `lstn` reporting mechanisms.\n\n## `lstn scan [path]`\n\nInspect the verdicts for your direct dependencies.\n\n### Flags\n\n```\n--json   output the verdicts (if any) in JSON form\n```\n\n### Config Flags\n\n```\n--loglevel string        set the logging level (default \"info\")\n--npm-endpoint string    the listen.dev endpoint emitting the NPM verdicts (default \"https://npm.listen.dev\")\n--pypi-endpoint string   the listen.dev endpoint emitting the PyPi verdicts (default \"https://pypi.listen.dev\")\n--timeout int            set the timeout, in seconds (default 60)\n```\n\n### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n### Filtering Flags\n\n```\n    --ignore-deptypes (dep,dev,optional,peer)   the list of dependencies types to not process (default [bundle])\n    --ignore-packages strings                   the list of packages to not process\n-q, --jq string                                 filter the output verdicts using a jq expression (requires --json)\n-s, --select string                             filter the output verdicts using a jsonpath script expression (server-side)\n```\n\n### Registry Flags\n\n```\n--npm-registry string   set a custom NPM registry (default \"https://registry.npmjs.org\")\n```\n\n### Reporting Flags\n\n```\n    --gh-owner string                                               set the GitHub owner name (org|user)\n    --gh-pull-id int                                                set the GitHub pull request ID\n    --gh-repo string                                                set the GitHub repository name\n-r, --reporter (gh-pull-check,gh-pull-comment,gh-pull-review,pro)   set one or more reporters to use (default [])\n```\n\n### Token Flags\n\n```\n--gh-token string   set the GitHub token\n```\n\nFor example:\n\n```bash\nlstn scan\nlstn scan .\nlstn scan sub/dir\nlstn scan /we/snitch\nlstn scan /we/snitch --ignore-deptypes peer\nlstn scan /we/snitch --ignore-deptypes dev,peer\nlstn scan /we/snitch --ignore-deptypes dev --ignore-deptypes peer\nlstn scan /we/snitch --ignore-packages react,glob --ignore-deptypes peer\nlstn scan /we/snitch --ignore-packages react --ignore-packages glob,@vue/devtools\n```\n\n## `lstn to <name> [[version] [shasum] | [version constraint]]`\n\nGet the verdicts of a package.\n\n### Flags\n\n```\n--json   output the verdicts (if any) in JSON form\n```\n\n### Config Flags\n\n```\n--loglevel string        set the logging level (default \"info\")\n--npm-endpoint string    the listen.dev endpoint emitting the NPM verdicts (default \"https://npm.listen.dev\")\n--pypi-endpoint string   the listen.dev endpoint emitting the PyPi verdicts (default \"https://pypi.listen.dev\")\n--timeout int            set the timeout, in seconds (default 60)\n```\n\n### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n### Filtering Flags\n\n```\n-q, --jq string       filter the output verdicts using a jq expression (requires --json)\n-s, --select string   filter the output verdicts using a jsonpath script expression (server-side)\n```\n\n### Registry Flags\n\n```\n--npm-registry string   set a custom NPM registry (default \"https://registry.npmjs.org\")\n```\n\nFor example:\n\n```bash\n# Get the verdicts for all the chalk versions that listen.dev owns\nlstn to chalk\nlstn to debug 4.3.4\nlstn to react 18.0.0 b468736d1f4a5891f38585ba8e8fb29f91c3cb96\n\n# Get the verdicts for all the existing chalk versions\nlstn to chalk \"*\"\n# Get the verdicts for nock versions >= 13.2.0 and < 13.3.0\nlstn to nock \"~13.2.x\"\n# Get the verdicts for tap versions >= 16.3.0 and < 16.4.0\nlstn to tap \"^16.3.0\"\n# Get the verdicts for prettier versions >= 2.7.0 <= 3.0.0\nlstn to prettier \">=2.7.0 <=3.0.0\"\n```\n\n## `lstn version`\n\nPrint out version information.\n\n### Flags\n\n```\n-v, -- count      increment the verbosity level\n    --changelog   output the relase notes URL\n```\n\n### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n"
+	suite.expectedOuts[Manual] = "# lstn cheatsheet\n\n## Global Flags\n\nEvery child command inherits the following flags:\n\n```\n--config string   config file (default is $HOME/.lstn.yaml)\n```\n\n## `lstn cache`\n\nManage the verdicts cache.\n\n### `lstn cache clear`\n\nRemove all the verdicts from the cache.\n\n#### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n### `lstn cache ls`\n\nList the cached verdicts.\n\n#### Flags\n\n```\n--json   output the verdicts (if any) in JSON form\n```\n\n#### Cache Flags\n\n```\n--cache-ttl int   set for how many hours to reuse the cached verdicts (default 24)\n```\n\n#### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n#### Filtering Flags\n\n```\n-q, --jq string   filter the output verdicts using a jq expression (requires --json)\n```\n\nFor example:\n\n```bash\nlstn cache ls\nlstn cache ls --cache-ttl 1\nlstn cache ls --json --jq '.[] | select(.expired) | .name'\n```\n\n### `lstn cache prune`\n\nRemove the expired verdicts from the cache.\n\n#### Cache Flags\n\n```\n--cache-ttl int   set for how many hours to reuse the cached verdicts (default 24)\n```\n\n#### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\nFor example:\n\n```bash\nlstn cache prune\nlstn cache prune --cache-ttl 168\n```\n\n## `lstn ci`\n\nListen in on what your CI does.\n\n### `lstn ci enable`\n\nEnable the CI eavesdropping.\n\n#### Flags\n\n```\n--dir string   the directory where the jibril binary is\n```\n\n#### Config Flags\n\n```\n--concurrency int        set the maximum number of concurrent requests (default 8)\n--core-endpoint string   the listen.dev Core API endpoint (default \"https://core.listen.dev\")\n--loglevel string        set the logging level (default \"info\")\n--rate-limit int         set the maximum number of requests per second (0 means no limit)\n--retries int            set how many times to retry the failed API requests (default 3)\n--timeout int            set the timeout, in seconds (default 60)\n```\n\n#### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n#### Network Flags\n\n```\n--ca-cert string       set a PEM file with the additional CA certificates to trust\n--client-cert string   set a PEM file with the client certificate for mutual TLS\n--client-key string    set a PEM file with the private key of the client certificate\n--proxy string         set the proxy URL of the outgoing requests (defaults to the HTTPS_PROXY environment variable)\n```\n\n#### Token Flags\n\n```\n--gh-token string    set the GitHub token\n--jwt-token string   set the listen.dev auth token\n```\n\n### `lstn ci report`\n\nReport the most critical findings into GitHub pull requests.\n\n#### Config Flags\n\n```\n--concurrency int        set the maximum number of concurrent requests (default 8)\n--core-endpoint string   the listen.dev Core API endpoint (default \"https://core.listen.dev\")\n--loglevel string        set the logging level (default \"info\")\n--rate-limit int         set the maximum number of requests per second (0 means no limit)\n--retries int            set how many times to retry the failed API requests (default 3)\n--timeout int            set the timeout, in seconds (default 60)\n```\n\n#### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n#### Network Flags\n\n```\n--ca-cert string       set a PEM file with the additional CA certificates to trust\n--client-cert string   set a PEM file with the client certificate for mutual TLS\n--client-key string    set a PEM file with the private key of the client certificate\n--proxy string         set the proxy URL of the outgoing requests (defaults to the HTTPS_PROXY environment variable)\n```\n\n#### Reporting Flags\n\n```\n--gh-owner string   set the GitHub owner name (org|user)\n--gh-pull-id int    set the GitHub pull request ID\n--gh-repo string    set the GitHub repository name\n```\n\n#### Token Flags\n\n```\n--gh-token string    set the GitHub token\n--jwt-token string   set the listen.dev auth token\n```\n\n## `lstn completion <bash|fish|powershell|zsh>`\n\nGenerate the autocompletion script for the specified shell.\n\n### `lstn completion bash`\n\nGenerate the autocompletion script for bash.\n\n#### Flags\n\n```\n--no-descriptions   disable completion descriptions\n```\n\n### `lstn completion fish [flags]`\n\nGenerate the autocompletion script for fish.\n\n#### Flags\n\n```\n--no-descriptions   disable completion descriptions\n```\n\n### `lstn completion powershell [flags]`\n\nGenerate the autocompletion script for powershell.\n\n#### Flags\n\n```\n--no-descriptions   disable completion descriptions\n```\n\n### `lstn completion zsh [flags]`\n\nGenerate the autocompletion script for zsh.\n\n#### Flags\n\n```\n--no-descriptions   disable completion descriptions\n```\n\n## `lstn config`\n\nDetails about the ~/.lstn.yaml config file.\n\n## `lstn diff <old> <new>`\n\nInspect the verdicts for the dependencies that changed between two lock files.\n\n### Flags\n\n```\n--json       output the verdicts (if any) in JSON form\n--markdown   output the changes as a markdown report\n```\n\n### Cache Flags\n\n```\n--cache-ttl int   set for how many hours to reuse the cached verdicts (default 24)\n--no-cache        do not use the verdicts cache\n--refresh         ignore the cached verdicts, and cache the fresh ones\n```\n\n### Config Flags\n\n```\n--concurrency int        set the maximum number of concurrent requests (default 8)\n--loglevel string        set the logging level (default \"info\")\n--npm-endpoint string    the listen.dev endpoint emitting the NPM verdicts (default \"https://npm.listen.dev\")\n--pypi-endpoint string   the listen.dev endpoint emitting the PyPi verdicts (default \"https://pypi.listen.dev\")\n--rate-limit int         set the maximum number of requests per second (0 means no limit)\n--retries int            set how many times to retry the failed API requests (default 3)\n--timeout int            set the timeout, in seconds (default 60)\n```\n\n### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n### Filtering Flags\n\n```\n-q, --jq string       filter the output verdicts using a jq expression (requires --json)\n-s, --select string   filter the output verdicts using a jsonpath script expression (server-side)\n```\n\n### Network Flags\n\n```\n--ca-cert string       set a PEM file with the additional CA certificates to trust\n--client-cert string   set a PEM file with the client certificate for mutual TLS\n--client-key string    set a PEM file with the private key of the client certificate\n--proxy string         set the proxy URL of the outgoing requests (defaults to the HTTPS_PROXY environment variable)\n```\n\n### Offline Flags\n\n```\n--offline                  answer from a verdicts bundle, without querying listen.dev\n--verdicts-bundle string   set the verdicts bundle (see lstn export) to answer from offline\n```\n\n### Policy Flags\n\n```\n--fail-on string          fail when some verdicts have the given severity (low, medium, high) or a higher one\n--fail-on-codes strings   fail when some verdicts have the given codes or code groups (eg., STN, TSN01)\n--fail-on-problems        fail when some packages have problems\n```\n\n### Registry Flags\n\n```\n--npm-registry string    set a custom NPM registry (default \"https://registry.npmjs.org\")\n--pypi-registry string   set a custom PyPi registry (default \"https://pypi.org\")\n```\n\n### Reporting Flags\n\n```\n    --gh-owner string                                               set the GitHub owner name (org|user)\n    --gh-pull-id int                                                set the GitHub pull request ID\n    --gh-repo string                                                set the GitHub repository name\n-r, --reporter (gh-pull-check,gh-pull-comment,gh-pull-review,pro)   set one or more reporters to use (default [])\n```\n\n### Token Flags\n\n```\n--gh-token string   set the GitHub token\n```\n\nFor example:\n\n```bash\nlstn diff old/package-lock.json package-lock.json\nlstn diff main:package-lock.json package-lock.json\nlstn diff HEAD~1:poetry.lock HEAD:poetry.lock\nlstn diff origin/main:web/yarn.lock web/yarn.lock --markdown\nlstn diff main:package-lock.json package-lock.json --fail-on high --reporter gh-pull-comment\n```\n\n## `lstn environment`\n\nWhich environment variables you can use with lstn.\n\n## `lstn exit`\n\nDetails about the lstn exit codes.\n\n## `lstn export [path]`\n\nExport the verdicts for your dependencies tree into a bundle.\n\n### Flags\n\n```\n-l, --lockfiles strings   set one or more lock file paths (relative to the working dir) to lookup for (default [package-lock.json,pnpm-lock.yaml,poetry.lock])\n-o, --output string       set the file to write the verdicts bundle into (default \"lstn-verdicts.json\")\n```\n\n### Cache Flags\n\n```\n--cache-ttl int   set for how many hours to reuse the cached verdicts (default 24)\n--no-cache        do not use the verdicts cache\n--refresh         ignore the cached verdicts, and cache the fresh ones\n```\n\n### Config Flags\n\n```\n--concurrency int        set the maximum number of concurrent requests (default 8)\n--loglevel string        set the logging level (default \"info\")\n--npm-endpoint string    the listen.dev endpoint emitting the NPM verdicts (default \"https://npm.listen.dev\")\n--pypi-endpoint string   the listen.dev endpoint emitting the PyPi verdicts (default \"https://pypi.listen.dev\")\n--rate-limit int         set the maximum number of requests per second (0 means no limit)\n--retries int            set how many times to retry the failed API requests (default 3)\n--timeout int            set the timeout, in seconds (default 60)\n```\n\n### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n### Network Flags\n\n```\n--ca-cert string       set a PEM file with the additional CA certificates to trust\n--client-cert string   set a PEM file with the client certificate for mutual TLS\n--client-key string    set a PEM file with the private key of the client certificate\n--proxy string         set the proxy URL of the outgoing requests (defaults to the HTTPS_PROXY environment variable)\n```\n\n### Registry Flags\n\n```\n--npm-registry string    set a custom NPM registry (default \"https://registry.npmjs.org\")\n--pypi-registry string   set a custom PyPi registry (default \"https://pypi.org\")\n```\n\nFor example:\n\n```bash\nlstn export\nlstn export --output /mnt/share/verdicts.json\nlstn export /we/snitch --lockfiles package-lock.json,poetry.lock\n\n# Then, in the offline environment\nlstn in /we/snitch --offline --verdicts-bundle /mnt/share/verdicts.json\n```\n\n## `lstn help [command]`\n\nHelp about any command.\n\n## `lstn in [path]`\n\nInspect the verdicts for your dependencies tree.\n\n### Flags\n\n```\n    --json                output the verdicts (if any) in JSON form\n-l, --lockfiles strings   set one or more lock file paths (relative to the working dir) to lookup for (default [package-lock.json,pnpm-lock.yaml,poetry.lock])\n```\n\n### Baseline Flags\n\n```\n--baseline string         report only the packages and the verdicts that the given baseline file does not have\n--write-baseline string   write the verdicts into the given baseline file\n```\n\n### Cache Flags\n\n```\n--cache-ttl int   set for how many hours to reuse the cached verdicts (default 24)\n--no-cache        do not use the verdicts cache\n--refresh         ignore the cached verdicts, and cache the fresh ones\n```\n\n### Config Flags\n\n```\n--concurrency int        set the maximum number of concurrent requests (default 8)\n--loglevel string        set the logging level (default \"info\")\n--npm-endpoint string    the listen.dev endpoint emitting the NPM verdicts (default \"https://npm.listen.dev\")\n--pypi-endpoint string   the listen.dev endpoint emitting the PyPi verdicts (default \"https://pypi.listen.dev\")\n--rate-limit int         set the maximum number of requests per second (0 means no limit)\n--retries int            set how many times to retry the failed API requests (default 3)\n--timeout int            set the timeout, in seconds (default 60)\n```\n\n### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n### Filtering Flags\n\n```\n    --ignore-groups strings   the list of dependency groups (eg., poetry groups) to not process\n-q, --jq string               filter the output verdicts using a jq expression (requires --json)\n```\n\n### Network Flags\n\n```\n--ca-cert string       set a PEM file with the additional CA certificates to trust\n--client-cert string   set a PEM file with the client certificate for mutual TLS\n--client-key string    set a PEM file with the private key of the client certificate\n--proxy string         set the proxy URL of the outgoing requests (defaults to the HTTPS_PROXY environment variable)\n```\n\n### Offline Flags\n\n```\n--offline                  answer from a verdicts bundle, without querying listen.dev\n--verdicts-bundle string   set the verdicts bundle (see lstn export) to answer from offline\n```\n\n### Policy Flags\n\n```\n--fail-on string          fail when some verdicts have the given severity (low, medium, high) or a higher one\n--fail-on-codes strings   fail when some verdicts have the given codes or code groups (eg., STN, TSN01)\n--fail-on-problems        fail when some packages have problems\n```\n\n### Registry Flags\n\n```\n--npm-registry string    set a custom NPM registry (default \"https://registry.npmjs.org\")\n--pypi-registry string   set a custom PyPi registry (default \"https://pypi.org\")\n```\n\n### Reporting Flags\n\n```\n    --gh-owner string                                               set the GitHub owner name (org|user)\n    --gh-pull-id int                                                set the GitHub pull request ID\n    --gh-repo string                                                set the GitHub repository name\n-r, --reporter (gh-pull-check,gh-pull-comment,gh-pull-review,pro)   set one or more reporters to use (default [])\n```\n\n### Token Flags\n\n```\n--gh-token string    set the GitHub token\n--jwt-token string   set the listen.dev auth token\n```\n\nFor example:\n\n```bash\nlstn in\nlstn in .\nlstn in /we/snitch\nlstn in sub/dir\nlstn in --lockfiles poetry.lock,package-lock.json\nlstn in /pyproj --lockfiles poetry.lock\nlstn in /pyproj --lockfiles poetry.lock --ignore-groups dev,docs\nlstn in --lockfiles yarn.lock\nlstn in --lockfiles npm-shrinkwrap.json,bun.lock\nlstn in /pyproj --lockfiles uv.lock,pdm.lock,Pipfile.lock\nlstn in /pyproj --lockfiles requirements.txt\nlstn in --offline --verdicts-bundle lstn-verdicts.json\nlstn in --fail-on high --fail-on-codes TSN,DDN\nlstn in --write-baseline lstn-baseline.json\nlstn in --baseline lstn-baseline.json --reporter gh-pull-comment\n```\n\n## `lstn manual`\n\nA comprehensive reference of all the lstn commands.\n\n## `lstn reporters`\n\nA comprehensive guide to the `lstn` reporting mechanisms.\n\n## `lstn scan [path]`\n\nInspect the verdicts for your direct dependencies.\n\n### Flags\n\n```\n--json                output the verdicts (if any) in JSON form\n--resolution string   how to resolve the version constraints (lockfile, highest, lowest) (default \"lockfile\")\n--strict              fail when some dependencies cannot be resolved against the registry\n```\n\n### Baseline Flags\n\n```\n--baseline string         report only the packages and the verdicts that the given baseline file does not have\n--write-baseline string   write the verdicts into the given baseline file\n```\n\n### Cache Flags\n\n```\n--cache-ttl int   set for how many hours to reuse the cached verdicts (default 24)\n--no-cache        do not use the verdicts cache\n--refresh         ignore the cached verdicts, and cache the fresh ones\n```\n\n### Config Flags\n\n```\n--concurrency int        set the maximum number of concurrent requests (default 8)\n--loglevel string        set the logging level (default \"info\")\n--npm-endpoint string    the listen.dev endpoint emitting the NPM verdicts (default \"https://npm.listen.dev\")\n--pypi-endpoint string   the listen.dev endpoint emitting the PyPi verdicts (default \"https://pypi.listen.dev\")\n--rate-limit int         set the maximum number of requests per second (0 means no limit)\n--retries int            set how many times to retry the failed API requests (default 3)\n--timeout int            set the timeout, in seconds (default 60)\n```\n\n### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n### Filtering Flags\n\n```\n    --ignore-deptypes (dep,dev,optional,peer)   the list of dependencies types to not process (default [bundle])\n    --ignore-groups strings                     the list of dependency groups (eg., poetry groups) to not process\n    --ignore-packages strings                   the list of packages to not process\n-q, --jq string                                 filter the output verdicts using a jq expression (requires --json)\n-s, --select string                             filter the output verdicts using a jsonpath script expression (server-side)\n```\n\n### Network Flags\n\n```\n--ca-cert string       set a PEM file with the additional CA certificates to trust\n--client-cert string   set a PEM file with the client certificate for mutual TLS\n--client-key string    set a PEM file with the private key of the client certificate\n--proxy string         set the proxy URL of the outgoing requests (defaults to the HTTPS_PROXY environment variable)\n```\n\n### Offline Flags\n\n```\n--offline                  answer from a verdicts bundle, without querying listen.dev\n--verdicts-bundle string   set the verdicts bundle (see lstn export) to answer from offline\n```\n\n### Policy Flags\n\n```\n--fail-on string          fail when some verdicts have the given severity (low, medium, high) or a higher one\n--fail-on-codes strings   fail when some verdicts have the given codes or code groups (eg., STN, TSN01)\n--fail-on-problems        fail when some packages have problems\n```\n\n### Registry Flags\n\n```\n--npm-registry string    set a custom NPM registry (default \"https://registry.npmjs.org\")\n--pypi-registry string   set a custom PyPi registry (default \"https://pypi.org\")\n```\n\n### Reporting Flags\n\n```\n    --gh-owner string                                               set the GitHub owner name (org|user)\n    --gh-pull-id int                                                set the GitHub pull request ID\n    --gh-repo string                                                set the GitHub repository name\n-r, --reporter (gh-pull-check,gh-pull-comment,gh-pull-review,pro)   set one or more reporters to use (default [])\n```\n\n### Token Flags\n\n```\n--gh-token string   set the GitHub token\n```\n\nFor example:\n\n```bash\nlstn scan\nlstn scan .\nlstn scan sub/dir\nlstn scan /we/snitch\nlstn scan /we/snitch --ignore-deptypes peer\nlstn scan /we/snitch --ignore-deptypes dev,peer\nlstn scan /we/snitch --ignore-deptypes dev --ignore-deptypes peer\nlstn scan /we/snitch --ignore-packages react,glob --ignore-deptypes peer\nlstn scan /we/snitch --ignore-packages react --ignore-packages glob,@vue/devtools\nlstn scan /pyproj --ignore-groups dev,docs\nlstn scan /we/snitch --resolution highest\nlstn scan /we/snitch --strict\nlstn scan /we/snitch --offline --verdicts-bundle lstn-verdicts.json\nlstn scan /we/snitch --fail-on medium --fail-on-problems\nlstn scan --write-baseline lstn-baseline.json\nlstn scan --baseline lstn-baseline.json --reporter gh-pull-comment\n```\n\n## `lstn to <name> [[version] [shasum] | [version constraint]]`\n\nGet the verdicts of a package.\n\n### Flags\n\n```\n--compare            compare the verdicts of the consecutive versions, and recommend the closest one without high severity verdicts\n--ecosystem string   the ecosystem of the package (npm, pypi) (default \"npm\")\n--json               output the verdicts (if any) in JSON form\n```\n\n### Cache Flags\n\n```\n--cache-ttl int   set for how many hours to reuse the cached verdicts (default 24)\n--no-cache        do not use the verdicts cache\n--refresh         ignore the cached verdicts, and cache the fresh ones\n```\n\n### Config Flags\n\n```\n--concurrency int        set the maximum number of concurrent requests (default 8)\n--loglevel string        set the logging level (default \"info\")\n--npm-endpoint string    the listen.dev endpoint emitting the NPM verdicts (default \"https://npm.listen.dev\")\n--pypi-endpoint string   the listen.dev endpoint emitting the PyPi verdicts (default \"https://pypi.listen.dev\")\n--rate-limit int         set the maximum number of requests per second (0 means no limit)\n--retries int            set how many times to retry the failed API requests (default 3)\n--timeout int            set the timeout, in seconds (default 60)\n```\n\n### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n### Filtering Flags\n\n```\n-q, --jq string       filter the output verdicts using a jq expression (requires --json)\n-s, --select string   filter the output verdicts using a jsonpath script expression (server-side)\n```\n\n### Network Flags\n\n```\n--ca-cert string       set a PEM file with the additional CA certificates to trust\n--client-cert string   set a PEM file with the client certificate for mutual TLS\n--client-key string    set a PEM file with the private key of the client certificate\n--proxy string         set the proxy URL of the outgoing requests (defaults to the HTTPS_PROXY environment variable)\n```\n\n### Offline Flags\n\n```\n--offline                  answer from a verdicts bundle, without querying listen.dev\n--verdicts-bundle string   set the verdicts bundle (see lstn export) to answer from offline\n```\n\n### Policy Flags\n\n```\n--fail-on string          fail when some verdicts have the given severity (low, medium, high) or a higher one\n--fail-on-codes strings   fail when some verdicts have the given codes or code groups (eg., STN, TSN01)\n--fail-on-problems        fail when some packages have problems\n```\n\n### Registry Flags\n\n```\n--npm-registry string    set a custom NPM registry (default \"https://registry.npmjs.org\")\n--pypi-registry string   set a custom PyPi registry (default \"https://pypi.org\")\n```\n\nFor example:\n\n```bash\n# Get the verdicts for all the chalk versions that listen.dev owns\nlstn to chalk\nlstn to debug 4.3.4\nlstn to react 18.0.0 b468736d1f4a5891f38585ba8e8fb29f91c3cb96\n\n# Get the verdicts for all the existing chalk versions\nlstn to chalk \"*\"\n# Get the verdicts for nock versions >= 13.2.0 and < 13.3.0\nlstn to nock \"~13.2.x\"\n# Get the verdicts for tap versions >= 16.3.0 and < 16.4.0\nlstn to tap \"^16.3.0\"\n# Get the verdicts for prettier versions >= 2.7.0 <= 3.0.0\nlstn to prettier \">=2.7.0 <=3.0.0\"\n\n# Get the verdicts for the PyPi requests package versions >= 2.31 and < 3\nlstn to --ecosystem pypi requests \">=2.31,<3\"\nlstn to pypi:requests 2.32.3\n\n# Get the verdicts for the package a package URL references\nlstn to pkg:npm/%40vue/devtools@6.5.0\nlstn to pkg:pypi/requests@2.31.0\n\n# Get the verdicts for a package version from a verdicts bundle\nlstn to debug 4.3.4 --offline --verdicts-bundle lstn-verdicts.json\n\n# Fail when a package version has high severity verdicts\nlstn to react 18.0.0 --fail-on high\n\n# Compare the verdicts of the debug versions >= 4.3.1 and < 5, to choose the one to upgrade 4.3.1 to\nlstn to debug \"^4.3.1\" --compare\nlstn to --ecosystem pypi requests \">=2.28,<3\" --compare --json\n```\n\n## `lstn version`\n\nPrint out version information.\n\n### Flags\n\n```\n-v, -- count      increment the verbosity level\n    --changelog   output the relase notes URL\n```\n\n### Debug Flags\n\n```\n--debug-options   output the options, then exit\n```\n\n"
 
-	suite.expectedOuts[Exit] = "The lstn CLI follows the usual conventions regarding exit codes.\n\nMeaning:\n\n* when a command completes successfully, the exit code will be 0\n\n* when a command fails for any reason, the exit code will be 1\n\n* when a command is running but gets cancelled, the exit code will be 2\n\n* when a command meets an authentication issue, the exit code will be 4\n\nNotice that it's possible that a particular command may have more exit codes,\nso it's a good practice to check the docs for the specific command\nin case you're relying on the exit codes to control some behaviour.\n"
+	suite.expectedOuts[Exit] = "The lstn CLI follows the usual conventions regarding exit codes.\n\nMeaning:\n\n* when a command completes successfully, the exit code will be 0\n\n* when a command fails for any reason, the exit code will be 1\n\n* when a command is running but gets cancelled, the exit code will be 2\n\n* when a command gets the verdicts of only some of the packages, the exit code will be 3\n\n* when a command meets an authentication issue, the exit code will be 4\n\n* when a jq expression halts with an error, the exit code will be 5 (unless it tells another one)\n\n* when some verdicts have the --fail-on severity or a higher one, the exit code will be 6\n\n* when some verdicts have the --fail-on-codes codes or code groups, the exit code will be 7\n\n* when some packages have problems and --fail-on-problems is on, the exit code will be 8\n\nWhen the verdicts violate more of the fail-on options, the exit code tells the first one among severity, codes, and problems.\n\nNotice that it's possible that a particular command may have more exit codes,\nso it's a good practice to check the docs for the specific command\nin case you're relying on the exit codes to control some behaviour.\n"
 }
 
 func TestCmdSuites(t *testing.T) {
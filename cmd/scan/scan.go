@@ -17,22 +17,33 @@ package scan
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"time"
 
 	"github.com/cli/cli/pkg/iostreams"
 	"github.com/listendev/lstn/internal/project"
+	"github.com/listendev/lstn/pkg/baseline"
 	"github.com/listendev/lstn/pkg/cmd/arguments"
 	"github.com/listendev/lstn/pkg/cmd/groups"
 	"github.com/listendev/lstn/pkg/cmd/options"
 	"github.com/listendev/lstn/pkg/cmd/packagesprinter"
+	"github.com/listendev/lstn/pkg/cmd/verdicts"
 	pkgcontext "github.com/listendev/lstn/pkg/context"
 	"github.com/listendev/lstn/pkg/listen"
+	"github.com/listendev/lstn/pkg/lockfile"
 	"github.com/listendev/lstn/pkg/npm"
+	"github.com/listendev/lstn/pkg/policy"
+	"github.com/listendev/lstn/pkg/pypi"
+	"github.com/listendev/lstn/pkg/reporter"
 	reporterfactory "github.com/listendev/lstn/pkg/reporter/factory"
+	"github.com/listendev/lstn/pkg/suppress"
 	"github.com/listendev/pkg/ecosystem"
+	"github.com/listendev/pkg/manifest"
 	"github.com/spf13/cobra"
 )
 
@@ -47,9 +58,35 @@ func New(ctx context.Context) (*cobra.Command, error) {
 		Long: `Query listen.dev for the verdicts of the dependencies in your project.
 
 Using this command, you can audit the first-level dependencies configured for a project and obtain their verdicts.
-This requires a package.json file to fetch the package name and version of the project dependencies.
+This requires a package.json or a pyproject.toml file to fetch the package name and version of the project dependencies.
+The version constraints of the Python dependencies (both the PEP 621 and the Poetry ones) get resolved against the PyPi registry.
 
-The verdicts it returns are listed by the name of each package and its specified version.`,
+For npm projects, it also scans the package.json of the workspaces, it applies the overrides, and it resolves the aliases (eg., npm:foo@^1) and the distribution tags (eg., latest).
+
+The dependencies it cannot resolve to a package version in the registry (eg., git repositories, tarball URLs, local and workspace packages,
+or packages missing from the registry) are listed as not analysed: in the table, or on stderr in JSON mode (so that the --jq query only gets the verdicts).
+So are the packages whose verdicts it couldn't get from the listen.dev API: in such a case, it exits with status code 3.
+Use the --strict flag to fail when some dependencies cannot be resolved against the registry instead.
+
+By default, it resolves every dependency to the version the lock file next to the manifest pins (eg., package-lock.json, poetry.lock),
+falling back to the highest version satisfying its constraints when there's no lock file or the lock file does not pin it.
+Use the --resolution flag to always resolve to the highest or to the lowest version satisfying the constraints instead.
+
+It queries the npm registries, and uses the credentials, configured in the .npmrc file of the project and in the user one.
+
+The verdicts it returns are listed by the name of each package and its specified version.
+
+Use the --offline flag to answer from a verdicts bundle (see lstn export) rather than from listen.dev.
+In such a case, it lists the packages the bundle does not cover as not analysed, while it still resolves the dependencies against the registries.
+
+Use the --fail-on, --fail-on-codes, and --fail-on-problems flags to exit with a non-zero status code (see lstn help exit)
+when some verdicts have a severity, or some codes, or when some packages have problems.
+
+The rules in the .lstnignore.yaml file of the target directory suppress the verdicts they match, with a reason, an owner, and an optional expiry date.
+The suppressed verdicts are listed apart, and they do not make the --fail-on flags fail.
+
+Use the --write-baseline flag to save a snapshot of the verdicts, and the --baseline flag to report only the packages and the verdicts
+that such a snapshot does not have. The verdicts already in the baseline do not make the --fail-on flags fail.`,
 		Example: `  lstn scan
   lstn scan .
   lstn scan sub/dir
@@ -58,7 +95,14 @@ The verdicts it returns are listed by the name of each package and its specified
   lstn scan /we/snitch --ignore-deptypes dev,peer
   lstn scan /we/snitch --ignore-deptypes dev --ignore-deptypes peer
   lstn scan /we/snitch --ignore-packages react,glob --ignore-deptypes peer
-  lstn scan /we/snitch --ignore-packages react --ignore-packages glob,@vue/devtools`,
+  lstn scan /we/snitch --ignore-packages react --ignore-packages glob,@vue/devtools
+  lstn scan /pyproj --ignore-groups dev,docs
+  lstn scan /we/snitch --resolution highest
+  lstn scan /we/snitch --strict
+  lstn scan /we/snitch --offline --verdicts-bundle lstn-verdicts.json
+  lstn scan /we/snitch --fail-on medium --fail-on-problems
+  lstn scan --write-baseline lstn-baseline.json
+  lstn scan --baseline lstn-baseline.json --reporter gh-pull-comment`,
 		Args:              arguments.SingleDirectory, // Executes before RunE
 		ValidArgsFunction: arguments.SingleDirectoryActiveHelp,
 		Annotations: map[string]string{
@@ -84,6 +128,7 @@ The verdicts it returns are listed by the name of each package and its specified
 			}
 
 			io := c.Context().Value(pkgcontext.IOStreamsKey).(*iostreams.IOStreams)
+			cs := io.ColorScheme()
 			io.StartProgressIndicator()
 
 			// Obtain the target directory that we want to listen in
@@ -92,64 +137,289 @@ The verdicts it returns are listed by the name of each package and its specified
 				return fmt.Errorf("couldn't get to know which directory you want me to scan")
 			}
 
-			packageJSON, err := npm.GetPackageJSONFromDir(targetDir)
+			// Drop the verdicts that the suppression rules of the project match
+			rules, err := suppress.Load(filepath.Join(targetDir, suppress.Filename))
 			if err != nil {
 				return err
 			}
+			for _, warning := range rules.ExpiredWarnings(time.Now()) {
+				c.PrintErrln(cs.WarningIcon(), warning)
+			}
 
-			// Exclude dependencies
-			packageJSON.FilterOutByTypes(scanOpts.Deptypes...)
-			packageJSON.FilterOutByNames(scanOpts.Packages...)
-
-			// Retrieve dependencies to process
-			deps := packageJSON.Deps(ctx, npm.DefaultVersionResolutionStrategy)
-			if len(deps) == 0 {
-				return fmt.Errorf("there are no dependencies to process")
+			// Keep only what the baseline does not have, while recording the verdicts into the new one
+			base, err := baseline.New(scanOpts.Baseline, scanOpts.WriteBaseline)
+			if err != nil {
+				return err
 			}
 
-			// Process one dependency set at once
-			tablePrinter := packagesprinter.NewTablePrinter(io)
-			combinedResponse := listen.Response{}
-			for _, deps := range deps {
-				// Create list of verdicts requests
-				reqs, bulkErr := listen.NewBulkVerdictsRequestsFromMap(deps, scanOpts.Expression)
-				if bulkErr != nil {
-					return bulkErr
+			// Lookup the manifest files declaring the direct dependencies
+			sources := []string{}
+			for _, name := range []string{manifest.PackageJSON.String(), pypi.PyprojectFilename} {
+				if _, statErr := os.Stat(filepath.Join(targetDir, name)); statErr == nil {
+					sources = append(sources, filepath.Join(targetDir, name))
 				}
+			}
+			if len(sources) == 0 {
+				return fmt.Errorf("directory %s does not contain a %s or a %s file", targetDir, manifest.PackageJSON.String(), pypi.PyprojectFilename)
+			}
+
+			// failures collects the requests to the listen.dev API that failed, while other ones succeeded
+			failures := &listen.PartialResultsError{}
+			// processed counts the sources having dependencies to process
+			processed := 0
+			// The verdicts the policy checks once the command reported them
+			checked := listen.Response{}
+			for _, src := range sources {
+				var eco ecosystem.Ecosystem
+				var sets []map[string]string
+				var groups map[string][]string
+				// digests maps the package name@version to the SHA1 digest the lock file records
+				digests := map[string]string{}
+				notAnalysed := []listen.NotAnalysed{}
+				resolutionErrs := []error{}
+
+				switch filepath.Base(src) {
+				case manifest.PackageJSON.String():
+					eco = ecosystem.Npm
 
-				// Query for verdicts about the current dependencies set in parallel...
-				res, resJSON, resErr := listen.BulkPackages(
-					reqs,
-					listen.WithContext(ctx),
-					listen.WithEcosystem(ecosystem.Npm), // FIXME: only NPM at the moment
-					listen.WithJSONOptions(scanOpts.JSONFlags),
-				)
+					packageJSON, err := npm.GetPackageJSONFromDir(targetDir)
+					if err != nil {
+						return err
+					}
 
-				if resErr != nil {
-					return resErr
+					// Process the workspaces too
+					packages := append([]npm.PackageJSON{packageJSON}, packageJSON.Workspaces()...)
+
+					// Exclude dependencies
+					for _, pkg := range packages {
+						pkg.FilterOutByTypes(scanOpts.Deptypes...)
+						pkg.FilterOutByNames(scanOpts.Packages...)
+					}
+
+					// List the dependencies not coming from the registry
+					for _, pkg := range packages {
+						for _, u := range pkg.Unresolvable() {
+							notAnalysed = append(notAnalysed, listen.NotAnalysed{Name: u.Name, Spec: u.Spec, Group: u.Type.String(), Source: u.Source, Reason: u.Reason})
+						}
+					}
+
+					// Use the registries and the credentials in the .npmrc files
+					npmrc, npmrcErr := npm.GetNpmrc(targetDir)
+					if npmrcErr != nil {
+						return npmrcErr
+					}
+					npmCtx := context.WithValue(ctx, pkgcontext.NpmrcKey, npmrc)
+
+					// Choose how to resolve the version constraints
+					resolve := npm.DefaultVersionResolutionStrategy
+					var locked map[string]npm.PackageLockDependency
+					switch scanOpts.Resolution {
+					case "lowest":
+						resolve = npm.LowestVersionResolutionStrategy
+					case "lockfile":
+						var lockErr error
+						locked, lockErr = npmLockedDeps(targetDir)
+						if lockErr != nil {
+							c.PrintErrln(cs.WarningIcon(), cs.Blue(fmt.Sprintf("[%s ecosystem]", eco.Case())), lockErr.Error())
+						}
+						if len(locked) > 0 {
+							resolve = npm.LockfileVersionResolutionStrategy(locked, resolve)
+						}
+					}
+
+					// The integrity of the locked dependencies, wherever they are in the node_modules tree
+					shasums := npm.LockedShasums(locked)
+
+					// Retrieve dependencies to process
+					seen := map[string]bool{}
+					for _, pkg := range packages {
+						resolved, errs := pkg.Deps(npmCtx, resolve)
+						for _, e := range errs {
+							notAnalysed = append(notAnalysed, listen.NotAnalysed{Name: e.Name, Spec: e.Spec, Group: e.Type.String(), Source: e.Source, Reason: e.Err.Error()})
+							resolutionErrs = append(resolutionErrs, e)
+						}
+						for _, deps := range resolved {
+							set := map[string]string{}
+							for name, version := range deps {
+								set[name] = ""
+								if version != nil {
+									set[name] = version.String()
+								}
+								// Workspaces can depend on the same package versions
+								if seen[name+"@"+set[name]] {
+									delete(set, name)
+
+									continue
+								}
+								seen[name+"@"+set[name]] = true
+
+								// Use the integrity of the locked dependencies too
+								if shasum, ok := shasums[name+"@"+set[name]]; ok {
+									digests[name+"@"+set[name]] = shasum
+								}
+							}
+							if len(set) > 0 {
+								sets = append(sets, set)
+							}
+						}
+					}
+
+				case pypi.PyprojectFilename:
+					eco = ecosystem.Pypi
+
+					pyprojectTOML, err := pypi.GetPyprojectTOMLFromDir(targetDir)
+					if err != nil {
+						return err
+					}
+
+					// Exclude dependencies
+					pyprojectTOML.FilterOutByGroups(scanOpts.Groups...)
+					pyprojectTOML.FilterOutByNames(scanOpts.Packages...)
+
+					// Choose how to resolve the version constraints
+					resolve := pypi.DefaultVersionResolutionStrategy
+					switch scanOpts.Resolution {
+					case "lowest":
+						resolve = pypi.LowestVersionResolutionStrategy
+					case "lockfile":
+						locked, lockErr := pypiLockedPackages(targetDir)
+						if lockErr != nil {
+							c.PrintErrln(cs.WarningIcon(), cs.Blue(fmt.Sprintf("[%s ecosystem]", eco.Case())), lockErr.Error())
+						}
+						if len(locked) > 0 {
+							resolve = pypi.LockfileVersionResolutionStrategy(locked, resolve)
+						}
+					}
+
+					// Retrieve dependencies to process
+					groups = map[string][]string{}
+					resolved, errs := pyprojectTOML.Deps(ctx, resolve)
+					for _, e := range errs {
+						notAnalysed = append(notAnalysed, listen.NotAnalysed{Name: e.Name, Spec: e.Constraint, Group: e.Group, Source: pypi.PyprojectFilename, Reason: e.Err.Error()})
+						resolutionErrs = append(resolutionErrs, e)
+					}
+					for group, deps := range resolved {
+						set := map[string]string{}
+						for name, version := range deps {
+							set[name] = version.String()
+							groups[name] = append(groups[name], group)
+						}
+						sets = append(sets, set)
+					}
+				}
+
+				if scanOpts.Strict && len(resolutionErrs) > 0 {
+					return fmt.Errorf("couldn't resolve some dependencies in %s: %w", src, errors.Join(resolutionErrs...))
 				}
+				if len(sets) == 0 && len(notAnalysed) == 0 {
+					// Eg., a pyproject.toml only configuring the tools of a JavaScript project
+					c.PrintErrln(cs.WarningIcon(), cs.Blue(fmt.Sprintf("[%s ecosystem]", eco.Case())), fmt.Sprintf("there are no dependencies to process in %s", src))
 
-				if resJSON != nil {
-					fmt.Fprintf(os.Stdout, "%s", resJSON)
+					continue
 				}
+				processed++
+
+				// Process one dependency set at once
+				combinedResponse := listen.Response{}
+				for _, deps := range sets {
+					// Create list of verdicts requests
+					reqs, bulkErr := listen.NewBulkVerdictsRequestsFromVersions(deps, scanOpts.Expression)
+					if bulkErr != nil {
+						return bulkErr
+					}
+					for _, req := range reqs {
+						req.Digest = digests[req.Name+"@"+req.Version]
+					}
 
-				// Appending the results of the current dependency set
-				if res != nil {
+					// Query for verdicts about the current dependencies set in parallel...
+					res, _, resErr := listen.BulkPackages(
+						reqs,
+						listen.WithContext(ctx),
+						listen.WithEcosystem(eco),
+					)
+
+					failures.Total += len(reqs)
+					var partialErr *listen.PartialResultsError
+					switch {
+					case errors.As(resErr, &partialErr):
+						// Render the verdicts of the other packages anyway
+						failures.Errors = append(failures.Errors, partialErr.Errors...)
+						for _, n := range partialErr.NotAnalysed() {
+							n.Source = src
+							notAnalysed = append(notAnalysed, n)
+						}
+					case resErr != nil:
+						return resErr
+					}
+
+					if res == nil {
+						continue
+					}
+					*res = verdicts.Suppress(rules, eco, *res)
+					*res = verdicts.CompareBaseline(base, eco, *res)
+					if scanOpts.JSON {
+						resJSON, err := verdicts.JSON(ctx, scanOpts.JSONFlags, res)
+						if err != nil {
+							return err
+						}
+						fmt.Fprintf(io.Out, "%s", resJSON)
+					}
+
+					// Appending the results of the current dependency set
 					combinedResponse = append(combinedResponse, *res...)
 				}
+				checked = append(checked, combinedResponse...)
+
+				suppressed := rules.Take()
+				known := base.TakeKnown()
+				if scanOpts.JSON {
+					if err := verdicts.WriteSummary(io.ErrOut, verdicts.Summary{Suppressed: suppressed, NotAnalysed: notAnalysed}); err != nil {
+						return err
+					}
+
+					continue
+				}
+
+				for _, g := range groups {
+					sort.Strings(g)
+				}
+				tablePrinter := packagesprinter.NewTablePrinter(io, packagesprinter.WithGroups(groups), packagesprinter.WithSuppressed(suppressed), packagesprinter.WithKnown(known), packagesprinter.WithNotAnalysed(notAnalysed))
+				err = tablePrinter.RenderPackages(&combinedResponse)
+				if err != nil {
+					return err
+				}
+
+				// Let the reporters tell how many verdicts the suppression rules, and the baseline, dropped
+				c.SetContext(context.WithValue(c.Context(), pkgcontext.SuppressedKey, suppressed))
+				reportOpts := []reporter.Option{}
+				if base.Comparing() {
+					reportOpts = append(reportOpts, reporter.WithBaseline(known))
+				}
+				if err := reporterfactory.Exec(c, scanOpts.Reporting, combinedResponse, &src, reportOpts...); err != nil {
+					return err
+				}
 			}
 
-			if scanOpts.JSON {
-				return nil
+			if processed == 0 {
+				return fmt.Errorf("there are no dependencies to process in %s", targetDir)
 			}
 
-			err = tablePrinter.RenderPackages(&combinedResponse)
-			if err != nil {
+			if err := base.Write(); err != nil {
 				return err
 			}
-			src := filepath.Join(targetDir, "package.json")
+			if scanOpts.WriteBaseline != "" {
+				c.PrintErrln(cs.SuccessIcon(), fmt.Sprintf("wrote the baseline into %s", scanOpts.WriteBaseline))
+			}
+
+			var failuresErr error
+			if len(failures.Errors) > 0 {
+				failuresErr = failures
+			}
+
+			// Fail on the package versions violating the policy, once reported
+			verdicts.Check(policy.FromContext(ctx), checked)
 
-			return reporterfactory.Exec(c, scanOpts.Reporting, combinedResponse, &src)
+			return policy.Result(ctx, failuresErr)
 		},
 	}
 
@@ -171,3 +441,68 @@ The verdicts it returns are listed by the name of each package and its specified
 
 	return scanCmd, nil
 }
+
+// npmLockedDeps returns the dependencies the npm lock file in the input directory pins.
+//
+// It returns no dependencies when the directory does not contain any npm lock file.
+func npmLockedDeps(dir string) (map[string]npm.PackageLockDependency, error) {
+	// Like npm does, prefer the npm-shrinkwrap.json to the package-lock.json
+	for _, lf := range []lockfile.Lockfile{lockfile.NpmShrinkwrapJSON, lockfile.PackageLockJSON, lockfile.PnpmLock, lockfile.YarnLock, lockfile.BunLock} {
+		if _, err := os.Stat(filepath.Join(dir, lf.String())); err != nil {
+			continue
+		}
+
+		var lock interface {
+			Deps() map[string]npm.PackageLockDependency
+		}
+		var err error
+		switch lf {
+		case lockfile.NpmShrinkwrapJSON:
+			lock, err = npm.GetNpmShrinkwrapJSONFromDir(dir)
+		case lockfile.PackageLockJSON:
+			lock, err = npm.GetPackageLockJSONFromDir(dir)
+		case lockfile.PnpmLock:
+			lock, err = npm.GetPnpmLockFromDir(dir)
+		case lockfile.YarnLock:
+			lock, err = npm.GetYarnLockFromDir(dir)
+		case lockfile.BunLock:
+			lock, err = npm.GetBunLockFromDir(dir)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't use the versions %s pins, resolving to the highest ones: %w", lf.String(), err)
+		}
+
+		return lock.Deps(), nil
+	}
+
+	return map[string]npm.PackageLockDependency{}, nil
+}
+
+// pypiLockedPackages returns the packages the Python lock file in the input directory pins.
+//
+// It returns no packages when the directory does not contain any Python lock file.
+func pypiLockedPackages(dir string) ([]pypi.PoetryPackage, error) {
+	for _, lf := range []lockfile.Lockfile{lockfile.PoetryLock, lockfile.UvLock, lockfile.PdmLock} {
+		if _, err := os.Stat(filepath.Join(dir, lf.String())); err != nil {
+			continue
+		}
+
+		var lock pypi.PoetryLock
+		var err error
+		switch lf {
+		case lockfile.PoetryLock:
+			lock, err = pypi.GetPoetryLockFromDir(dir)
+		case lockfile.UvLock:
+			lock, err = pypi.GetUvLockFromDir(dir)
+		case lockfile.PdmLock:
+			lock, err = pypi.GetPdmLockFromDir(dir)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't use the versions %s pins, resolving to the highest ones: %w", lf.String(), err)
+		}
+
+		return lock.Packages(), nil
+	}
+
+	return []pypi.PoetryPackage{}, nil
+}
@@ -17,20 +17,31 @@ package to
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/cli/cli/pkg/iostreams"
 	"github.com/listendev/lstn/internal/project"
 	"github.com/listendev/lstn/pkg/cmd/arguments"
+	"github.com/listendev/lstn/pkg/cmd/flags"
 	"github.com/listendev/lstn/pkg/cmd/groups"
 	"github.com/listendev/lstn/pkg/cmd/options"
 	"github.com/listendev/lstn/pkg/cmd/packagesprinter"
+	"github.com/listendev/lstn/pkg/cmd/verdicts"
 	pkgcontext "github.com/listendev/lstn/pkg/context"
 	"github.com/listendev/lstn/pkg/jsonpath"
 	"github.com/listendev/lstn/pkg/listen"
 	"github.com/listendev/lstn/pkg/npm"
+	"github.com/listendev/lstn/pkg/policy"
+	"github.com/listendev/lstn/pkg/purl"
+	"github.com/listendev/lstn/pkg/pypi"
+	"github.com/listendev/lstn/pkg/suppress"
+	"github.com/listendev/lstn/pkg/upgrade"
 	"github.com/listendev/pkg/ecosystem"
 	"github.com/spf13/cobra"
 )
@@ -55,7 +66,32 @@ Using this command, you can audit a single package version or all the versions o
 
 Specifying the package name is mandatory.
 
-It lists out the verdicts of all the versions of the input package name.`,
+It lists out the verdicts of all the versions of the input package name.
+
+You can also reference the package with a package URL (purl), in which case it must be the only argument.
+The ecosystem, the name, the version, and the checksum qualifier (sha1 for npm, sha256 for PyPi) come from it.
+
+The package is an npm one, unless you ask for the PyPi ecosystem with the --ecosystem flag or with the pypi: prefix on its name.
+For PyPi packages, the version constraints are PEP 440 version specifiers (or Poetry ones) resolved through the PyPi JSON API,
+while the shasum is the SHA256 digest of a distribution file.
+
+For npm packages, the version constraints are resolved against the registry, and with the credentials,
+configured in the .npmrc file of the current directory and in the user one.
+
+When it couldn't get the verdicts of some of the versions, it lists them as not analysed and exits with status code 3.
+
+Use the --offline flag to answer from a verdicts bundle (see lstn export) rather than from listen.dev.
+In such a case, the exact versions need no registry, and the versions the bundle does not cover are listed as not analysed.
+
+Use the --fail-on, --fail-on-codes, and --fail-on-problems flags to exit with a non-zero status code (see lstn help exit)
+when some verdicts have a severity, or some codes, or when some packages have problems.
+
+The rules in the .lstnignore.yaml file of the current directory suppress the verdicts they match, with a reason, an owner, and an optional expiry date.
+The suppressed verdicts are listed apart, and they do not make the --fail-on flags fail.
+
+Use the --compare flag to get which verdicts appear or disappear between the consecutive versions, from the lowest one,
+rather than the verdicts of every version. It also recommends the closest version to the lowest one without high severity verdicts.
+This is handy to choose the version to upgrade to, within a version constraint.`,
 		Example: `  # Get the verdicts for all the chalk versions that listen.dev owns
   lstn to chalk
   lstn to debug 4.3.4
@@ -68,7 +104,25 @@ It lists out the verdicts of all the versions of the input package name.`,
   # Get the verdicts for tap versions >= 16.3.0 and < 16.4.0
   lstn to tap "^16.3.0"
   # Get the verdicts for prettier versions >= 2.7.0 <= 3.0.0
-  lstn to prettier ">=2.7.0 <=3.0.0"`,
+  lstn to prettier ">=2.7.0 <=3.0.0"
+
+  # Get the verdicts for the PyPi requests package versions >= 2.31 and < 3
+  lstn to --ecosystem pypi requests ">=2.31,<3"
+  lstn to pypi:requests 2.32.3
+
+  # Get the verdicts for the package a package URL references
+  lstn to pkg:npm/%40vue/devtools@6.5.0
+  lstn to pkg:pypi/requests@2.31.0
+
+  # Get the verdicts for a package version from a verdicts bundle
+  lstn to debug 4.3.4 --offline --verdicts-bundle lstn-verdicts.json
+
+  # Fail when a package version has high severity verdicts
+  lstn to react 18.0.0 --fail-on high
+
+  # Compare the verdicts of the debug versions >= 4.3.1 and < 5, to choose the one to upgrade 4.3.1 to
+  lstn to debug "^4.3.1" --compare
+  lstn to --ecosystem pypi requests ">=2.28,<3" --compare --json`,
 		// Executes before RunE
 		Args: func(c *cobra.Command, args []string) error {
 			// Do not enforce arguments validation when users uses --debug-options
@@ -76,6 +130,14 @@ It lists out the verdicts of all the versions of the input package name.`,
 				return nil
 			}
 
+			if len(args) > 0 && purl.IsPURL(args[0]) {
+				return arguments.PackageTriple(c, args)
+			}
+			eco, args := ecosystemFromArgs(toOpts, args)
+			if eco == ecosystem.Pypi {
+				return arguments.PyPiPackageTriple(c, args)
+			}
+
 			return arguments.PackageTriple(c, args)
 		},
 		ValidArgsFunction: arguments.PackageTripleActiveHelp,
@@ -83,13 +145,45 @@ It lists out the verdicts of all the versions of the input package name.`,
 			"source": project.GetSourceURL(filename),
 		},
 		PreRunE: func(c *cobra.Command, args []string) error {
+			// Package URLs reference exact versions, so there's nothing to resolve
+			if len(args) > 0 && purl.IsPURL(args[0]) {
+				return nil
+			}
+			eco, args := ecosystemFromArgs(toOpts, args)
 			if len(args) > 1 {
-				// Theoretically, it's impossible args[1] is not a valid semver constraint at this point
-				constraints, _ := semver.NewConstraint(args[1])
+				// The verdicts bundle answers for the exact versions without resolving them against the registry
+				cfgOpts, ok := c.Context().Value(pkgcontext.ConfigKey).(*flags.ConfigFlags)
+				if ok && cfgOpts.Offline && isExactVersion(eco, args[1]) {
+					return nil
+				}
 
-				versions, err := npm.GetVersionsFromRegistry(c.Context(), args[0], constraints)
-				if err != nil {
-					return err
+				var versions any
+				switch eco {
+				case ecosystem.Pypi:
+					// Theoretically, it's impossible args[1] is not a valid version specifier at this point
+					constraints, _ := pypi.NewConstraints(args[1])
+
+					pyVersions, err := pypi.GetVersionsFromRegistry(c.Context(), pypi.NormalizeName(args[0]), constraints)
+					if err != nil {
+						return err
+					}
+					versions = pyVersions
+				default:
+					// Theoretically, it's impossible args[1] is not a valid semver constraint at this point
+					constraints, _ := semver.NewConstraint(args[1])
+
+					// Use the registries and the credentials in the .npmrc files
+					npmrc, err := npm.GetNpmrc(".")
+					if err != nil {
+						return err
+					}
+					npmCtx := context.WithValue(c.Context(), pkgcontext.NpmrcKey, npmrc)
+
+					npmVersions, err := npm.GetVersionsFromRegistry(npmCtx, args[0], constraints)
+					if err != nil {
+						return err
+					}
+					versions = npmVersions
 				}
 
 				// Store all of its versions matching the constraints
@@ -119,30 +213,62 @@ It lists out the verdicts of all the versions of the input package name.`,
 			}
 
 			var res *listen.Response
-			var resJSON []byte
 			var resErr error
 
 			io := c.Context().Value(pkgcontext.IOStreamsKey).(*iostreams.IOStreams)
 			io.StartProgressIndicator()
 			defer io.StopProgressIndicator()
 
-			versions, multiple := ctx.Value(pkgcontext.VersionsCollection).(semver.Collection)
-			if multiple {
-				nv := len(versions)
+			// Drop the verdicts that the suppression rules of the working directory match
+			cwd, err := arguments.GetDirectory([]string{})
+			if err != nil {
+				return err
+			}
+			rules, err := suppress.Load(filepath.Join(cwd, suppress.Filename))
+			if err != nil {
+				return err
+			}
+			for _, warning := range rules.ExpiredWarnings(time.Now()) {
+				c.PrintErrln(io.ColorScheme().WarningIcon(), warning)
+			}
+
+			eco, args := ecosystemFromArgs(toOpts, args)
+			if eco == ecosystem.Pypi {
+				args = append([]string{pypi.NormalizeName(args[0])}, args[1:]...)
+			}
 
-				names := make([]string, nv)
-				for i := 0; i < nv; i++ {
+			versions := []string{}
+			multiple := true
+			switch collection := ctx.Value(pkgcontext.VersionsCollection).(type) {
+			case semver.Collection:
+				for _, v := range collection {
+					versions = append(versions, v.String())
+				}
+			case pypi.Versions:
+				for _, v := range collection {
+					versions = append(versions, v.String())
+				}
+			default:
+				multiple = false
+			}
+			if multiple {
+				names := make([]string, len(versions))
+				for i := range names {
 					names[i] = args[0]
 				}
 
 				// Create list of verdicts requests
-				reqs, multipleErr := listen.NewBulkVerdictsRequests(names, versions, toOpts.Expression)
+				reqs, multipleErr := listen.NewBulkVerdictsRequestsFromStrings(names, versions, toOpts.Expression)
 				if multipleErr != nil {
 					return multipleErr
 				}
 
 				// Query for verdicts about specific package versions...
-				res, resJSON, resErr = listen.BulkPackages(reqs, listen.WithContext(ctx), listen.WithJSONOptions(toOpts.JSONFlags))
+				res, _, resErr = listen.BulkPackages(
+					reqs,
+					listen.WithContext(ctx),
+					listen.WithEcosystem(eco),
+				)
 
 				goto EXIT
 			}
@@ -157,35 +283,79 @@ It lists out the verdicts of all the versions of the input package name.`,
 				}
 				req.Select = jsonpath.Make(toOpts.Expression)
 
-				res, resJSON, resErr = listen.Packages(
+				res, _, resErr = listen.Packages(
 					req,
 					listen.WithContext(ctx),
-					listen.WithEcosystem(ecosystem.Npm), // FIXME: only NPM atm
-					listen.WithJSONOptions(toOpts.JSONFlags),
+					listen.WithEcosystem(eco),
 				)
+
+				// List the package version the verdicts bundle does not cover as not analysed
+				var notInBundleErr *listen.RequestError
+				if errors.As(resErr, &notInBundleErr) && errors.Is(resErr, listen.ErrNotInBundle) {
+					resErr = &listen.PartialResultsError{Errors: []*listen.RequestError{notInBundleErr}, Total: 1}
+					res = &listen.Response{}
+				}
 			}
 
 		EXIT:
-			if resErr != nil {
-				return err
+			// Render the verdicts of the other versions anyway when only some requests failed
+			notAnalysed := []listen.NotAnalysed{}
+			var partialErr *listen.PartialResultsError
+			switch {
+			case errors.As(resErr, &partialErr):
+				notAnalysed = partialErr.NotAnalysed()
+			case resErr != nil:
+				return resErr
 			}
 
-			if resJSON != nil {
-				fmt.Fprintf(io.Out, "%s", resJSON)
+			if res == nil {
+				return resErr
 			}
+			*res = verdicts.Suppress(rules, eco, *res)
 
-			if res == nil {
-				return nil
+			// Compare the verdicts of the versions rather than listing them
+			var advice *upgrade.Advice
+			if toOpts.Compare {
+				advice = upgrade.Compare(eco, *res)
 			}
 
-			tablePrinter := packagesprinter.NewTablePrinter(io)
+			suppressed := rules.Take()
+			tablePrinter := packagesprinter.NewTablePrinter(io, packagesprinter.WithSuppressed(suppressed), packagesprinter.WithNotAnalysed(notAnalysed))
+			switch {
+			case toOpts.JSON:
+				// The comparison of the versions gets its own JSON output
+				var out any = res
+				if advice != nil {
+					out = advice
+				}
+				resJSON, err := verdicts.JSON(ctx, toOpts.JSONFlags, out)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(io.Out, "%s", resJSON)
 
-			return tablePrinter.RenderPackages(res)
+				if err := verdicts.WriteSummary(io.ErrOut, verdicts.Summary{Suppressed: suppressed, NotAnalysed: notAnalysed}); err != nil {
+					return err
+				}
+			case advice != nil:
+				if err := tablePrinter.RenderComparison(advice); err != nil {
+					return err
+				}
+			default:
+				if err := tablePrinter.RenderPackages(res); err != nil {
+					return err
+				}
+			}
+
+			// Fail on the package versions violating the policy, once reported
+			verdicts.Check(policy.FromContext(ctx), *res)
+
+			return policy.Result(ctx, resErr)
 		},
 	}
 
 	// Local flags will only run when this command is called directly
-	toOpts.Attach(toCmd, []string{"--reporter", "--gh-owner", "--gh-repo", "--gh-pull-id", "--gh-token", "--jwt-token", "--ignore-packages", "--ignore-deptypes", "--lockfiles", "core-endpoint"})
+	toOpts.Attach(toCmd, []string{"--reporter", "--gh-owner", "--gh-repo", "--gh-pull-id", "--gh-token", "--jwt-token", "--ignore-packages", "--ignore-deptypes", "--ignore-groups", "--lockfiles", "core-endpoint"})
 
 	// Pass the options through the context
 	ctx = context.WithValue(ctx, pkgcontext.ToKey, toOpts)
@@ -193,3 +363,46 @@ It lists out the verdicts of all the versions of the input package name.`,
 
 	return toCmd, nil
 }
+
+// isExactVersion tells whether the input version constraint is a single version of the input ecosystem.
+func isExactVersion(eco ecosystem.Ecosystem, constraint string) bool {
+	if eco == ecosystem.Pypi {
+		_, err := pypi.NewVersion(constraint)
+
+		return err == nil
+	}
+	_, err := semver.StrictNewVersion(constraint)
+
+	return err == nil
+}
+
+// pypiPrefix is the prefix of the package names telling they are PyPi packages (eg., pypi:requests).
+const pypiPrefix = "pypi:"
+
+// ecosystemFromArgs returns the ecosystem of the input package along with the arguments without its prefix (if any).
+//
+// It turns a package URL into the name, version, and digest arguments.
+// Both package URLs and the pypi: prefix take precedence over the --ecosystem flag.
+func ecosystemFromArgs(toOpts *options.To, args []string) (ecosystem.Ecosystem, []string) {
+	if len(args) == 1 && purl.IsPURL(args[0]) {
+		if p, err := purl.Parse(args[0]); err == nil {
+			ret := []string{p.FullName()}
+			if p.Version != "" {
+				ret = append(ret, p.Version)
+				if digest := p.Digest(); digest != "" {
+					ret = append(ret, digest)
+				}
+			}
+
+			return p.Ecosystem(), ret
+		}
+	}
+	if len(args) > 0 && strings.HasPrefix(strings.ToLower(args[0]), pypiPrefix) {
+		return ecosystem.Pypi, append([]string{args[0][len(pypiPrefix):]}, args[1:]...)
+	}
+	if toOpts.Ecosystem == ecosystem.Pypi.String() {
+		return ecosystem.Pypi, args
+	}
+
+	return ecosystem.Npm, args
+}
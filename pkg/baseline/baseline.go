@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/listendev/pkg/models"
+)
+
+// Version is the version of the baseline format.
+const Version = 1
+
+// Snapshot is the normalized snapshot of the verdicts of some package versions.
+type Snapshot struct {
+	Version  int        `json:"version"`
+	Packages []*Package `json:"packages"`
+}
+
+// Package is a package version in a snapshot.
+type Package struct {
+	Ecosystem string    `json:"ecosystem"`
+	Name      string    `json:"name"`
+	Version   string    `json:"version,omitempty"`
+	Verdicts  []Verdict `json:"verdicts"`
+}
+
+// Verdict is a verdict in a snapshot.
+//
+// Its code and its message identify it.
+type Verdict struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (v Verdict) key() string {
+	return v.Code + "\x00" + v.Message
+}
+
+func packageKey(eco, name, version string) string {
+	return eco + "\x00" + name + "\x00" + version
+}
+
+func verdictFrom(v models.Verdict) Verdict {
+	return Verdict{Code: v.Code.String(), Severity: v.Severity.String(), Message: v.Message}
+}
+
+// Baseline compares the verdicts of the current run with the ones of a previous snapshot,
+// and records them into a new snapshot.
+//
+// It is safe for concurrent use. A nil Baseline considers everything new.
+type Baseline struct {
+	// previous maps the package versions of the previous snapshot to their verdicts
+	previous map[string]map[string]bool
+	// path is the file to write the new snapshot into
+	path string
+
+	mu      sync.Mutex
+	current map[string]*Package
+	known   uint
+}
+
+// New creates the baseline comparing with the snapshot in the input file,
+// and writing the new snapshot into the other input file.
+//
+// It returns nil when both the files are empty.
+func New(path, writePath string) (*Baseline, error) {
+	if path == "" && writePath == "" {
+		return nil, nil
+	}
+
+	ret := &Baseline{path: writePath, current: map[string]*Package{}}
+	if path == "" {
+		return ret, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the baseline: %w", err)
+	}
+	s := &Snapshot{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("couldn't decode the baseline %s: %w", path, err)
+	}
+	if s.Version != Version {
+		return nil, fmt.Errorf("the baseline %s has an unsupported version (%d)", path, s.Version)
+	}
+	ret.previous = make(map[string]map[string]bool, len(s.Packages))
+	for _, p := range s.Packages {
+		verdicts := make(map[string]bool, len(p.Verdicts))
+		for _, v := range p.Verdicts {
+			verdicts[v.key()] = true
+		}
+		ret.previous[packageKey(p.Ecosystem, p.Name, p.Version)] = verdicts
+	}
+
+	return ret, nil
+}
+
+// Diff returns the verdicts of the input package version that the previous snapshot does not have,
+// and whether to report the package version at all.
+//
+// It reports all the package versions that are not in the previous snapshot,
+// while it reports the other ones only when they have new verdicts.
+// It records the package version into the new snapshot.
+func (b *Baseline) Diff(eco, name, version string, verdicts []models.Verdict) ([]models.Verdict, bool) {
+	if b == nil {
+		return verdicts, true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.record(eco, name, version, verdicts)
+
+	if b.previous == nil {
+		return verdicts, true
+	}
+	known, ok := b.previous[packageKey(eco, name, version)]
+	if !ok {
+		return verdicts, true
+	}
+	ret := []models.Verdict{}
+	for _, v := range verdicts {
+		if known[verdictFrom(v).key()] {
+			b.known++
+
+			continue
+		}
+		ret = append(ret, v)
+	}
+
+	return ret, len(ret) > 0
+}
+
+func (b *Baseline) record(eco, name, version string, verdicts []models.Verdict) {
+	k := packageKey(eco, name, version)
+	p, ok := b.current[k]
+	if !ok {
+		p = &Package{Ecosystem: eco, Name: name, Version: version, Verdicts: []Verdict{}}
+		b.current[k] = p
+	}
+	for _, v := range verdicts {
+		p.Verdicts = append(p.Verdicts, verdictFrom(v))
+	}
+}
+
+// TakeKnown returns how many verdicts already in the previous snapshot it hid since the previous call.
+func (b *Baseline) TakeKnown() uint {
+	if b == nil {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ret := b.known
+	b.known = 0
+
+	return ret
+}
+
+// Comparing tells whether it compares with a previous snapshot.
+func (b *Baseline) Comparing() bool {
+	return b != nil && b.previous != nil
+}
+
+// Snapshot returns the normalized snapshot of the package versions recorded so far.
+//
+// Its package versions are sorted, and the verdicts of each one are sorted and unique.
+func (b *Baseline) Snapshot() *Snapshot {
+	ret := &Snapshot{Version: Version, Packages: []*Package{}}
+	if b == nil {
+		return ret
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, p := range b.current {
+		seen := map[string]bool{}
+		verdicts := []Verdict{}
+		for _, v := range p.Verdicts {
+			if !seen[v.key()] {
+				seen[v.key()] = true
+				verdicts = append(verdicts, v)
+			}
+		}
+		sort.Slice(verdicts, func(i, j int) bool {
+			if verdicts[i].Code != verdicts[j].Code {
+				return verdicts[i].Code < verdicts[j].Code
+			}
+
+			return verdicts[i].Message < verdicts[j].Message
+		})
+		ret.Packages = append(ret.Packages, &Package{Ecosystem: p.Ecosystem, Name: p.Name, Version: p.Version, Verdicts: verdicts})
+	}
+	sort.Slice(ret.Packages, func(i, j int) bool {
+		return packageKey(ret.Packages[i].Ecosystem, ret.Packages[i].Name, ret.Packages[i].Version) <
+			packageKey(ret.Packages[j].Ecosystem, ret.Packages[j].Name, ret.Packages[j].Version)
+	})
+
+	return ret
+}
+
+// Write writes the new snapshot into its file, if any.
+func (b *Baseline) Write() error {
+	if b == nil || b.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(b.Snapshot(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(b.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("couldn't write the baseline: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/listendev/pkg/models"
+	"github.com/listendev/pkg/models/severity"
+	"github.com/listendev/pkg/verdictcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	stn001 = models.Verdict{Code: verdictcode.STN001, Severity: severity.High, Message: "unexpected outbound connection"}
+	tsn01  = models.Verdict{Code: verdictcode.TSN01, Severity: severity.Medium, Message: "typosquatting"}
+)
+
+func TestNewWithoutFiles(t *testing.T) {
+	b, err := New("", "")
+	require.Nil(t, err)
+	assert.Nil(t, b)
+
+	// A nil Baseline considers everything new
+	verdicts, ok := b.Diff("npm", "react", "18.0.0", []models.Verdict{stn001})
+	assert.True(t, ok)
+	assert.Equal(t, []models.Verdict{stn001}, verdicts)
+	assert.False(t, b.Comparing())
+	assert.Zero(t, b.TakeKnown())
+	assert.Nil(t, b.Write())
+}
+
+func TestNewErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := New(filepath.Join(dir, "missing.json"), "")
+	assert.ErrorContains(t, err, "couldn't read the baseline")
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.Nil(t, os.WriteFile(invalid, []byte("{"), 0o600))
+	_, err = New(invalid, "")
+	assert.ErrorContains(t, err, "couldn't decode the baseline "+invalid)
+
+	unsupported := filepath.Join(dir, "unsupported.json")
+	require.Nil(t, os.WriteFile(unsupported, []byte(`{"version":2,"packages":[]}`), 0o600))
+	_, err = New(unsupported, "")
+	assert.EqualError(t, err, "the baseline "+unsupported+" has an unsupported version (2)")
+}
+
+func TestWriteThenDiff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+
+	// Only recording: everything is new
+	b, err := New("", path)
+	require.Nil(t, err)
+	verdicts, ok := b.Diff("npm", "react", "18.0.0", []models.Verdict{stn001, stn001})
+	assert.True(t, ok)
+	assert.Len(t, verdicts, 2)
+	_, ok = b.Diff("npm", "chalk", "5.3.0", []models.Verdict{})
+	assert.True(t, ok)
+	assert.False(t, b.Comparing())
+	require.Nil(t, b.Write())
+
+	// The snapshot is sorted, and its verdicts are unique
+	assert.Equal(t, &Snapshot{Version: Version, Packages: []*Package{
+		{Ecosystem: "npm", Name: "chalk", Version: "5.3.0", Verdicts: []Verdict{}},
+		{Ecosystem: "npm", Name: "react", Version: "18.0.0", Verdicts: []Verdict{{Code: "STN001", Severity: "high", Message: "unexpected outbound connection"}}},
+	}}, b.Snapshot())
+
+	b, err = New(path, "")
+	require.Nil(t, err)
+	assert.True(t, b.Comparing())
+
+	// Known package versions without new verdicts
+	_, ok = b.Diff("npm", "chalk", "5.3.0", []models.Verdict{})
+	assert.False(t, ok)
+	_, ok = b.Diff("npm", "react", "18.0.0", []models.Verdict{stn001})
+	assert.False(t, ok)
+	assert.Equal(t, uint(1), b.TakeKnown())
+	assert.Zero(t, b.TakeKnown())
+
+	// Known package versions with new verdicts
+	verdicts, ok = b.Diff("npm", "react", "18.0.0", []models.Verdict{stn001, tsn01})
+	assert.True(t, ok)
+	assert.Equal(t, []models.Verdict{tsn01}, verdicts)
+	assert.Equal(t, uint(1), b.TakeKnown())
+
+	// New package versions
+	verdicts, ok = b.Diff("npm", "react", "18.2.0", []models.Verdict{stn001})
+	assert.True(t, ok)
+	assert.Equal(t, []models.Verdict{stn001}, verdicts)
+	_, ok = b.Diff("pypi", "chalk", "5.3.0", []models.Verdict{})
+	assert.True(t, ok)
+	assert.Zero(t, b.TakeKnown())
+}
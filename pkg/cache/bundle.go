@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+)
+
+// BundleVersion is the version of the verdicts bundle format.
+const BundleVersion = 1
+
+// Bundle is a self-contained set of verdicts, answering the requests without querying listen.dev.
+//
+// Unlike the cache, its entries never expire and do not depend on the listen.dev endpoint.
+type Bundle struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Entries   []*Entry  `json:"entries"`
+	// Checksum is the SHA256 digest of the JSON encoding of the entries
+	Checksum string `json:"checksum"`
+
+	index map[Key]*Entry
+}
+
+// NewBundle creates an empty verdicts bundle.
+func NewBundle() *Bundle {
+	return &Bundle{
+		Version:   BundleVersion,
+		CreatedAt: time.Now().UTC(),
+		Entries:   []*Entry{},
+		index:     map[Key]*Entry{},
+	}
+}
+
+// bundleKey returns the key of the input one in a bundle.
+//
+// The package versions do not depend on the endpoint, nor on the digest the lock files record.
+func bundleKey(k Key) Key {
+	k.Endpoint = ""
+	if k.Name != "" {
+		k.Digest = ""
+	}
+
+	return k
+}
+
+// Add stores the input value for the input key, replacing the value it had (if any).
+func (b *Bundle) Add(k Key, value any) error {
+	v, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	k = bundleKey(k)
+	if e, ok := b.index[k]; ok {
+		e.Value = v
+		e.CreatedAt = time.Now().UTC()
+
+		return nil
+	}
+	e := &Entry{Key: k, CreatedAt: time.Now().UTC(), Value: v}
+	b.Entries = append(b.Entries, e)
+	b.index[k] = e
+
+	return nil
+}
+
+// Get unmarshals the value of the input key into the input target.
+//
+// It tells whether the bundle covers the key.
+// A nil Bundle covers nothing.
+func (b *Bundle) Get(k Key, target any) bool {
+	if b == nil {
+		return false
+	}
+	e, ok := b.index[bundleKey(k)]
+	if !ok {
+		return false
+	}
+
+	return json.Unmarshal(e.Value, target) == nil
+}
+
+func (b *Bundle) checksum() (string, error) {
+	data, err := json.Marshal(b.Entries)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// WriteFile writes the bundle, along with its checksum, into the input file.
+func (b *Bundle) WriteFile(path string) error {
+	checksum, err := b.checksum()
+	if err != nil {
+		return err
+	}
+	b.Checksum = checksum
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// ReadBundle reads the verdicts bundle in the input file.
+//
+// It fails when the checksum of the bundle does not match its entries.
+func ReadBundle(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the verdicts bundle: %w", err)
+	}
+	ret := &Bundle{}
+	if err := json.Unmarshal(data, ret); err != nil {
+		return nil, fmt.Errorf("couldn't decode the verdicts bundle %s: %w", path, err)
+	}
+	if ret.Version != BundleVersion {
+		return nil, fmt.Errorf("the verdicts bundle %s has an unsupported version (%d)", path, ret.Version)
+	}
+	checksum, err := ret.checksum()
+	if err != nil {
+		return nil, err
+	}
+	if checksum != ret.Checksum {
+		return nil, fmt.Errorf("the verdicts bundle %s is corrupted: its checksum does not match", path)
+	}
+
+	ret.index = make(map[Key]*Entry, len(ret.Entries))
+	for _, e := range ret.Entries {
+		ret.index[bundleKey(e.Key)] = e
+	}
+
+	return ret, nil
+}
+
+// BundleFromContext returns the verdicts bundle in the input context, if any.
+func BundleFromContext(ctx context.Context) *Bundle {
+	b, _ := ctx.Value(pkgcontext.VerdictsBundleKey).(*Bundle)
+
+	return b
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle(t *testing.T) {
+	b := NewBundle()
+	lock := Key{Endpoint: "https://npm.listen.dev", Ecosystem: "npm", Digest: "sha256:0123"}
+	pkg := Key{Endpoint: "https://npm.listen.dev", Ecosystem: "npm", Name: "react", Version: "18.2.0"}
+	require.Nil(t, b.Add(lock, []value{{Name: "react"}, {Name: "js-tokens"}}))
+	require.Nil(t, b.Add(pkg, value{Name: "old"}))
+	require.Nil(t, b.Add(pkg, value{Name: "react"}))
+	assert.Len(t, b.Entries, 2)
+
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	require.Nil(t, b.WriteFile(path))
+
+	read, err := ReadBundle(path)
+	require.Nil(t, err)
+	assert.Equal(t, b.Checksum, read.Checksum)
+	assert.True(t, strings.HasPrefix(read.Checksum, "sha256:"))
+
+	got := value{}
+	assert.True(t, read.Get(pkg, &got))
+	assert.Equal(t, "react", got.Name)
+	// Neither the endpoint nor the digest of the package versions matter
+	assert.True(t, read.Get(Key{Endpoint: "http://127.0.0.1:3000", Ecosystem: "npm", Name: "react", Version: "18.2.0", Digest: "b468736d1f4a5891f38585ba8e8fb29f91c3cb96"}, &value{}))
+	// While the digest of the lock files does
+	all := []value{}
+	assert.True(t, read.Get(Key{Ecosystem: "npm", Digest: "sha256:0123"}, &all))
+	assert.Len(t, all, 2)
+	assert.False(t, read.Get(Key{Ecosystem: "npm", Digest: "sha256:4567"}, &all))
+
+	assert.False(t, read.Get(Key{Ecosystem: "npm", Name: "react", Version: "18.3.0"}, &value{}))
+	assert.False(t, read.Get(Key{Ecosystem: "pypi", Name: "react", Version: "18.2.0"}, &value{}))
+}
+
+func TestReadBundleErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := ReadBundle(filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "couldn't read the verdicts bundle")
+
+	b := NewBundle()
+	require.Nil(t, b.Add(Key{Ecosystem: "npm", Name: "react", Version: "18.2.0"}, value{Name: "react"}))
+	path := filepath.Join(dir, "bundle.json")
+	require.Nil(t, b.WriteFile(path))
+
+	data, err := os.ReadFile(path)
+	require.Nil(t, err)
+
+	tampered := filepath.Join(dir, "tampered.json")
+	require.Nil(t, os.WriteFile(tampered, []byte(strings.Replace(string(data), "18.2.0", "18.3.0", 1)), 0o600))
+	_, err = ReadBundle(tampered)
+	assert.EqualError(t, err, "the verdicts bundle "+tampered+" is corrupted: its checksum does not match")
+
+	future := filepath.Join(dir, "future.json")
+	require.Nil(t, os.WriteFile(future, []byte(strings.Replace(string(data), `"version":1`, `"version":2`, 1)), 0o600))
+	_, err = ReadBundle(future)
+	assert.EqualError(t, err, "the verdicts bundle "+future+" has an unsupported version (2)")
+
+	var nilBundle *Bundle
+	assert.False(t, nilBundle.Get(Key{Ecosystem: "npm", Name: "react", Version: "18.2.0"}, &value{}))
+}
@@ -0,0 +1,313 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+)
+
+// Key identifies the verdicts of a package version.
+//
+// The verdicts of a lock file analysis have no name and no version, while their digest is the one of the lock file.
+type Key struct {
+	// Endpoint is the base URL of the listen.dev API that emitted the verdicts
+	Endpoint  string `json:"endpoint"`
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Digest    string `json:"digest,omitempty"`
+	// Select is the server-side filter of the verdicts
+	Select string `json:"select,omitempty"`
+}
+
+// Hash returns the SHA256 digest of the key, naming its cache entry.
+func (k Key) Hash() string {
+	h := sha256.New()
+	for _, part := range []string{k.Endpoint, k.Ecosystem, k.Name, k.Version, k.Digest, k.Select} {
+		// The NUL separator avoids the collisions among the concatenations of the parts
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (k Key) String() string {
+	if k.Name == "" {
+		return fmt.Sprintf("%s lock file %s", k.Ecosystem, k.Digest)
+	}
+	ret := fmt.Sprintf("%s %s", k.Ecosystem, k.Name)
+	if k.Version != "" {
+		ret += "@" + k.Version
+	}
+
+	return ret
+}
+
+// Entry is a cached listen.dev API response.
+type Entry struct {
+	Key       Key             `json:"key"`
+	CreatedAt time.Time       `json:"created_at"`
+	Value     json.RawMessage `json:"value"`
+
+	// path is the file storing the entry
+	path string
+	size int64
+}
+
+// Size returns the size in bytes of the file storing the entry.
+func (e *Entry) Size() int64 {
+	return e.size
+}
+
+// Cache stores the listen.dev API responses on disk, one file for each key.
+type Cache struct {
+	dir     string
+	ttl     time.Duration
+	refresh bool
+	now     func() time.Time
+}
+
+// Option customizes a Cache.
+type Option func(*Cache)
+
+// WithRefresh makes the cache ignore the stored entries, while still storing the new ones.
+func WithRefresh() Option {
+	return func(c *Cache) {
+		c.refresh = true
+	}
+}
+
+// New creates a cache in the input directory, whose entries expire after the input duration.
+func New(dir string, ttl time.Duration, opts ...Option) *Cache {
+	ret := &Cache{dir: dir, ttl: ttl, now: time.Now}
+	for _, opt := range opts {
+		opt(ret)
+	}
+
+	return ret
+}
+
+// DefaultDir returns the directory of the cache under the user cache directory.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "lstn", "verdicts"), nil
+}
+
+// NewFromConfig creates the cache in the default directory, with the TTL and the refresh mode of the configuration options.
+func NewFromConfig(cfg *flags.ConfigFlags) (*Cache, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	opts := []Option{}
+	if cfg.Refresh {
+		opts = append(opts, WithRefresh())
+	}
+
+	return New(dir, time.Hour*time.Duration(cfg.Cache.TTL), opts...), nil
+}
+
+// FromContext returns the cache in the input context, if any.
+func FromContext(ctx context.Context) *Cache {
+	c, _ := ctx.Value(pkgcontext.VerdictsCacheKey).(*Cache)
+
+	return c
+}
+
+// Dir returns the directory of the cache.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Expired tells whether the input entry is older than the cache TTL.
+func (c *Cache) Expired(e *Entry) bool {
+	return c.now().Sub(e.CreatedAt) > c.ttl
+}
+
+func (c *Cache) path(k Key) string {
+	h := k.Hash()
+
+	return filepath.Join(c.dir, h[:2], h+".json")
+}
+
+func readEntry(path string) (*Entry, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ret := &Entry{}
+	if err := json.Unmarshal(b, ret); err != nil {
+		return nil, err
+	}
+	ret.path = path
+	ret.size = int64(len(b))
+
+	return ret, nil
+}
+
+// Get unmarshals the value of the input key into the input target.
+//
+// It tells whether the cache had a valid entry for the key.
+// A nil Cache never has any.
+func (c *Cache) Get(k Key, target any) bool {
+	if c == nil || c.refresh {
+		return false
+	}
+	e, err := readEntry(c.path(k))
+	if err != nil || e.Key != k || c.Expired(e) {
+		return false
+	}
+
+	return json.Unmarshal(e.Value, target) == nil
+}
+
+// Put stores the input value for the input key.
+//
+// It does nothing on a nil Cache.
+func (c *Cache) Put(k Key, value any) error {
+	if c == nil {
+		return nil
+	}
+	v, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(&Entry{Key: k, CreatedAt: c.now().UTC(), Value: v})
+	if err != nil {
+		return err
+	}
+
+	path := c.path(k)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	// Write to a temporary file then rename it, so that concurrent readers never see partial entries
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// walk calls the input function for every file of the cache entries.
+func (c *Cache) walk(fn func(path string) error) error {
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".json") {
+			return nil
+		}
+
+		return fn(path)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// Entries returns the cache entries, from the most recent one.
+//
+// It skips the files that are not valid entries.
+func (c *Cache) Entries() ([]*Entry, error) {
+	ret := []*Entry{}
+	err := c.walk(func(path string) error {
+		if e, err := readEntry(path); err == nil {
+			ret = append(ret, e)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].CreatedAt.After(ret[j].CreatedAt)
+	})
+
+	return ret, nil
+}
+
+// Prune removes the expired entries, and the files that are not valid entries.
+//
+// It returns the number of removed files.
+func (c *Cache) Prune() (int, error) {
+	count := 0
+	err := c.walk(func(path string) error {
+		if e, err := readEntry(path); err == nil && !c.Expired(e) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		count++
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Clear removes all the entries.
+//
+// It returns the number of removed files.
+func (c *Cache) Clear() (int, error) {
+	count := 0
+	err := c.walk(func(path string) error {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		count++
+
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	return count, os.RemoveAll(c.dir)
+}
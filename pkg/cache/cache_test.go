@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type value struct {
+	Name     string   `json:"name"`
+	Verdicts []string `json:"verdicts"`
+}
+
+func newTestCache(t *testing.T, now *time.Time, opts ...Option) *Cache {
+	t.Helper()
+
+	c := New(filepath.Join(t.TempDir(), "verdicts"), time.Hour, opts...)
+	c.now = func() time.Time {
+		return *now
+	}
+
+	return c
+}
+
+func TestGetPut(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestCache(t, &now)
+
+	k := Key{Endpoint: "https://npm.listen.dev", Ecosystem: "npm", Name: "react", Version: "18.2.0"}
+	got := value{}
+	assert.False(t, c.Get(k, &got))
+
+	want := value{Name: "react", Verdicts: []string{"a", "b"}}
+	require.Nil(t, c.Put(k, want))
+	assert.True(t, c.Get(k, &got))
+	assert.Equal(t, want, got)
+
+	// Keys differing in any part do not share the entry
+	for _, other := range []Key{
+		{Endpoint: "https://npm.listen.dev", Ecosystem: "npm", Name: "react", Version: "18.3.0"},
+		{Endpoint: "http://127.0.0.1:3000", Ecosystem: "npm", Name: "react", Version: "18.2.0"},
+		{Endpoint: "https://npm.listen.dev", Ecosystem: "npm", Name: "react", Version: "18.2.0", Select: "@.severity == \"high\""},
+	} {
+		assert.False(t, c.Get(other, &value{}), other.String())
+	}
+
+	// Entries expire after the TTL
+	now = now.Add(time.Hour + time.Second)
+	assert.False(t, c.Get(k, &value{}))
+}
+
+func TestRefresh(t *testing.T) {
+	now := time.Now()
+	dir := filepath.Join(t.TempDir(), "verdicts")
+	k := Key{Endpoint: "https://pypi.listen.dev", Ecosystem: "pypi", Name: "requests", Version: "2.31.0"}
+
+	require.Nil(t, New(dir, time.Hour).Put(k, value{Name: "old"}))
+
+	c := New(dir, time.Hour, WithRefresh())
+	c.now = func() time.Time {
+		return now
+	}
+	assert.False(t, c.Get(k, &value{}))
+	require.Nil(t, c.Put(k, value{Name: "new"}))
+
+	got := value{}
+	assert.True(t, New(dir, time.Hour).Get(k, &got))
+	assert.Equal(t, "new", got.Name)
+}
+
+func TestNilCache(t *testing.T) {
+	var c *Cache
+	k := Key{Ecosystem: "npm", Name: "react", Version: "18.2.0"}
+
+	assert.Nil(t, c.Put(k, value{}))
+	assert.False(t, c.Get(k, &value{}))
+}
+
+func TestEntriesPruneClear(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestCache(t, &now)
+
+	old := Key{Ecosystem: "npm", Name: "react", Version: "18.2.0"}
+	require.Nil(t, c.Put(old, value{Name: "react"}))
+	now = now.Add(2 * time.Hour)
+	recent := Key{Ecosystem: "npm", Digest: "sha256:0123"}
+	require.Nil(t, c.Put(recent, value{}))
+	// A file that is not a cache entry
+	require.Nil(t, os.WriteFile(filepath.Join(c.Dir(), "garbage.json"), []byte("{"), 0o600))
+
+	entries, err := c.Entries()
+	require.Nil(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, recent, entries[0].Key)
+		assert.Equal(t, "npm lock file sha256:0123", entries[0].Key.String())
+		assert.False(t, c.Expired(entries[0]))
+		assert.Equal(t, old, entries[1].Key)
+		assert.Equal(t, "npm react@18.2.0", entries[1].Key.String())
+		assert.True(t, c.Expired(entries[1]))
+		assert.Greater(t, entries[1].Size(), int64(0))
+	}
+
+	count, err := c.Prune()
+	require.Nil(t, err)
+	assert.Equal(t, 2, count)
+	entries, err = c.Entries()
+	require.Nil(t, err)
+	assert.Len(t, entries, 1)
+
+	count, err = c.Clear()
+	require.Nil(t, err)
+	assert.Equal(t, 1, count)
+	assert.NoDirExists(t, c.Dir())
+
+	// An empty cache has nothing to list, prune, or clear
+	entries, err = c.Entries()
+	require.Nil(t, err)
+	assert.Empty(t, entries)
+	count, err = c.Prune()
+	require.Nil(t, err)
+	assert.Zero(t, count)
+	count, err = c.Clear()
+	require.Nil(t, err)
+	assert.Zero(t, count)
+}
@@ -21,8 +21,8 @@ import (
 	"path/filepath"
 
 	"github.com/XANi/goneric"
+	"github.com/listendev/lstn/pkg/lockfile"
 	"github.com/listendev/lstn/pkg/validate"
-	"github.com/listendev/pkg/lockfile"
 	"github.com/spf13/cobra"
 )
 
@@ -22,7 +22,7 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/listendev/pkg/lockfile"
+	"github.com/listendev/lstn/pkg/lockfile"
 	"github.com/stretchr/testify/require"
 )
 
@@ -94,6 +94,17 @@ func TestGetLockfiles(t *testing.T) {
 				lockfile.PoetryLock:      {fmt.Errorf("testdata/not-existing/poetry.lock not found")},
 			},
 		},
+		{
+			inputCWD:       "testdata",
+			inputLockfiles: []string{"package-lock.json", "yarn.lock", "not-existing/yarn.lock"},
+			wantLockfiles: map[string]lockfile.Lockfile{
+				"testdata/package-lock.json": lockfile.PackageLockJSON,
+				"testdata/yarn.lock":         lockfile.YarnLock,
+			},
+			wantErrors: map[lockfile.Lockfile][]error{
+				lockfile.YarnLock: {fmt.Errorf("testdata/not-existing/yarn.lock not found")},
+			},
+		},
 		{
 			inputCWD:       "testdata",
 			inputLockfiles: []string{"unsupported-lockfile.json", "poetry.lock", "1/poetry.lock", "unk/package-lock.json", "not-existing/poetry.lock"},
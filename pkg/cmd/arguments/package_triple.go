@@ -18,7 +18,10 @@ package arguments
 import (
 	"fmt"
 
+	"github.com/listendev/lstn/pkg/purl"
+	"github.com/listendev/lstn/pkg/pypi"
 	"github.com/listendev/lstn/pkg/validate"
+	"github.com/listendev/pkg/ecosystem"
 	"github.com/spf13/cobra"
 )
 
@@ -28,6 +31,7 @@ import (
 // It checks that there are no more that 3 arguments.
 // It accepts a single version or versions constraints as the second argument,
 // in which case the third one is ignored.
+// It also accepts a package URL (eg., pkg:npm/debug@4.3.4) as the only argument.
 func PackageTriple(c *cobra.Command, args []string) error {
 	if err := cobra.MinimumNArgs(1)(c, args); err != nil {
 		return fmt.Errorf("requires at least 1 arg (package name)")
@@ -35,6 +39,13 @@ func PackageTriple(c *cobra.Command, args []string) error {
 	if err := cobra.RangeArgs(1, 3)(c, args); err != nil {
 		return err
 	}
+	if purl.IsPURL(args[0]) {
+		if len(args) > 1 {
+			return fmt.Errorf("a package URL must be the only argument")
+		}
+
+		return invalidArguments(packageURL(args[0]))
+	}
 
 	all := []error{}
 	switch len(args) {
@@ -64,7 +75,90 @@ func PackageTriple(c *cobra.Command, args []string) error {
 		}
 	}
 
-	// Format errors (if any)
+	return invalidArguments(all)
+}
+
+// PyPiPackageTriple validates that the arguments are a triple PyPi package, version, and SHA256 digest.
+//
+// It checks that there's a valid Python package name as first arguments, at least.
+// It checks that there are no more that 3 arguments.
+// It accepts a single PEP 440 version or PEP 440 version specifiers as the second argument,
+// in which case the third one is ignored, while still having to be a valid SHA256 digest.
+func PyPiPackageTriple(c *cobra.Command, args []string) error {
+	if err := cobra.MinimumNArgs(1)(c, args); err != nil {
+		return fmt.Errorf("requires at least 1 arg (package name)")
+	}
+	if err := cobra.RangeArgs(1, 3)(c, args); err != nil {
+		return err
+	}
+
+	all := []error{}
+	switch len(args) {
+	case 3:
+		// Validate third argument is a SHA256 digest
+		if err := validate.Singleton.Var(args[2], "sha256"); err != nil {
+			all = append(all, fmt.Errorf("%s is not a valid SHA256 digest", args[2]))
+		}
+
+		fallthrough
+	case 2:
+		// Validate second argument is a valid PEP 440 version
+		if err := validate.Singleton.Var(args[1], "pep440"); err != nil {
+			// Then, check whether it is a valid version specifier
+			if _, err := pypi.NewConstraints(args[1]); err != nil {
+				all = append(all, fmt.Errorf("%s is neither a valid version specifier nor an exact valid PEP 440 version", args[1]))
+			}
+		}
+
+		fallthrough
+	case 1:
+		if err := validate.Singleton.Var(args[0], "pypi_package_name"); err != nil {
+			// Check the first argument is a valid package name
+			all = append(all, fmt.Errorf("%s is not a valid PyPi package name", args[0]))
+		}
+	}
+
+	return invalidArguments(all)
+}
+
+// packageURL validates that the input is a package URL of a package lstn supports,
+// and that its name, version, and checksum are valid for its ecosystem.
+func packageURL(arg string) []error {
+	p, err := purl.Parse(arg)
+	if err != nil {
+		return []error{fmt.Errorf("%s is not a valid package URL: %w", arg, err)}
+	}
+
+	all := []error{}
+	var nameTag, versionTag, digestTag, digestAlgo string
+	switch p.Ecosystem() {
+	case ecosystem.Npm:
+		nameTag, versionTag, digestTag, digestAlgo = "npm_package_name", "semver", "shasum", "sha1"
+	case ecosystem.Pypi:
+		nameTag, versionTag, digestTag, digestAlgo = "pypi_package_name", "pep440", "sha256", "sha256"
+	default:
+		return []error{fmt.Errorf("%s is not a package URL of the npm or pypi type", arg)}
+	}
+
+	if err := validate.Singleton.Var(p.FullName(), nameTag); err != nil {
+		all = append(all, fmt.Errorf("%s is not a valid %s package name", p.FullName(), p.Ecosystem().Case()))
+	}
+	if p.Version != "" {
+		if err := validate.Singleton.Var(p.Version, versionTag); err != nil {
+			all = append(all, fmt.Errorf("%s is not a valid %s package version", p.Version, p.Ecosystem().Case()))
+		}
+	}
+	if _, ok := p.Qualifiers["checksum"]; ok {
+		if err := validate.Singleton.Var(p.Digest(), digestTag); err != nil {
+			all = append(all, fmt.Errorf("%s does not contain a valid %s checksum", arg, digestAlgo))
+		}
+	}
+
+	return all
+}
+
+// invalidArguments formats the input validation errors (if any) into a single error.
+func invalidArguments(all []error) error {
 	if len(all) > 0 {
 		ret := "invalid arguments"
 		for _, e := range all {
@@ -85,7 +179,7 @@ func PackageTripleActiveHelp(_ *cobra.Command, args []string, _ /*toComplete*/ s
 
 	switch len(args) {
 	case 0:
-		comps = cobra.AppendActiveHelp(comps, "Provide a package name")
+		comps = cobra.AppendActiveHelp(comps, "Provide a package name or a package URL")
 	case 1:
 		comps = cobra.AppendActiveHelp(comps, fmt.Sprintf("Provide the version of package %s, or a version constraint, or just execute the command like it is now", args[0]))
 	case 2:
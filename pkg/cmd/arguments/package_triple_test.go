@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package arguments
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageTripleWithPackageURL(t *testing.T) {
+	cases := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"pkg:npm/%40vue/devtools@6.5.0"}, ""},
+		{[]string{"pkg:npm/debug@4.3.4?checksum=sha1:b468736d1f4a5891f38585ba8e8fb29f91c3cb96"}, ""},
+		{[]string{"pkg:pypi/Django_REST@3.15.2"}, ""},
+		{[]string{"pkg:pypi/requests"}, ""},
+		{[]string{"pkg:npm/react", "18.0.0"}, "a package URL must be the only argument"},
+		{[]string{"pkg:npm"}, "invalid arguments\n       pkg:npm is not a valid package URL: missing the type"},
+		{[]string{"pkg:gem/rails@7.1.0"}, "invalid arguments\n       pkg:gem/rails@7.1.0 is not a package URL of the npm or pypi type"},
+		{[]string{"pkg:npm/react@latest"}, "invalid arguments\n       latest is not a valid NPM package version"},
+		{[]string{"pkg:pypi/-requests@2.x"}, "invalid arguments\n       -requests is not a valid PyPi package name\n       2.x is not a valid PyPi package version"},
+		{[]string{"pkg:npm/debug@4.3.4?checksum=sha256:aaaa"}, "invalid arguments\n       pkg:npm/debug@4.3.4?checksum=sha256:aaaa does not contain a valid sha1 checksum"},
+	}
+
+	for _, tc := range cases {
+		err := PackageTriple(&cobra.Command{}, tc.args)
+		if tc.wantErr == "" {
+			assert.NoError(t, err, tc.args)
+
+			continue
+		}
+		if assert.Error(t, err, tc.args) {
+			assert.Equal(t, tc.wantErr, err.Error())
+		}
+	}
+}
+
+func TestPyPiPackageTriple(t *testing.T) {
+	cases := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"requests"}, ""},
+		{[]string{"Flask_SQLAlchemy", "3.1.1"}, ""},
+		{[]string{"requests", ">=2.31,<3"}, ""},
+		{[]string{"requests", "^2.31"}, ""},
+		{[]string{"click", "8.1.7", "ae74fb96c20a0277a1d615f1e4d73c8414f5a98db8b799a7931d1582f3390c28"}, ""},
+		{[]string{}, "requires at least 1 arg (package name)"},
+		{[]string{"-requests"}, "invalid arguments\n       -requests is not a valid PyPi package name"},
+		{[]string{"requests", "~=2"}, "invalid arguments\n       ~=2 is neither a valid version specifier nor an exact valid PEP 440 version"},
+		{[]string{"click", "8.1.7", "notadigest"}, "invalid arguments\n       notadigest is not a valid SHA256 digest"},
+		{[]string{"click", ">8", "notadigest"}, "invalid arguments\n       notadigest is not a valid SHA256 digest"},
+	}
+
+	for _, tc := range cases {
+		err := PyPiPackageTriple(&cobra.Command{}, tc.args)
+		if tc.wantErr == "" {
+			assert.NoError(t, err, tc.args)
+
+			continue
+		}
+		if assert.Error(t, err, tc.args) {
+			assert.Equal(t, tc.wantErr, err.Error())
+		}
+	}
+}
@@ -44,7 +44,7 @@ func (suite *FlagsBaseSuite) TestGetNames() {
 	res := GetNames(&ScanOpts{})
 
 	// Expecting all the (sub)fields
-	assert.Len(suite.T(), res, 18)
+	assert.Len(suite.T(), res, 35)
 }
 
 func (suite *FlagsBaseSuite) TestGetDefaults() {
@@ -54,7 +54,7 @@ func (suite *FlagsBaseSuite) TestGetDefaults() {
 	}
 	res := GetDefaults(&ScanOpts{})
 
-	assert.Len(suite.T(), res, 8)
+	assert.Len(suite.T(), res, 15)
 }
 
 func (suite *FlagsBaseSuite) TestGetField() {
@@ -93,26 +93,61 @@ func (suite *FlagsBaseSuite) TestValidate() {
 		{
 			"empty config flags",
 			&ConfigFlags{},
-			[]string{"timeout must be 30 or greater", "NPM endpoint must be a valid URL", "PyPi endpoint must be a valid URL", "Core API must be a valid URL"},
+			[]string{"timeout must be 30 or greater", "concurrency must be 1 or greater", "cache TTL must be 1 or greater", "NPM endpoint must be a valid URL", "PyPi endpoint must be a valid URL", "Core API must be a valid URL"},
 		},
 		{
 			"invalid timeout",
-			&ConfigFlags{Timeout: 29, Endpoint: Endpoint{Npm: "http://127.0.0.1:3000", PyPi: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
+			&ConfigFlags{Timeout: 29, Concurrency: 8, Cache: Cache{TTL: 24}, Endpoint: Endpoint{Npm: "http://127.0.0.1:3000", PyPi: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
 			[]string{"timeout must be 30 or greater"},
 		},
+		{
+			"invalid concurrency",
+			&ConfigFlags{Timeout: 31, Concurrency: 65, Cache: Cache{TTL: 24}, Endpoint: Endpoint{Npm: "http://127.0.0.1:3000", PyPi: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
+			[]string{"concurrency must be 64 or less"},
+		},
+		{
+			"offline without verdicts bundle",
+			&ConfigFlags{Timeout: 31, Concurrency: 8, Cache: Cache{TTL: 24}, OfflineMode: OfflineMode{Offline: true}, Endpoint: Endpoint{Npm: "http://127.0.0.1:3000", PyPi: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
+			[]string{"verdicts bundle is mandatory when using --offline"},
+		},
+		{
+			"client key without client certificate",
+			&ConfigFlags{Timeout: 31, Concurrency: 8, Cache: Cache{TTL: 24}, Network: Network{ClientKey: "base_test.go"}, Endpoint: Endpoint{Npm: "http://127.0.0.1:3000", PyPi: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
+			[]string{"client certificate is mandatory when using --client-key"},
+		},
+		{
+			"missing CA certificates",
+			&ConfigFlags{Timeout: 31, Concurrency: 8, Cache: Cache{TTL: 24}, Network: Network{CACert: "testdata/unexistent.pem"}, Endpoint: Endpoint{Npm: "http://127.0.0.1:3000", PyPi: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
+			[]string{"CA certificates is not a valid existing file"},
+		},
+		{
+			"invalid proxy",
+			&ConfigFlags{Timeout: 31, Concurrency: 8, Cache: Cache{TTL: 24}, Network: Network{Proxy: "proxy"}, Endpoint: Endpoint{Npm: "http://127.0.0.1:3000", PyPi: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
+			[]string{"proxy must be a valid URL"},
+		},
+		{
+			"invalid fail on severity",
+			&ConfigFlags{Timeout: 31, Concurrency: 8, Cache: Cache{TTL: 24}, Policy: Policy{FailOn: "critical"}, Endpoint: Endpoint{Npm: "http://127.0.0.1:3000", PyPi: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
+			[]string{"fail on must be one of [low medium high]"},
+		},
+		{
+			"invalid cache TTL",
+			&ConfigFlags{Timeout: 31, Concurrency: 8, Cache: Cache{TTL: 0}, Endpoint: Endpoint{Npm: "http://127.0.0.1:3000", PyPi: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
+			[]string{"cache TTL must be 1 or greater"},
+		},
 		{
 			"invalid NPM endpoint",
-			&ConfigFlags{Timeout: 31, Endpoint: Endpoint{Npm: "http://invalid.endpoint", PyPi: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
+			&ConfigFlags{Timeout: 31, Concurrency: 8, Cache: Cache{TTL: 24}, Endpoint: Endpoint{Npm: "http://invalid.endpoint", PyPi: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
 			[]string{"NPM endpoint must be a valid listen.dev endpoint"},
 		},
 		{
 			"invalid PyPi endpoint",
-			&ConfigFlags{Timeout: 31, Endpoint: Endpoint{PyPi: "http://invalid.endpoint", Npm: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
+			&ConfigFlags{Timeout: 31, Concurrency: 8, Cache: Cache{TTL: 24}, Endpoint: Endpoint{PyPi: "http://invalid.endpoint", Npm: "http://127.0.0.1:3001", Core: "http://127.0.0.1:3002"}},
 			[]string{"PyPi endpoint must be a valid listen.dev endpoint"},
 		},
 		{
 			"valid config flags",
-			&ConfigFlags{Timeout: 31, Endpoint: Endpoint{Npm: "http://127.0.0.1:3000", PyPi: "http://127.0.0.1:3000", Core: "http://127.0.0.1:3002"}},
+			&ConfigFlags{Timeout: 31, Concurrency: 8, Cache: Cache{TTL: 24}, Endpoint: Endpoint{Npm: "http://127.0.0.1:3000", PyPi: "http://127.0.0.1:3000", Core: "http://127.0.0.1:3002"}},
 			[]string{},
 		},
 	}
@@ -208,12 +243,14 @@ func (suite *FlagsBaseSuite) TestTransform() {
 			"custom registry with leading slash",
 			&ConfigFlags{
 				Registry: Registry{
-					NPM: "https://registry.npm.org/",
+					NPM:  "https://registry.npm.org/",
+					PyPi: "https://pypi.org/",
 				},
 			},
 			&ConfigFlags{
 				Registry: Registry{
-					NPM: "https://registry.npm.org",
+					NPM:  "https://registry.npm.org",
+					PyPi: "https://pypi.org",
 				},
 				Reporting: Reporting{
 					Types: []cmd.ReportType{},
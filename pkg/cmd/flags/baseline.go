@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package flags
+
+type BaselineFlags struct {
+	Baseline      string `desc:"report only the packages and the verdicts that the given baseline file does not have" flag:"baseline"       flagset:"Baseline" json:"baseline"       name:"baseline" validate:"omitempty,file"`
+	WriteBaseline string `desc:"write the verdicts into the given baseline file"                                      flag:"write-baseline" flagset:"Baseline" json:"write-baseline" name:"write baseline"`
+}
@@ -46,7 +46,8 @@ type TokenMandatory struct {
 }
 
 type Registry struct {
-	NPM string `default:"https://registry.npmjs.org" desc:"set a custom NPM registry" flag:"npm-registry" flagset:"Registry" json:"npm-registry" name:"NPM registry" transform:"tsuffix=/" validate:"omitempty,url"`
+	NPM  string `default:"https://registry.npmjs.org" desc:"set a custom NPM registry"  flag:"npm-registry"  flagset:"Registry" json:"npm-registry"  name:"NPM registry"  transform:"tsuffix=/" validate:"omitempty,url"`
+	PyPi string `default:"https://pypi.org"            desc:"set a custom PyPi registry" flag:"pypi-registry" flagset:"Registry" json:"pypi-registry" name:"PyPi registry" transform:"tsuffix=/" validate:"omitempty,url"`
 }
 
 type Pull struct {
@@ -68,8 +69,9 @@ type Reporting struct {
 }
 
 type Ignore struct {
-	Packages []string          `default:"[]"                                         desc:"the list of packages to not process" flag:"ignore-packages" json:"ignore-packages"         name:"ignore packages" transform:"unique"`
-	Deptypes []npmdeptype.Enum `desc:"the list of dependencies types to not process" flag:"ignore-deptypes"                     json:"ignore-deptypes" name:"ignore dependency types" transform:"unique"`
+	Packages []string          `default:"[]"                                         desc:"the list of packages to not process"                               flag:"ignore-packages" json:"ignore-packages"         name:"ignore packages" transform:"unique"`
+	Deptypes []npmdeptype.Enum `desc:"the list of dependencies types to not process" flag:"ignore-deptypes"                                                   json:"ignore-deptypes" name:"ignore dependency types" transform:"unique"`
+	Groups   []string          `default:"[]"                                         desc:"the list of dependency groups (eg., poetry groups) to not process" flag:"ignore-groups"   json:"ignore-groups"           name:"ignore groups"   transform:"unique"`
 
 	types *enumflag.EnumFlagValue[npmdeptype.Enum]
 }
@@ -79,6 +81,30 @@ type Filtering struct {
 	Expression string `desc:"filter the output verdicts using a jsonpath script expression (server-side)" flag:"select" flagset:"Filtering" json:"select" name:"filter verdicts" shorthand:"s"`
 }
 
+type Cache struct {
+	TTL     int  `default:"24"                                                desc:"set for how many hours to reuse the cached verdicts" flag:"cache-ttl" flagset:"Cache" json:"cache-ttl" name:"cache TTL" validate:"number,min=1"`
+	NoCache bool `desc:"do not use the verdicts cache"                        flag:"no-cache"                                            flagset:"Cache"  json:"no-cache" name:"no cache"`
+	Refresh bool `desc:"ignore the cached verdicts, and cache the fresh ones" flag:"refresh"                                             flagset:"Cache"  json:"refresh"  name:"refresh"`
+}
+
+type OfflineMode struct {
+	Offline bool   `desc:"answer from a verdicts bundle, without querying listen.dev"     flag:"offline"         flagset:"Offline" json:"offline"         name:"offline"`
+	Bundle  string `desc:"set the verdicts bundle (see lstn export) to answer from offline" flag:"verdicts-bundle" flagset:"Offline" json:"verdicts-bundle" name:"verdicts bundle" validate:"required_if=Offline true"`
+}
+
+type Network struct {
+	Proxy      string `desc:"set the proxy URL of the outgoing requests (defaults to the HTTPS_PROXY environment variable)" flag:"proxy"       flagset:"Network" json:"proxy"       name:"proxy"              validate:"omitempty,url"`
+	CACert     string `desc:"set a PEM file with the additional CA certificates to trust"                                 flag:"ca-cert"     flagset:"Network" json:"ca-cert"     name:"CA certificates"    validate:"omitempty,file"`
+	ClientCert string `desc:"set a PEM file with the client certificate for mutual TLS"                                   flag:"client-cert" flagset:"Network" json:"client-cert" name:"client certificate" validate:"required_with=ClientKey,omitempty,file"`
+	ClientKey  string `desc:"set a PEM file with the private key of the client certificate"                               flag:"client-key"  flagset:"Network" json:"client-key"  name:"client key"         validate:"omitempty,file"`
+}
+
+type Policy struct {
+	FailOn         string   `desc:"fail when some verdicts have the given severity (low, medium, high) or a higher one" flag:"fail-on"                                                                       flagset:"Policy"     json:"fail-on"          name:"fail on"       validate:"omitempty,oneof=low medium high"`
+	FailOnCodes    []string `default:"[]"                                                                               desc:"fail when some verdicts have the given codes or code groups (eg., STN, TSN01)" flag:"fail-on-codes" flagset:"Policy"        json:"fail-on-codes" name:"fail on codes" transform:"unique" validate:"dive,alphanum"`
+	FailOnProblems bool     `desc:"fail when some packages have problems"                                               flag:"fail-on-problems"                                                              flagset:"Policy"     json:"fail-on-problems" name:"fail on problems"`
+}
+
 type Endpoint struct {
 	Npm  string `default:"https://npm.listen.dev"  desc:"the listen.dev endpoint emitting the NPM verdicts"  flag:"npm-endpoint"  flagset:"Config" json:"npm"  name:"NPM endpoint"  transform:"tsuffix=/" validate:"url,endpoint"`
 	PyPi string `default:"https://pypi.listen.dev" desc:"the listen.dev endpoint emitting the PyPi verdicts" flag:"pypi-endpoint" flagset:"Config" json:"pypi" name:"PyPi endpoint" transform:"tsuffix=/" validate:"url,endpoint"`
@@ -114,14 +140,21 @@ func (e Endpoint) IsLocalCore() bool {
 
 // ConfigFlags are the options that the CLI also reads from the YAML configuration file.
 type ConfigFlags struct {
-	LogLevel string   `default:"info"  desc:"set the logging level"       flag:"loglevel" flagset:"Config" json:"loglevel" name:"log level"`                          // TODO > validator
-	Timeout  int      `default:"60"    desc:"set the timeout, in seconds" flag:"timeout"  flagset:"Config" json:"timeout"  name:"timeout"   validate:"number,min=30"` // FIXME: change to time.Duration type
-	Endpoint Endpoint `json:"endpoint"`
+	LogLevel    string   `default:"info" desc:"set the logging level"                                            flag:"loglevel"    flagset:"Config" json:"loglevel"    name:"log level"`                            // TODO > validator
+	Timeout     int      `default:"60"   desc:"set the timeout, in seconds"                                      flag:"timeout"     flagset:"Config" json:"timeout"     name:"timeout"     validate:"number,min=30"` // FIXME: change to time.Duration type
+	Retries     int      `default:"3"    desc:"set how many times to retry the failed API requests"              flag:"retries"     flagset:"Config" json:"retries"     name:"retries"     validate:"number,min=0,max=10"`
+	Concurrency int      `default:"8"    desc:"set the maximum number of concurrent requests"                    flag:"concurrency" flagset:"Config" json:"concurrency" name:"concurrency" validate:"number,min=1,max=64"`
+	RateLimit   int      `default:"0"    desc:"set the maximum number of requests per second (0 means no limit)" flag:"rate-limit"  flagset:"Config" json:"rate-limit"  name:"rate limit"  validate:"number,min=0"`
+	Endpoint    Endpoint `json:"endpoint"`
 	Token
 	Registry
+	Cache
+	OfflineMode
+	Network
+	Policy
 	Reporting
 	Filtering
-	Lockfiles []string `default:"[\"package-lock.json\",\"poetry.lock\"]" desc:"set one or more lock file paths (relative to the working dir) to lookup for" flag:"lockfiles" json:"lockfiles" shorthand:"l" transform:"unique"`
+	Lockfiles []string `default:"[\"package-lock.json\",\"pnpm-lock.yaml\",\"poetry.lock\"]" desc:"set one or more lock file paths (relative to the working dir) to lookup for" flag:"lockfiles" json:"lockfiles" shorthand:"l" transform:"unique"`
 }
 
 func NewConfigFlags() (*ConfigFlags, error) {
@@ -103,15 +103,19 @@ func (suite *FlagsConfigSuite) TestNewConfigFlagsDefaults() {
 	assert.Equal(suite.T(), "reviewdog", i.Owner)
 	assert.Equal(suite.T(), "reviewdog", i.Repo)
 	assert.Equal(suite.T(), "https://registry.npmjs.org", i.NPM)
+	assert.Equal(suite.T(), "https://pypi.org", i.Registry.PyPi)
 	assert.Equal(suite.T(), "info", i.LogLevel)
 	assert.Equal(suite.T(), "https://npm.listen.dev", i.Endpoint.Npm)
 	assert.Equal(suite.T(), "https://pypi.listen.dev", i.Endpoint.PyPi)
 	assert.Equal(suite.T(), 60, i.Timeout)
+	assert.Equal(suite.T(), 3, i.Retries)
+	assert.Equal(suite.T(), 8, i.Concurrency)
+	assert.Equal(suite.T(), 0, i.RateLimit)
 }
 
 func (suite *FlagsConfigSuite) TestGetConfigFlagsNames() {
 	m := GetNames(&ConfigFlags{})
-	assert.Equal(suite.T(), 16, len(m))
+	assert.Equal(suite.T(), 33, len(m))
 
 	expected := make(map[string]string)
 	expected["loglevel"] = "LogLevel"
@@ -119,6 +123,9 @@ func (suite *FlagsConfigSuite) TestGetConfigFlagsNames() {
 	expected["pypi-endpoint"] = "Endpoint.PyPi"
 	expected["core-endpoint"] = "Endpoint.Core"
 	expected["timeout"] = "Timeout"
+	expected["retries"] = "Retries"
+	expected["concurrency"] = "Concurrency"
+	expected["rate-limit"] = "RateLimit"
 	expected["gh-token"] = "Token.GitHub"
 	expected["jwt-token"] = "Token.JWT"
 	expected["gh-pull-id"] = "Reporting.GitHub.Pull.ID"
@@ -126,8 +133,22 @@ func (suite *FlagsConfigSuite) TestGetConfigFlagsNames() {
 	expected["gh-owner"] = "Reporting.GitHub.Owner"
 	expected["reporter"] = "Reporting.Types"
 	expected["npm-registry"] = "Registry.NPM"
+	expected["pypi-registry"] = "Registry.PyPi"
+	expected["cache-ttl"] = "Cache.TTL"
+	expected["no-cache"] = "Cache.NoCache"
+	expected["refresh"] = "Cache.Refresh"
+	expected["offline"] = "OfflineMode.Offline"
+	expected["verdicts-bundle"] = "OfflineMode.Bundle"
+	expected["proxy"] = "Network.Proxy"
+	expected["ca-cert"] = "Network.CACert"
+	expected["client-cert"] = "Network.ClientCert"
+	expected["client-key"] = "Network.ClientKey"
+	expected["fail-on"] = "Policy.FailOn"
+	expected["fail-on-codes"] = "Policy.FailOnCodes"
+	expected["fail-on-problems"] = "Policy.FailOnProblems"
 	expected["ignore-packages"] = "Filtering.Ignore.Packages"
 	expected["ignore-deptypes"] = "Filtering.Ignore.Deptypes"
+	expected["ignore-groups"] = "Filtering.Ignore.Groups"
 	expected["select"] = "Filtering.Expression"
 	expected["lockfiles"] = "Lockfiles"
 
@@ -140,7 +161,7 @@ func (suite *FlagsConfigSuite) TestGetConfigFlagsNames() {
 
 func (suite *FlagsConfigSuite) TestGetConfigFlagsDefaults() {
 	m := GetDefaults(&ConfigFlags{})
-	assert.Equal(suite.T(), 8, len(m))
+	assert.Equal(suite.T(), 15, len(m))
 
 	expected := make(map[string]string)
 	expected["npm-endpoint"] = "https://npm.listen.dev"
@@ -148,9 +169,16 @@ func (suite *FlagsConfigSuite) TestGetConfigFlagsDefaults() {
 	expected["core-endpoint"] = "https://core.listen.dev"
 	expected["loglevel"] = "info"
 	expected["timeout"] = "60"
+	expected["retries"] = "3"
+	expected["concurrency"] = "8"
+	expected["rate-limit"] = "0"
 	expected["npm-registry"] = "https://registry.npmjs.org"
+	expected["pypi-registry"] = "https://pypi.org"
+	expected["cache-ttl"] = "24"
 	expected["ignore-packages"] = "[]"
-	expected["lockfiles"] = "[\"package-lock.json\",\"poetry.lock\"]"
+	expected["ignore-groups"] = "[]"
+	expected["fail-on-codes"] = "[]"
+	expected["lockfiles"] = "[\"package-lock.json\",\"pnpm-lock.yaml\",\"poetry.lock\"]"
 
 	for k, v := range m {
 		e, ok := expected[k]
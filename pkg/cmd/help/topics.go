@@ -45,8 +45,20 @@ var Topics = map[string]map[string]string{
 
 			* when a command is running but gets cancelled, the exit code will be 2
 
+			* when a command gets the verdicts of only some of the packages, the exit code will be 3
+
 			* when a command meets an authentication issue, the exit code will be 4
 
+			* when a jq expression halts with an error, the exit code will be 5 (unless it tells another one)
+
+			* when some verdicts have the --fail-on severity or a higher one, the exit code will be 6
+
+			* when some verdicts have the --fail-on-codes codes or code groups, the exit code will be 7
+
+			* when some packages have problems and --fail-on-problems is on, the exit code will be 8
+
+			When the verdicts violate more of the fail-on options, the exit code tells the first one among severity, codes, and problems.
+
 			Notice that it's possible that a particular command may have more exit codes,
 			so it's a good practice to check the docs for the specific command
 			in case you're relying on the exit codes to control some behaviour.
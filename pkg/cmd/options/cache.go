@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package options
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/creasty/defaults"
+	"github.com/listendev/lstn/pkg/cmd"
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	"github.com/listendev/lstn/pkg/cmd/flagusages"
+	"github.com/spf13/cobra"
+)
+
+var _ cmd.CommandOptions = (*CacheLs)(nil)
+
+type CacheLs struct {
+	flags.DebugFlags `flagset:"Debug"`
+	flags.JSONFlags
+	flags.Cache
+}
+
+func NewCacheLs() (*CacheLs, error) {
+	o := &CacheLs{}
+
+	if err := defaults.Set(o); err != nil {
+		return nil, fmt.Errorf("error setting configuration defaults")
+	}
+
+	return o, nil
+}
+
+func (o *CacheLs) Attach(c *cobra.Command, exclusions []string) {
+	flags.Define(c, o, "", exclusions)
+	flagusages.Set(c)
+}
+
+func (o *CacheLs) Validate() []error {
+	return flags.Validate(o)
+}
+
+func (o *CacheLs) Transform(ctx context.Context) error {
+	return flags.Transform(ctx, o)
+}
+
+func (o *CacheLs) AsJSON() string {
+	return flags.AsJSON(o)
+}
+
+var _ cmd.CommandOptions = (*CachePrune)(nil)
+
+type CachePrune struct {
+	flags.DebugFlags `flagset:"Debug"`
+	flags.Cache
+}
+
+func NewCachePrune() (*CachePrune, error) {
+	o := &CachePrune{}
+
+	if err := defaults.Set(o); err != nil {
+		return nil, fmt.Errorf("error setting configuration defaults")
+	}
+
+	return o, nil
+}
+
+func (o *CachePrune) Attach(c *cobra.Command, exclusions []string) {
+	flags.Define(c, o, "", exclusions)
+	flagusages.Set(c)
+}
+
+func (o *CachePrune) Validate() []error {
+	return flags.Validate(o)
+}
+
+func (o *CachePrune) Transform(ctx context.Context) error {
+	return flags.Transform(ctx, o)
+}
+
+func (o *CachePrune) AsJSON() string {
+	return flags.AsJSON(o)
+}
+
+var _ cmd.CommandOptions = (*CacheClear)(nil)
+
+type CacheClear struct {
+	flags.DebugFlags `flagset:"Debug"`
+}
+
+func NewCacheClear() (*CacheClear, error) {
+	o := &CacheClear{}
+
+	if err := defaults.Set(o); err != nil {
+		return nil, fmt.Errorf("error setting configuration defaults")
+	}
+
+	return o, nil
+}
+
+func (o *CacheClear) Attach(c *cobra.Command, exclusions []string) {
+	flags.Define(c, o, "", exclusions)
+	flagusages.Set(c)
+}
+
+func (o *CacheClear) Validate() []error {
+	return flags.Validate(o)
+}
+
+func (o *CacheClear) Transform(ctx context.Context) error {
+	return flags.Transform(ctx, o)
+}
+
+func (o *CacheClear) AsJSON() string {
+	return flags.AsJSON(o)
+}
@@ -30,6 +30,7 @@ var _ cmd.CommandOptions = (*In)(nil)
 
 type In struct {
 	flags.JSONFlags
+	flags.BaselineFlags
 	flags.ConfigFlags
 	flags.DebugFlags `flagset:"Debug"`
 }
@@ -29,8 +29,11 @@ import (
 var _ cmd.CommandOptions = (*Scan)(nil)
 
 type Scan struct {
+	Resolution       string `default:"lockfile" desc:"how to resolve the version constraints (lockfile, highest, lowest)" flag:"resolution" json:"resolution" name:"resolution" validate:"oneof=lockfile highest lowest"`
+	Strict           bool   `desc:"fail when some dependencies cannot be resolved against the registry" flag:"strict" json:"strict" name:"strict"`
 	flags.DebugFlags `flagset:"Debug"`
 	flags.JSONFlags
+	flags.BaselineFlags
 	flags.ConfigFlags
 }
 
@@ -29,6 +29,8 @@ import (
 var _ cmd.CommandOptions = (*To)(nil)
 
 type To struct {
+	Ecosystem        string `default:"npm"                                                                                                         desc:"the ecosystem of the package (npm, pypi)" flag:"ecosystem" json:"ecosystem" name:"ecosystem" validate:"oneof=npm pypi"`
+	Compare          bool   `desc:"compare the verdicts of the consecutive versions, and recommend the closest one without high severity verdicts" flag:"compare"                                  json:"compare"   name:"compare"`
 	flags.DebugFlags `flagset:"Debug"`
 	flags.JSONFlags
 	flags.ConfigFlags
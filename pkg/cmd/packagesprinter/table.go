@@ -20,10 +20,14 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/cli/cli/pkg/iostreams"
 	"github.com/cli/cli/utils"
+	"github.com/listendev/lstn/pkg/diff"
 	"github.com/listendev/lstn/pkg/listen"
+	"github.com/listendev/lstn/pkg/suppress"
+	"github.com/listendev/lstn/pkg/upgrade"
 	"github.com/listendev/pkg/models"
 	"github.com/listendev/pkg/verdictcode"
 )
@@ -53,13 +57,54 @@ func verdictSeverityToColorFunc(colorScheme *iostreams.ColorScheme, sev string)
 }
 
 type TablePrinter struct {
-	streams *iostreams.IOStreams
+	streams     *iostreams.IOStreams
+	groups      map[string][]string
+	notAnalysed []listen.NotAnalysed
+	suppressed  []suppress.Suppressed
+	known       uint
 }
 
-func NewTablePrinter(streams *iostreams.IOStreams) *TablePrinter {
-	return &TablePrinter{
+type TablePrinterOption func(*TablePrinter)
+
+// WithGroups makes the table printer show the dependency groups (eg., main, dev) of the packages.
+//
+// It maps the package names to their groups.
+func WithGroups(groups map[string][]string) TablePrinterOption {
+	return func(t *TablePrinter) {
+		t.groups = groups
+	}
+}
+
+// WithNotAnalysed makes the table printer list the dependencies that lstn didn't analyse.
+func WithNotAnalysed(notAnalysed []listen.NotAnalysed) TablePrinterOption {
+	return func(t *TablePrinter) {
+		t.notAnalysed = notAnalysed
+	}
+}
+
+// WithSuppressed makes the table printer list the verdicts that the suppression rules dropped.
+func WithSuppressed(suppressed []suppress.Suppressed) TablePrinterOption {
+	return func(t *TablePrinter) {
+		t.suppressed = suppressed
+	}
+}
+
+// WithKnown makes the table printer tell how many verdicts it does not show because they are already in the baseline.
+func WithKnown(known uint) TablePrinterOption {
+	return func(t *TablePrinter) {
+		t.known = known
+	}
+}
+
+func NewTablePrinter(streams *iostreams.IOStreams, opts ...TablePrinterOption) *TablePrinter {
+	ret := &TablePrinter{
 		streams: streams,
 	}
+	for _, opt := range opts {
+		opt(ret)
+	}
+
+	return ret
 }
 
 func (t *TablePrinter) RenderPackages(pkgs *listen.Response) error {
@@ -68,8 +113,170 @@ func (t *TablePrinter) RenderPackages(pkgs *listen.Response) error {
 		return err
 	}
 	t.printPackages(pkgs)
+	t.printKnown()
+
+	if err := t.printSuppressed(); err != nil {
+		return err
+	}
+
+	return t.printNotAnalysed()
+}
+
+// RenderChanges renders the changes of the packages between two lock files,
+// along with the verdicts of the new package versions.
+func (t *TablePrinter) RenderChanges(changes diff.Changes) error {
+	if err := t.printChanges(changes); err != nil {
+		return err
+	}
+	res := changes.Response()
+	t.printPackages(&res)
+
+	if err := t.printSuppressed(); err != nil {
+		return err
+	}
+
+	return t.printNotAnalysed()
+}
+
+func (t *TablePrinter) printChanges(changes diff.Changes) error {
+	if len(changes) == 0 {
+		cs := t.streams.ColorScheme()
+		fmt.Fprintf(t.streams.Out, "%s No changes to the dependencies\n", cs.SuccessIcon())
+
+		return nil
+	}
 
-	return nil
+	tab := utils.NewTablePrinter(t.streams)
+	cs := t.streams.ColorScheme()
+	for _, c := range changes {
+		switch c.Kind {
+		case diff.Added:
+			tab.AddField("+", nil, cs.Green)
+			tab.AddField(c.Name, nil, cs.Bold)
+			tab.AddField(c.To, nil, nil)
+		case diff.Removed:
+			tab.AddField("-", nil, cs.Red)
+			tab.AddField(c.Name, nil, cs.Bold)
+			tab.AddField(c.From, nil, cs.Gray)
+		case diff.Upgraded, diff.Downgraded:
+			sign := "↑"
+			if c.Kind == diff.Downgraded {
+				sign = "↓"
+			}
+			tab.AddField(sign, nil, cs.Yellow)
+			tab.AddField(c.Name, nil, cs.Bold)
+			tab.AddField(fmt.Sprintf("%s → %s", c.From, c.To), nil, nil)
+		}
+
+		if !c.Analysed() {
+			tab.AddField("", nil, nil)
+			tab.AddField("", nil, nil)
+			tab.EndRow()
+
+			continue
+		}
+
+		verdictsCount := 0
+		for _, v := range c.Verdicts {
+			if v.Code == verdictcode.UNK {
+				continue
+			}
+			verdictsCount++
+		}
+		if verdictsCount > 0 {
+			tab.AddField(fmt.Sprintf("%s %d verdicts", cs.FailureIcon(), verdictsCount), nil, cs.ColorFromString("red"))
+		} else {
+			tab.AddField(fmt.Sprintf("%s %d verdicts", cs.SuccessIcon(), verdictsCount), nil, cs.ColorFromString("green"))
+		}
+		if len(c.Problems) > 0 {
+			tab.AddField(fmt.Sprintf("%s %d problems", cs.WarningIcon(), len(c.Problems)), nil, cs.ColorFromString("yellow"))
+		} else {
+			tab.AddField(fmt.Sprintf("%s %d problems", cs.SuccessIcon(), len(c.Problems)), nil, cs.ColorFromString("green"))
+		}
+		tab.EndRow()
+	}
+
+	return tab.Render()
+}
+
+// RenderComparison renders how the verdicts change between the consecutive versions of a package,
+// along with the version it recommends.
+func (t *TablePrinter) RenderComparison(advice *upgrade.Advice) error {
+	if err := t.printComparison(advice); err != nil {
+		return err
+	}
+	t.printSteps(advice)
+	t.printRecommended(advice)
+
+	if err := t.printSuppressed(); err != nil {
+		return err
+	}
+
+	return t.printNotAnalysed()
+}
+
+func (t *TablePrinter) printComparison(advice *upgrade.Advice) error {
+	if advice == nil {
+		return nil
+	}
+
+	tab := utils.NewTablePrinter(t.streams)
+	cs := t.streams.ColorScheme()
+	for i, v := range advice.Versions {
+		tab.AddField(advice.Name, nil, cs.Bold)
+		tab.AddField(v.Version, nil, nil)
+		if len(v.Verdicts) > 0 {
+			tab.AddField(fmt.Sprintf("%s %d verdicts", cs.FailureIcon(), len(v.Verdicts)), nil, cs.ColorFromString("red"))
+		} else {
+			tab.AddField(fmt.Sprintf("%s %d verdicts", cs.SuccessIcon(), len(v.Verdicts)), nil, cs.ColorFromString("green"))
+		}
+		if i == 0 {
+			tab.AddField("", nil, nil)
+		} else {
+			step := advice.Steps[i-1]
+			tab.AddField(fmt.Sprintf("+%d -%d", len(step.Appeared), len(step.Disappeared)), nil, cs.Gray)
+		}
+		tab.EndRow()
+	}
+
+	return tab.Render()
+}
+
+func (t *TablePrinter) printSteps(advice *upgrade.Advice) {
+	if advice == nil {
+		return
+	}
+
+	cs := t.streams.ColorScheme()
+	out := t.streams.Out
+	for _, step := range advice.Steps {
+		if !step.Changed() {
+			continue
+		}
+		fmt.Fprintf(out, "\nFrom %s to %s\n\n", cs.CyanBold(step.From), cs.CyanBold(step.To))
+		for _, v := range step.Appeared {
+			prioColor := verdictSeverityToColorFunc(cs, v.Severity.String())
+			fmt.Fprintf(out, "  %s %s %s\n", cs.Red("+"), prioColor(fmt.Sprintf("[%s]", v.Severity)), v.Message)
+		}
+		for _, v := range step.Disappeared {
+			prioColor := verdictSeverityToColorFunc(cs, v.Severity.String())
+			fmt.Fprintf(out, "  %s %s %s\n", cs.Green("-"), prioColor(fmt.Sprintf("[%s]", v.Severity)), v.Message)
+		}
+	}
+}
+
+func (t *TablePrinter) printRecommended(advice *upgrade.Advice) {
+	if advice == nil {
+		return
+	}
+
+	cs := t.streams.ColorScheme()
+	if advice.Recommended == "" {
+		fmt.Fprintf(t.streams.Out, "\n%s All the versions of %s have high severity verdicts\n", cs.FailureIcon(), cs.CyanBold(advice.Name))
+
+		return
+	}
+	fmt.Fprintf(t.streams.Out, "\n%s The closest version without high severity verdicts is %s@%s\n", cs.SuccessIcon(), cs.CyanBold(advice.Name), cs.CyanBold(advice.Recommended))
 }
 
 func (t *TablePrinter) printVerdictMetadata(metadata map[string]interface{}) {
@@ -232,6 +439,10 @@ func (t *TablePrinter) printTable(packages *listen.Response) error {
 		}
 		tab.AddField(version, nil, nil)
 
+		if len(t.groups) > 0 {
+			tab.AddField(strings.Join(t.groups[p.Name], ","), nil, cs.Gray)
+		}
+
 		verdictsCount := 0
 		for _, v := range p.Verdicts {
 			if v.Code == verdictcode.UNK {
@@ -248,3 +459,66 @@ func (t *TablePrinter) printTable(packages *listen.Response) error {
 
 	return tab.Render()
 }
+
+func (t *TablePrinter) printKnown() {
+	if t.known == 0 {
+		return
+	}
+
+	cs := t.streams.ColorScheme()
+	verdictsWord := "verdicts"
+	if t.known == 1 {
+		verdictsWord = "verdict"
+	}
+	fmt.Fprintf(t.streams.Out, "\n%s %s %s already in the baseline\n", cs.SuccessIcon(), cs.Bold(strconv.FormatUint(uint64(t.known), 10)), verdictsWord)
+}
+
+func (t *TablePrinter) printSuppressed() error {
+	if len(t.suppressed) == 0 {
+		return nil
+	}
+
+	cs := t.streams.ColorScheme()
+	verdictsWord := "verdicts"
+	if len(t.suppressed) == 1 {
+		verdictsWord = "verdict"
+	}
+	fmt.Fprintf(t.streams.Out, "\n%s %s %s suppressed\n\n", cs.SuccessIcon(), cs.Bold(strconv.Itoa(len(t.suppressed))), verdictsWord)
+
+	tab := utils.NewTablePrinter(t.streams)
+	for _, s := range t.suppressed {
+		tab.AddField(s.Name, nil, cs.Bold)
+		tab.AddField(s.Version, nil, nil)
+		tab.AddField(s.Code, nil, cs.Gray)
+		tab.AddField(s.Reason, nil, nil)
+		tab.AddField(s.Owner, nil, cs.Gray)
+		tab.EndRow()
+	}
+
+	return tab.Render()
+}
+
+func (t *TablePrinter) printNotAnalysed() error {
+	if len(t.notAnalysed) == 0 {
+		return nil
+	}
+
+	cs := t.streams.ColorScheme()
+	dependenciesWord := "dependencies"
+	if len(t.notAnalysed) == 1 {
+		dependenciesWord = "dependency"
+	}
+	fmt.Fprintf(t.streams.Out, "\n%s %s %s not analysed\n\n", cs.WarningIcon(), cs.Bold(strconv.Itoa(len(t.notAnalysed))), dependenciesWord)
+
+	tab := utils.NewTablePrinter(t.streams)
+	for _, n := range t.notAnalysed {
+		tab.AddField(n.Name, nil, cs.Bold)
+		tab.AddField(n.Spec, nil, nil)
+		tab.AddField(n.Group, nil, cs.Gray)
+		tab.AddField(n.Source, nil, cs.Gray)
+		tab.AddField(n.Reason, nil, cs.Yellow)
+		tab.EndRow()
+	}
+
+	return tab.Render()
+}
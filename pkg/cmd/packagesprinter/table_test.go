@@ -21,8 +21,12 @@ import (
 	"testing"
 
 	"github.com/cli/cli/pkg/iostreams"
+	"github.com/listendev/lstn/pkg/diff"
 	"github.com/listendev/lstn/pkg/listen"
+	"github.com/listendev/lstn/pkg/suppress"
+	"github.com/listendev/lstn/pkg/upgrade"
 	"github.com/listendev/pkg/ecosystem"
+	"github.com/listendev/pkg/models/severity"
 	"github.com/listendev/pkg/verdictcode"
 	"github.com/stretchr/testify/require"
 )
@@ -518,3 +522,109 @@ func TestTablePrinter_printTable(t *testing.T) {
 		})
 	}
 }
+
+func TestTablePrinter_printTableWithGroups(t *testing.T) {
+	outBuf := &bytes.Buffer{}
+	tr := NewTablePrinter(&iostreams.IOStreams{Out: outBuf}, WithGroups(map[string][]string{
+		"click":    {"main"},
+		"colorama": {"main", "dev"},
+	}))
+	packages := &listen.Response{
+		listen.Package{
+			Name:     "click",
+			Version:  strPtr("8.1.7"),
+			Verdicts: []listen.Verdict{},
+			Problems: []listen.Problem{},
+		},
+		listen.Package{
+			Name:     "colorama",
+			Version:  strPtr("0.4.6"),
+			Verdicts: []listen.Verdict{},
+			Problems: []listen.Problem{},
+		},
+		listen.Package{
+			Name:     "unknown",
+			Version:  strPtr("1.0.0"),
+			Verdicts: []listen.Verdict{},
+			Problems: []listen.Problem{},
+		},
+	}
+	require.Nil(t, tr.printTable(packages))
+	require.Equal(t, "click\t8.1.7\tmain\t✓ 0 verdicts\t✓ 0 problems\ncolorama\t0.4.6\tmain,dev\t✓ 0 verdicts\t✓ 0 problems\nunknown\t1.0.0\t\t✓ 0 verdicts\t✓ 0 problems\n", outBuf.String())
+}
+
+func TestTablePrinter_printChanges(t *testing.T) {
+	outBuf := &bytes.Buffer{}
+	tr := NewTablePrinter(&iostreams.IOStreams{Out: outBuf})
+	require.Nil(t, tr.printChanges(diff.Changes{}))
+	require.Equal(t, "✓ No changes to the dependencies\n", outBuf.String())
+
+	outBuf.Reset()
+	changes := diff.Compute(ecosystem.Npm,
+		diff.Locked{"react": {"17.0.2"}, "lodash": {"4.17.21"}, "debug": {"4.3.4"}},
+		diff.Locked{"react": {"18.2.0"}, "lodash": {"4.17.20"}, "js-tokens": {"4.0.0"}},
+	)
+	changes.Attach(listen.Response{
+		{Name: "js-tokens", Version: strPtr("4.0.0"), Verdicts: []listen.Verdict{{Code: verdictcode.TSN01, Message: "typosquat"}}, Problems: []listen.Problem{}},
+		{Name: "react", Version: strPtr("18.2.0"), Verdicts: []listen.Verdict{}, Problems: []listen.Problem{}},
+	})
+	require.Nil(t, tr.printChanges(changes))
+	require.Equal(t, "-\tdebug\t4.3.4\t\t\n+\tjs-tokens\t4.0.0\tX 1 verdicts\t✓ 0 problems\n↓\tlodash\t4.17.21 → 4.17.20\t\t\n↑\treact\t17.0.2 → 18.2.0\t✓ 0 verdicts\t✓ 0 problems\n", outBuf.String())
+}
+
+func TestTablePrinter_RenderComparison(t *testing.T) {
+	outBuf := &bytes.Buffer{}
+	tr := NewTablePrinter(&iostreams.IOStreams{Out: outBuf})
+	advice := upgrade.Compare(ecosystem.Npm, listen.Response{
+		{Name: "chalk", Version: strPtr("1.1.0"), Verdicts: []listen.Verdict{{Code: verdictcode.DDN01, Severity: severity.Medium, Message: "shell access"}}},
+		{Name: "chalk", Version: strPtr("1.0.0"), Verdicts: []listen.Verdict{{Code: verdictcode.STN001, Severity: severity.High, Message: "install script"}}},
+		{Name: "chalk", Version: strPtr("1.0.1"), Verdicts: []listen.Verdict{{Code: verdictcode.STN001, Severity: severity.High, Message: "install script"}}},
+	})
+	require.Nil(t, tr.RenderComparison(advice))
+	require.Equal(t, "chalk\t1.0.0\tX 1 verdicts\t\nchalk\t1.0.1\tX 1 verdicts\t+0 -0\nchalk\t1.1.0\tX 1 verdicts\t+1 -1\n\nFrom 1.0.1 to 1.1.0\n\n  + [medium] shell access\n  - [high] install script\n\n✓ The closest version without high severity verdicts is chalk@1.1.0\n", outBuf.String())
+
+	outBuf.Reset()
+	advice = upgrade.Compare(ecosystem.Npm, listen.Response{
+		{Name: "chalk", Version: strPtr("1.0.0"), Verdicts: []listen.Verdict{{Code: verdictcode.STN001, Severity: severity.High, Message: "install script"}}},
+	})
+	require.Nil(t, tr.RenderComparison(advice))
+	require.Equal(t, "chalk\t1.0.0\tX 1 verdicts\t\n\nX All the versions of chalk have high severity verdicts\n", outBuf.String())
+}
+
+func TestTablePrinter_printNotAnalysed(t *testing.T) {
+	outBuf := &bytes.Buffer{}
+	tr := NewTablePrinter(&iostreams.IOStreams{Out: outBuf})
+	require.Nil(t, tr.printNotAnalysed())
+	require.Empty(t, outBuf.String())
+
+	tr = NewTablePrinter(&iostreams.IOStreams{Out: outBuf}, WithNotAnalysed([]listen.NotAnalysed{
+		{Name: "cli", Spec: "git+ssh://git@github.com:npm/cli", Group: "dep", Source: "package.json", Reason: "git repository"},
+		{Name: "ghost", Spec: "^1.0.0", Group: "dev", Source: "packages/a/package.json", Reason: "package ghost doesn't exist on registry https://registry.npmjs.org"},
+	}))
+	require.Nil(t, tr.printNotAnalysed())
+	require.Equal(t, "\n! 2 dependencies not analysed\n\ncli\tgit+ssh://git@github.com:npm/cli\tdep\tpackage.json\tgit repository\nghost\t^1.0.0\tdev\tpackages/a/package.json\tpackage ghost doesn't exist on registry https://registry.npmjs.org\n", outBuf.String())
+}
+
+func TestTablePrinter_printKnown(t *testing.T) {
+	outBuf := &bytes.Buffer{}
+	tr := NewTablePrinter(&iostreams.IOStreams{Out: outBuf})
+	tr.printKnown()
+	require.Empty(t, outBuf.String())
+
+	tr = NewTablePrinter(&iostreams.IOStreams{Out: outBuf}, WithKnown(3))
+	tr.printKnown()
+	require.Equal(t, "\n✓ 3 verdicts already in the baseline\n", outBuf.String())
+}
+
+func TestTablePrinter_printSuppressed(t *testing.T) {
+	outBuf := &bytes.Buffer{}
+	tr := NewTablePrinter(&iostreams.IOStreams{Out: outBuf})
+	require.Nil(t, tr.printSuppressed())
+	require.Empty(t, outBuf.String())
+
+	tr = NewTablePrinter(&iostreams.IOStreams{Out: outBuf}, WithSuppressed([]suppress.Suppressed{
+		{Name: "loose-envify", Version: "1.4.0", Code: "STN001", Message: "bad", Reason: "it only fetches its own docs", Owner: "@security"},
+	}))
+	require.Nil(t, tr.printSuppressed())
+	require.Equal(t, "\n✓ 1 verdict suppressed\n\nloose-envify\t1.4.0\tSTN001\tit only fetches its own docs\t@security\n", outBuf.String())
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package report
+
+import (
+	"io"
+
+	"github.com/listendev/lstn/pkg/cmd/report/templates"
+	"github.com/listendev/lstn/pkg/diff"
+	"github.com/listendev/lstn/pkg/suppress"
+)
+
+type ChangesMarkdownReport struct {
+	output     io.Writer
+	suppressed []suppress.Suppressed
+}
+
+func NewChangesMarkdownReport() *ChangesMarkdownReport {
+	return &ChangesMarkdownReport{}
+}
+
+func (r *ChangesMarkdownReport) WithOutput(w io.Writer) {
+	r.output = w
+}
+
+// WithSuppressed makes the report tell how many verdicts the suppression rules dropped.
+func (r *ChangesMarkdownReport) WithSuppressed(suppressed []suppress.Suppressed) {
+	r.suppressed = suppressed
+}
+
+func (r *ChangesMarkdownReport) Render(changes diff.Changes) error {
+	return templates.RenderChanges(r.output, changes, templates.Summary{
+		Suppressed: uint(len(r.suppressed)),
+	})
+}
@@ -20,10 +20,14 @@ import (
 
 	"github.com/listendev/lstn/pkg/cmd/report/templates"
 	"github.com/listendev/lstn/pkg/listen"
+	"github.com/listendev/lstn/pkg/suppress"
 )
 
 type FullMarkdwonReport struct {
-	output io.Writer
+	output     io.Writer
+	suppressed []suppress.Suppressed
+	baseline   bool
+	known      uint
 }
 
 func NewFullMarkdwonReport() *FullMarkdwonReport {
@@ -34,6 +38,22 @@ func (r *FullMarkdwonReport) WithOutput(w io.Writer) {
 	r.output = w
 }
 
+// WithSuppressed makes the report tell how many verdicts the suppression rules dropped.
+func (r *FullMarkdwonReport) WithSuppressed(suppressed []suppress.Suppressed) {
+	r.suppressed = suppressed
+}
+
+// WithBaseline makes the report tell that it shows only what is new compared to the baseline,
+// and how many verdicts are already in the baseline.
+func (r *FullMarkdwonReport) WithBaseline(known uint) {
+	r.baseline = true
+	r.known = known
+}
+
 func (r *FullMarkdwonReport) Render(packages []listen.Package) error {
-	return templates.RenderContainer(r.output, packages)
+	return templates.RenderContainer(r.output, packages, templates.Summary{
+		Suppressed: uint(len(r.suppressed)),
+		Baseline:   r.baseline,
+		Known:      r.known,
+	})
 }
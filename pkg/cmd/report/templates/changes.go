@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package templates
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/listendev/lstn/pkg/diff"
+)
+
+var changeIcons = map[diff.Kind]string{
+	diff.Added:      "➕",
+	diff.Upgraded:   "⬆️",
+	diff.Downgraded: "⬇️",
+	diff.Removed:    "➖",
+}
+
+var changesFuncs = template.FuncMap{
+	"changeIcon": func(kind diff.Kind) string {
+		return changeIcons[kind]
+	},
+	"changeVersions": func(c *diff.Change) string {
+		switch c.Kind {
+		case diff.Added:
+			return fmt.Sprintf("<code>%s</code>", c.To)
+		case diff.Removed:
+			return fmt.Sprintf("<code>%s</code>", c.From)
+		default:
+			return fmt.Sprintf("<code>%s</code> → <code>%s</code>", c.From, c.To)
+		}
+	},
+}
+
+// RenderChanges renders the markdown report of the changes of the packages between two lock files,
+// along with the verdicts of the new package versions.
+//
+// It also tells how many verdicts the suppression rules dropped from the new package versions.
+func RenderChanges(
+	w io.Writer,
+	changes diff.Changes,
+	summary Summary,
+) error {
+	packages := changes.Response()
+	rendered, err := renderSections(packages)
+	if err != nil {
+		return err
+	}
+
+	tmplData, err := tmplChanges.ReadFile("changes.html")
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("changes").Funcs(changesFuncs).Parse(string(tmplData))
+	if err != nil {
+		return err
+	}
+
+	counts := newAmounts(packages)
+	counts.Suppressed = summary.Suppressed
+
+	return tmpl.Execute(w, struct {
+		Changes        diff.Changes
+		Added          int
+		Upgraded       int
+		Downgraded     int
+		Removed        int
+		Amounts        amounts
+		RenderHigh     string
+		RenderMedium   string
+		RenderLow      string
+		RenderProblems string
+	}{
+		Changes:        changes,
+		Added:          changes.Count(diff.Added),
+		Upgraded:       changes.Count(diff.Upgraded),
+		Downgraded:     changes.Count(diff.Downgraded),
+		Removed:        changes.Count(diff.Removed),
+		Amounts:        counts,
+		RenderHigh:     rendered.high,
+		RenderMedium:   rendered.medium,
+		RenderLow:      rendered.low,
+		RenderProblems: rendered.problems,
+	})
+}
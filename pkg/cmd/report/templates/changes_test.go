@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/listendev/lstn/pkg/diff"
+	"github.com/listendev/lstn/pkg/listen"
+	"github.com/listendev/pkg/ecosystem"
+	"github.com/listendev/pkg/verdictcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderChanges(t *testing.T) {
+	withVerdicts := diff.Compute(ecosystem.Npm,
+		diff.Locked{"react": {"17.0.2"}, "lodash": {"4.17.21"}, "debug": {"4.3.4"}},
+		diff.Locked{"react": {"18.2.0"}, "lodash": {"4.17.20"}, "js-tokens": {"4.0.0"}},
+	)
+	withVerdicts.Attach(listen.Response{
+		{
+			Name:    "js-tokens",
+			Version: strPtr("4.0.0"),
+			Verdicts: []listen.Verdict{
+				{
+					Pkg:      "js-tokens",
+					Version:  "4.0.0",
+					Code:     verdictcode.TSN01,
+					Message:  "js-tokens could be a typosquat of js-token",
+					Severity: "high",
+				},
+			},
+			Problems: []listen.Problem{},
+		},
+		{Name: "react", Version: strPtr("18.2.0"), Verdicts: []listen.Verdict{}, Problems: []listen.Problem{}},
+	})
+
+	tests := []struct {
+		name           string
+		changes        diff.Changes
+		summary        Summary
+		expectedOutput []byte
+		snapshot       bool
+	}{
+		{
+			snapshot:       true,
+			name:           "no changes",
+			changes:        diff.Changes{},
+			expectedOutput: testdataFileToBytes(t, "testdata/changes_none.md"),
+		},
+		{
+			snapshot:       true,
+			name:           "changes with verdicts",
+			changes:        withVerdicts,
+			summary:        Summary{Suppressed: 1},
+			expectedOutput: testdataFileToBytes(t, "testdata/changes_with_verdicts.md"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outBuf := &bytes.Buffer{}
+			require.Nil(t, RenderChanges(outBuf, tt.changes, tt.summary))
+
+			if tt.snapshot {
+				assert.Nil(t, os.WriteFile(fmt.Sprintf("./testdata/snapshots/%s.md", tt.name), outBuf.Bytes(), 0o644))
+			}
+
+			require.Equal(t, tt.expectedOutput, outBuf.Bytes())
+		})
+	}
+}
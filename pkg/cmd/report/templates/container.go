@@ -26,9 +26,21 @@ import (
 )
 
 type amounts struct {
-	Map      map[string]uint
-	Total    uint
-	Problems uint
+	Map        map[string]uint
+	Total      uint
+	Problems   uint
+	Suppressed uint
+	Known      uint
+}
+
+// Summary tells about the verdicts that the report does not show.
+type Summary struct {
+	// Suppressed is how many verdicts the suppression rules dropped
+	Suppressed uint
+	// Baseline tells whether the report shows only the verdicts that are not in the baseline
+	Baseline bool
+	// Known is how many verdicts the report does not show because they are already in the baseline
+	Known uint
 }
 
 func newAmounts(packages []listen.Package) amounts {
@@ -48,7 +60,7 @@ func newAmounts(packages []listen.Package) amounts {
 		}
 	}
 
-	return amounts{m, t, p}
+	return amounts{Map: m, Total: t, Problems: p}
 }
 
 var icons = map[string]string{
@@ -112,26 +124,15 @@ var funcs = template.FuncMap{
 	},
 }
 
+// RenderContainer renders the markdown report of the input packages.
+//
+// It also tells about the verdicts that the suppression rules, or the baseline, dropped from the packages.
 func RenderContainer(
 	w io.Writer,
 	packages []listen.Package,
+	summary Summary,
 ) error {
-	r := NewFromPackages(packages, icons, funcs)
-
-	rHigh, err := r.Severity(severity.High)
-	if err != nil {
-		return err
-	}
-	rMedium, err := r.Severity(severity.Medium)
-	if err != nil {
-		return err
-	}
-	rLow, err := r.Severity(severity.Low)
-	if err != nil {
-		return err
-	}
-
-	rProblems, err := r.Problems()
+	rendered, err := renderSections(packages)
 	if err != nil {
 		return err
 	}
@@ -146,19 +147,54 @@ func RenderContainer(
 		return err
 	}
 
+	counts := newAmounts(packages)
+	counts.Suppressed = summary.Suppressed
+	counts.Known = summary.Known
+
 	return tmpl.Execute(w, struct {
 		Icons          map[string]string
 		Amounts        amounts
+		Baseline       bool
 		RenderHigh     string
 		RenderMedium   string
 		RenderLow      string
 		RenderProblems string
 	}{
 		Icons:          icons,
-		Amounts:        newAmounts(packages),
-		RenderHigh:     rHigh,
-		RenderMedium:   rMedium,
-		RenderLow:      rLow,
-		RenderProblems: rProblems,
+		Amounts:        counts,
+		Baseline:       summary.Baseline,
+		RenderHigh:     rendered.high,
+		RenderMedium:   rendered.medium,
+		RenderLow:      rendered.low,
+		RenderProblems: rendered.problems,
 	})
 }
+
+// sections are the markdown sections listing the verdicts by severity, and the problems, of some packages.
+type sections struct {
+	high     string
+	medium   string
+	low      string
+	problems string
+}
+
+func renderSections(packages []listen.Package) (sections, error) {
+	r := NewFromPackages(packages, icons, funcs)
+
+	var ret sections
+	var err error
+	if ret.high, err = r.Severity(severity.High); err != nil {
+		return ret, err
+	}
+	if ret.medium, err = r.Severity(severity.Medium); err != nil {
+		return ret, err
+	}
+	if ret.low, err = r.Severity(severity.Low); err != nil {
+		return ret, err
+	}
+	if ret.problems, err = r.Problems(); err != nil {
+		return ret, err
+	}
+
+	return ret, nil
+}
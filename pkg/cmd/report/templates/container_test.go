@@ -45,6 +45,7 @@ func TestRenderContainer(t *testing.T) {
 	tests := []struct {
 		name           string
 		packages       []listen.Package
+		summary        Summary
 		expectedOutput []byte
 		snapshot       bool
 		wantErr        bool
@@ -56,6 +57,22 @@ func TestRenderContainer(t *testing.T) {
 			expectedOutput: testdataFileToBytes(t, "testdata/container_no_packages.md"),
 			wantErr:        false,
 		},
+		{
+			snapshot:       true,
+			name:           "no packages with suppressed verdicts",
+			packages:       []listen.Package{},
+			summary:        Summary{Suppressed: 2},
+			expectedOutput: testdataFileToBytes(t, "testdata/container_suppressed.md"),
+			wantErr:        false,
+		},
+		{
+			snapshot:       true,
+			name:           "no new packages compared to the baseline",
+			packages:       []listen.Package{},
+			summary:        Summary{Baseline: true, Known: 3},
+			expectedOutput: testdataFileToBytes(t, "testdata/container_baseline.md"),
+			wantErr:        false,
+		},
 		{
 			snapshot: true,
 			name:     "with packages",
@@ -551,7 +568,7 @@ func TestRenderContainer(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			outBuf := &bytes.Buffer{}
-			err := RenderContainer(outBuf, tt.packages)
+			err := RenderContainer(outBuf, tt.packages, tt.summary)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RenderContainer() error = %v, wantErr %v", err, tt.wantErr)
 
@@ -32,6 +32,9 @@ import (
 //go:embed container.html
 var tmplContainer embed.FS
 
+//go:embed changes.html
+var tmplChanges embed.FS
+
 //go:embed severity.html
 var tmpSeverity embed.FS
 
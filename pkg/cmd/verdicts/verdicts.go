@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verdicts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/listendev/lstn/pkg/baseline"
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	"github.com/listendev/lstn/pkg/listen"
+	"github.com/listendev/lstn/pkg/policy"
+	"github.com/listendev/lstn/pkg/suppress"
+	"github.com/listendev/pkg/ecosystem"
+)
+
+// Suppress drops the verdicts that the input suppression rules match.
+//
+// The rules keep track of the verdicts they drop (see suppress.Rules.Take).
+func Suppress(rules *suppress.Rules, eco ecosystem.Ecosystem, res listen.Response) listen.Response {
+	if rules == nil {
+		return res
+	}
+
+	ret := make(listen.Response, len(res))
+	for i, pkg := range res {
+		version := ""
+		if pkg.Version != nil {
+			version = *pkg.Version
+		}
+		verdicts := []listen.Verdict{}
+		for _, v := range pkg.Verdicts {
+			e := eco.String()
+			if e == "" {
+				e = v.Ecosystem.String()
+			}
+			if !rules.Suppress(e, pkg.Name, version, v) {
+				verdicts = append(verdicts, v)
+			}
+		}
+		pkg.Verdicts = verdicts
+		ret[i] = pkg
+	}
+
+	return ret
+}
+
+// CompareBaseline keeps only the package versions and the verdicts that the input baseline does not have.
+//
+// The baseline records the verdicts it compares (see baseline.Baseline.Write)
+// and keeps track of how many it already has (see baseline.Baseline.TakeKnown).
+func CompareBaseline(b *baseline.Baseline, eco ecosystem.Ecosystem, res listen.Response) listen.Response {
+	if b == nil {
+		return res
+	}
+
+	ret := listen.Response{}
+	for _, pkg := range res {
+		version := ""
+		if pkg.Version != nil {
+			version = *pkg.Version
+		}
+		verdicts, ok := b.Diff(eco.String(), pkg.Name, version, pkg.Verdicts)
+		if !ok {
+			continue
+		}
+		pkg.Verdicts = verdicts
+		ret = append(ret, pkg)
+	}
+
+	return ret
+}
+
+// Check records the package versions of the input response violating the input policy, if any.
+func Check(p *policy.Policy, res listen.Response) {
+	for _, pkg := range res {
+		version := ""
+		if pkg.Version != nil {
+			version = *pkg.Version
+		}
+		p.Check(pkg.Name, version, pkg.Verdicts, pkg.Problems)
+	}
+}
+
+// JSON encodes the input value, eventually filtering it with the jq query of the input JSON options.
+func JSON(ctx context.Context, opts flags.JSONFlags, v any) ([]byte, error) {
+	allJSON := new(bytes.Buffer)
+	if err := json.NewEncoder(allJSON).Encode(v); err != nil {
+		return nil, fmt.Errorf("couldn't JSON encode the verdicts")
+	}
+
+	out := new(bytes.Buffer)
+	if err := opts.GetOutput(ctx, allJSON, out); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// Summary lists what the verdicts in the JSON output leave out.
+type Summary struct {
+	// Suppressed are the verdicts that the suppression rules dropped
+	Suppressed []suppress.Suppressed `json:"suppressed,omitempty"`
+	// NotAnalysed are the dependencies lstn didn't get the verdicts for
+	NotAnalysed []listen.NotAnalysed `json:"not_analysed,omitempty"`
+}
+
+// WriteSummary writes the input summary, when not empty, as one JSON document into w.
+//
+// It never applies the jq query, which targets the verdicts only.
+// So callers write it apart from the verdicts (eg., on stderr) to keep their JSON output a single document.
+func WriteSummary(w io.Writer, s Summary) error {
+	if len(s.Suppressed) == 0 && len(s.NotAnalysed) == 0 {
+		return nil
+	}
+	if err := json.NewEncoder(w).Encode(s); err != nil {
+		return fmt.Errorf("couldn't JSON encode the summary of the verdicts")
+	}
+
+	return nil
+}
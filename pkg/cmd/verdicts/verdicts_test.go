@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verdicts
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/listendev/lstn/pkg/baseline"
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	"github.com/listendev/lstn/pkg/listen"
+	"github.com/listendev/lstn/pkg/policy"
+	"github.com/listendev/lstn/pkg/suppress"
+	"github.com/listendev/pkg/ecosystem"
+	"github.com/listendev/pkg/models/severity"
+	"github.com/listendev/pkg/verdictcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestSuppress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), suppress.Filename)
+	require.Nil(t, os.WriteFile(path, []byte("rules:\n- name: js-tokens\n  code: TSN\n  reason: it is the real one\n  owner: security\n"), 0o600))
+	rules, err := suppress.Load(path)
+	require.Nil(t, err)
+
+	res := listen.Response{
+		listen.Package{Name: "js-tokens", Version: strPtr("4.0.0"), Verdicts: []listen.Verdict{
+			{Message: "typosquat", Severity: severity.High, Code: verdictcode.TSN01},
+			{Message: "outbound connection", Severity: severity.Low, Code: verdictcode.STN001},
+		}},
+	}
+
+	// Without suppression rules nothing is dropped
+	assert.Equal(t, res, Suppress(nil, ecosystem.Npm, res))
+
+	got := Suppress(rules, ecosystem.Npm, res)
+	require.Len(t, got, 1)
+	require.Len(t, got[0].Verdicts, 1)
+	assert.Equal(t, "outbound connection", got[0].Verdicts[0].Message)
+	// The input response stays untouched
+	assert.Len(t, res[0].Verdicts, 2)
+
+	// The suppressed verdicts do not violate the policy
+	p := policy.New(&flags.ConfigFlags{Policy: flags.Policy{FailOn: "high"}})
+	Check(p, got)
+	assert.Nil(t, p.Err())
+
+	suppressed := rules.Take()
+	require.Len(t, suppressed, 1)
+	assert.Equal(t, "TSN01", suppressed[0].Code)
+}
+
+func TestCompareBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.Nil(t, os.WriteFile(path, []byte(`{"version":1,"packages":[{"ecosystem":"npm","name":"js-tokens","version":"4.0.0","verdicts":[{"code":"TSN01","severity":"high","message":"typosquat"}]}]}`), 0o600))
+	b, err := baseline.New(path, "")
+	require.Nil(t, err)
+
+	res := listen.Response{
+		listen.Package{Name: "js-tokens", Version: strPtr("4.0.0"), Verdicts: []listen.Verdict{{Message: "typosquat", Severity: severity.High, Code: verdictcode.TSN01}}},
+		listen.Package{Name: "loose-envify", Version: strPtr("1.4.0"), Verdicts: []listen.Verdict{}},
+	}
+
+	// Without a baseline everything is new
+	assert.Equal(t, res, CompareBaseline(nil, ecosystem.Npm, res))
+
+	got := CompareBaseline(b, ecosystem.Npm, res)
+	require.Len(t, got, 1)
+	assert.Equal(t, "loose-envify", got[0].Name)
+	assert.Equal(t, uint(1), b.TakeKnown())
+}
+
+func TestCheck(t *testing.T) {
+	res := listen.Response{
+		listen.Package{Name: "js-tokens", Version: strPtr("4.0.0"), Verdicts: []listen.Verdict{{Message: "typosquat", Severity: severity.High, Code: verdictcode.TSN01}}},
+		listen.Package{Name: "loose-envify", Version: strPtr("1.4.0"), Verdicts: []listen.Verdict{}},
+	}
+
+	// Without a policy nothing fails
+	Check(nil, res)
+
+	p := policy.New(&flags.ConfigFlags{Policy: flags.Policy{FailOn: "high"}})
+	Check(p, res)
+
+	var violationErr *policy.ViolationError
+	require.True(t, errors.As(p.Err(), &violationErr))
+	assert.Equal(t, []string{"js-tokens@4.0.0"}, violationErr.Packages)
+}
+
+func TestJSON(t *testing.T) {
+	res := listen.Response{
+		listen.Package{Name: "js-tokens", Version: strPtr("4.0.0"), Verdicts: []listen.Verdict{}},
+	}
+
+	out, err := JSON(t.Context(), flags.JSONFlags{JSON: true}, res)
+	require.Nil(t, err)
+	assert.JSONEq(t, `[{"name":"js-tokens","verdicts":[],"version":"4.0.0"}]`, string(out))
+
+	out, err = JSON(t.Context(), flags.JSONFlags{JSON: true, JQ: ".[].name"}, res)
+	require.Nil(t, err)
+	assert.Equal(t, "js-tokens\n", string(out))
+
+	_, err = JSON(t.Context(), flags.JSONFlags{}, res)
+	assert.Error(t, err)
+}
+
+func TestWriteSummary(t *testing.T) {
+	out := new(strings.Builder)
+	require.Nil(t, WriteSummary(out, Summary{}))
+	assert.Empty(t, out.String())
+
+	require.Nil(t, WriteSummary(out, Summary{
+		Suppressed:  []suppress.Suppressed{{Name: "react", Version: "18.0.0", Code: "FNI001", Message: "outbound network connection", Reason: "telemetry", Owner: "@frontend"}},
+		NotAnalysed: []listen.NotAnalysed{{Name: "local", Spec: "file:../local", Reason: "local path"}},
+	}))
+	assert.JSONEq(t, heredoc.Doc(`{
+		"suppressed": [{"name": "react", "version": "18.0.0", "code": "FNI001", "message": "outbound network connection", "reason": "telemetry", "owner": "@frontend"}],
+		"not_analysed": [{"name": "local", "spec": "file:../local", "reason": "local path"}]
+	}`), out.String())
+}
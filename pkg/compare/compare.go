@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compare
+
+import (
+	"github.com/Masterminds/semver/v3"
+	"github.com/listendev/lstn/pkg/pypi"
+	"github.com/listendev/pkg/ecosystem"
+)
+
+// Lower tells whether the version a is lower than the version b, according to the versioning scheme of the input ecosystem.
+//
+// The versions it cannot compare are not lower.
+func Lower(eco ecosystem.Ecosystem, a, b string) bool {
+	switch eco {
+	case ecosystem.Npm:
+		va, errA := semver.NewVersion(a)
+		vb, errB := semver.NewVersion(b)
+
+		return errA == nil && errB == nil && va.LessThan(vb)
+	case ecosystem.Pypi:
+		va, errA := pypi.NewVersion(a)
+		vb, errB := pypi.NewVersion(b)
+
+		return errA == nil && errB == nil && va.Compare(vb) < 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compare
+
+import (
+	"testing"
+
+	"github.com/listendev/pkg/ecosystem"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLower(t *testing.T) {
+	tests := []struct {
+		eco  ecosystem.Ecosystem
+		a    string
+		b    string
+		want bool
+	}{
+		{ecosystem.Npm, "1.2.3", "1.10.0", true},
+		{ecosystem.Npm, "1.10.0", "1.2.3", false},
+		{ecosystem.Npm, "1.0.0-beta.1", "1.0.0", true},
+		{ecosystem.Npm, "1.0.0", "1.0.0", false},
+		{ecosystem.Pypi, "1.0rc1", "1.0", true},
+		{ecosystem.Pypi, "2.0.post1", "2.0", false},
+		{ecosystem.Pypi, "1.9", "1.10", true},
+		// The versions it cannot compare are not lower
+		{ecosystem.Npm, "github:owner/repo", "1.0.0", false},
+		{ecosystem.Npm, "1.0.0", "github:owner/repo", false},
+		{ecosystem.None, "1.0.0", "2.0.0", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.eco.String()+" "+tc.a+" < "+tc.b, func(t *testing.T) {
+			assert.Equal(t, tc.want, Lower(tc.eco, tc.a, tc.b))
+		})
+	}
+}
@@ -55,5 +55,41 @@ var VersionShortKey contextKey = "version_short"
 // VersionLongKey is the key storing the long version.
 var VersionLongKey contextKey = "version_long"
 
+// NpmrcKey is the key storing the npm configuration of the registries and of their credentials.
+var NpmrcKey contextKey = "npmrc"
+
 // IOStreamsKey is the key storing the IOStreams (stdout, stderr, stdin).
 var IOStreamsKey contextKey = "iostreams"
+
+// RateLimiterKey is the key storing the limiter of the requests per second shared by all the HTTP clients.
+var RateLimiterKey contextKey = "ratelimiter"
+
+// VerdictsCacheKey is the key storing the on-disk cache of the verdicts.
+var VerdictsCacheKey contextKey = "verdictscache"
+
+// CacheLsKey is the key indexing the options for the `cache ls` child command.
+var CacheLsKey contextKey = "cachels"
+
+// CachePruneKey is the key indexing the options for the `cache prune` child command.
+var CachePruneKey contextKey = "cacheprune"
+
+// CacheClearKey is the key indexing the options for the `cache clear` child command.
+var CacheClearKey contextKey = "cacheclear"
+
+// VerdictsBundleKey is the key storing the verdicts bundle answering in offline mode.
+var VerdictsBundleKey contextKey = "verdictsbundle"
+
+// ExportKey is the key indexing the options for the `export` child command.
+var ExportKey contextKey = "export"
+
+// HTTPTransportKey is the key storing the HTTP transport (proxy, CA certificates, client certificate) shared by all the HTTP clients.
+var HTTPTransportKey contextKey = "httptransport"
+
+// PolicyKey is the key storing the policy telling which verdicts make the commands fail.
+var PolicyKey contextKey = "policy"
+
+// SuppressedKey is the key storing the verdicts the suppression rules dropped from the response to report.
+var SuppressedKey contextKey = "suppressed"
+
+// DiffKey is the key indexing the options for the `diff` child command.
+var DiffKey contextKey = "diff"
@@ -0,0 +1,310 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/listendev/lstn/pkg/compare"
+	"github.com/listendev/lstn/pkg/git"
+	"github.com/listendev/lstn/pkg/listen"
+	listentype "github.com/listendev/lstn/pkg/listen/type"
+	"github.com/listendev/lstn/pkg/lockfile"
+	"github.com/listendev/lstn/pkg/npm"
+	"github.com/listendev/lstn/pkg/pypi"
+	"github.com/listendev/pkg/ecosystem"
+)
+
+// Side is one of the two lock files to compare.
+type Side struct {
+	// Path is the path of the lock file, relative to the root of the git repository when Rev is not empty
+	Path string
+	// Rev is the git revision (eg., main, HEAD~1) of the lock file, if any
+	Rev string
+}
+
+// ParseSide parses a lock file path, or a git revision and a lock file path separated by a colon (eg., main:package-lock.json).
+//
+// The existing files win over the git revisions.
+func ParseSide(arg string) Side {
+	if _, err := os.Stat(arg); err == nil {
+		return Side{Path: arg}
+	}
+	if rev, path, ok := strings.Cut(arg, ":"); ok && rev != "" && path != "" {
+		return Side{Path: path, Rev: rev}
+	}
+
+	return Side{Path: arg}
+}
+
+func (s Side) String() string {
+	if s.Rev == "" {
+		return s.Path
+	}
+
+	return s.Rev + ":" + s.Path
+}
+
+// Locked are the versions that a lock file pins, by package name.
+//
+// A package can have more versions, when the lock file nests some of them (eg., foo/node_modules/bar).
+type Locked map[string][]string
+
+// Read returns the ecosystem of the lock file and the package versions it pins.
+//
+// It looks for the git repository from the input directory.
+func (s Side) Read(dir string) (ecosystem.Ecosystem, Locked, error) {
+	lf, ok := lockfile.FromPath(s.Path)
+	if !ok {
+		return ecosystem.None, nil, fmt.Errorf("%s is not a supported lock file", s)
+	}
+
+	var data []byte
+	var err error
+	if s.Rev == "" {
+		data, err = os.ReadFile(s.Path)
+		if err != nil {
+			return ecosystem.None, nil, fmt.Errorf("%s not found", s)
+		}
+	} else {
+		data, err = git.ReadFile(dir, s.Rev, s.Path)
+		if err != nil {
+			return ecosystem.None, nil, err
+		}
+	}
+
+	locked, err := parse(lf, data)
+	if err != nil {
+		return ecosystem.None, nil, fmt.Errorf("could not process %s yet: %w", s, err)
+	}
+
+	return lockfile.Ecosystem(lf), locked, nil
+}
+
+// parse returns the package versions that the input lock file contents pin.
+func parse(lf lockfile.Lockfile, data []byte) (Locked, error) {
+	var lock listentype.AnalysisRequester
+	var err error
+	switch lf {
+	case lockfile.PackageLockJSON:
+		lock, err = npm.NewPackageLockJSONFromBytes(data)
+	case lockfile.NpmShrinkwrapJSON:
+		lock, err = npm.NewNpmShrinkwrapJSONFromReader(bytes.NewReader(data))
+	case lockfile.YarnLock:
+		lock, err = npm.NewYarnLockFromBytes(data)
+	case lockfile.PnpmLock:
+		lock, err = npm.NewPnpmLockFromBytes(data)
+	case lockfile.BunLock:
+		lock, err = npm.NewBunLockFromBytes(data)
+	case lockfile.PoetryLock:
+		lock, err = pypi.NewPoetryLockFromBytes(data)
+	case lockfile.UvLock:
+		lock, err = pypi.NewUvLockFromBytes(data)
+	case lockfile.PdmLock:
+		lock, err = pypi.NewPdmLockFromBytes(data)
+	case lockfile.PipfileLock:
+		lock, err = pypi.NewPipfileLockFromBytes(data)
+	case lockfile.RequirementsTxt:
+		lock, err = pypi.NewRequirementsTxtFromBytes(data)
+	default:
+		return nil, fmt.Errorf("unsupported lock file")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ret := Locked{}
+	switch l := lock.(type) {
+	case interface {
+		Deps() map[string]npm.PackageLockDependency
+	}:
+		// The npm lock files key the dependencies by their node_modules path
+		for _, v := range npm.LockedVersions(l.Deps()) {
+			ret[v.Name] = append(ret[v.Name], v.Version)
+		}
+	case pypi.PoetryLock:
+		for _, pkg := range l.Packages() {
+			ret[pkg.Name] = append(ret[pkg.Name], pkg.Version)
+		}
+	}
+
+	return ret, nil
+}
+
+// Kind is the kind of change of a package.
+type Kind string
+
+const (
+	Added      Kind = "added"
+	Removed    Kind = "removed"
+	Upgraded   Kind = "upgraded"
+	Downgraded Kind = "downgraded"
+)
+
+// Change is the change of a package between two lock files.
+//
+// The verdicts and the problems are the ones of the new package version, if any.
+type Change struct {
+	Kind     Kind             `json:"kind"`
+	Name     string           `json:"name"`
+	From     string           `json:"from,omitempty"`
+	To       string           `json:"to,omitempty"`
+	Verdicts []listen.Verdict `json:"verdicts,omitempty"`
+	Problems []listen.Problem `json:"problems,omitempty"`
+
+	analysed bool
+}
+
+// Analysed tells whether the new package version got its verdicts.
+func (c *Change) Analysed() bool {
+	return c.analysed
+}
+
+// Changes are the changes of the packages between two lock files, sorted by package name.
+type Changes []*Change
+
+// Compute returns the added, removed, upgraded, and downgraded packages between the input package versions.
+//
+// When a package has more versions, it pairs every version going away with the closest new version above it (or below it).
+func Compute(eco ecosystem.Ecosystem, before, after Locked) Changes {
+	names := map[string]bool{}
+	for name := range before {
+		names[name] = true
+	}
+	for name := range after {
+		names[name] = true
+	}
+
+	ret := Changes{}
+	for name := range names {
+		removed := subtractVersions(eco, before[name], after[name])
+		added := subtractVersions(eco, after[name], before[name])
+		for _, from := range removed {
+			if len(added) == 0 {
+				ret = append(ret, &Change{Kind: Removed, Name: name, From: from})
+
+				continue
+			}
+			// Prefer the lowest upgrade, then the highest downgrade
+			kind := Upgraded
+			i := slices.IndexFunc(added, func(to string) bool {
+				return !compare.Lower(eco, to, from)
+			})
+			if i < 0 {
+				kind = Downgraded
+				i = len(added) - 1
+			}
+			ret = append(ret, &Change{Kind: kind, Name: name, From: from, To: added[i]})
+			added = slices.Delete(added, i, i+1)
+		}
+		for _, to := range added {
+			ret = append(ret, &Change{Kind: Added, Name: name, To: to})
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Name != ret[j].Name {
+			return ret[i].Name < ret[j].Name
+		}
+
+		return compare.Lower(eco, ret[i].version(), ret[j].version())
+	})
+
+	return ret
+}
+
+// version returns the new version of the package, or the old one when the package went away.
+func (c *Change) version() string {
+	if c.To != "" {
+		return c.To
+	}
+
+	return c.From
+}
+
+// subtractVersions returns the versions in a that are not in b, from the lowest one.
+func subtractVersions(eco ecosystem.Ecosystem, a, b []string) []string {
+	ret := []string{}
+	for _, v := range a {
+		if !slices.Contains(b, v) && !slices.Contains(ret, v) {
+			ret = append(ret, v)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return compare.Lower(eco, ret[i], ret[j])
+	})
+
+	return ret
+}
+
+// NewVersions returns the names and the versions of the new package versions, to get the verdicts of.
+func (c Changes) NewVersions() ([]string, []string) {
+	names := []string{}
+	versions := []string{}
+	for _, change := range c {
+		if change.To == "" {
+			continue
+		}
+		names = append(names, change.Name)
+		versions = append(versions, change.To)
+	}
+
+	return names, versions
+}
+
+// Attach sets the verdicts and the problems of the new package versions from the input response.
+func (c Changes) Attach(res listen.Response) {
+	for _, pkg := range res {
+		for _, change := range c {
+			if change.Name != pkg.Name || change.To == "" || pkg.Version == nil || *pkg.Version != change.To {
+				continue
+			}
+			change.Verdicts = pkg.Verdicts
+			change.Problems = pkg.Problems
+			change.analysed = true
+		}
+	}
+}
+
+// Response returns the new package versions that got their verdicts.
+func (c Changes) Response() listen.Response {
+	ret := listen.Response{}
+	for _, change := range c {
+		if !change.analysed {
+			continue
+		}
+		version := change.To
+		ret = append(ret, listen.Package{Name: change.Name, Version: &version, Verdicts: change.Verdicts, Problems: change.Problems})
+	}
+
+	return ret
+}
+
+// Count returns how many changes are of the input kind.
+func (c Changes) Count(kind Kind) int {
+	ret := 0
+	for _, change := range c {
+		if change.Kind == kind {
+			ret++
+		}
+	}
+
+	return ret
+}
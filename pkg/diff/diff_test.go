@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package diff
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/listendev/lstn/pkg/listen"
+	"github.com/listendev/pkg/ecosystem"
+	"github.com/listendev/pkg/verdictcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func packageLock(versions map[string]string) string {
+	deps := []string{}
+	for name, version := range versions {
+		deps = append(deps, fmt.Sprintf("%q: {\"version\": %q}", name, version))
+	}
+
+	return fmt.Sprintf(`{"name": "example", "version": "1.0.0", "lockfileVersion": 1, "requires": true, "dependencies": {%s}}`, strings.Join(deps, ", "))
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestParseSide(t *testing.T) {
+	existing := filepath.Join(t.TempDir(), "package-lock.json")
+	require.Nil(t, os.WriteFile(existing, []byte("{}"), 0o600))
+
+	assert.Equal(t, Side{Path: existing}, ParseSide(existing))
+	assert.Equal(t, Side{Path: "package-lock.json", Rev: "main"}, ParseSide("main:package-lock.json"))
+	assert.Equal(t, Side{Path: "sub/poetry.lock", Rev: "HEAD~1"}, ParseSide("HEAD~1:sub/poetry.lock"))
+	assert.Equal(t, Side{Path: "missing/package-lock.json"}, ParseSide("missing/package-lock.json"))
+	assert.Equal(t, Side{Path: ":package-lock.json"}, ParseSide(":package-lock.json"))
+
+	assert.Equal(t, "main:package-lock.json", ParseSide("main:package-lock.json").String())
+}
+
+func TestRead(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.Nil(t, err)
+
+	path := filepath.Join(dir, "package-lock.json")
+	require.Nil(t, os.WriteFile(path, []byte(packageLock(map[string]string{"react": "17.0.2"})), 0o600))
+	w, err := repo.Worktree()
+	require.Nil(t, err)
+	_, err = w.Add("package-lock.json")
+	require.Nil(t, err)
+	_, err = w.Commit("lock", &git.CommitOptions{Author: &object.Signature{Name: "lstn", Email: "lstn@listen.dev", When: time.Now()}})
+	require.Nil(t, err)
+	require.Nil(t, os.WriteFile(path, []byte(packageLock(map[string]string{"react": "18.2.0", "chalk": "5.3.0"})), 0o600))
+
+	eco, locked, err := Side{Path: "package-lock.json", Rev: "HEAD"}.Read(dir)
+	require.Nil(t, err)
+	assert.Equal(t, ecosystem.Npm, eco)
+	assert.Equal(t, Locked{"react": {"17.0.2"}}, locked)
+
+	eco, locked, err = Side{Path: path}.Read(dir)
+	require.Nil(t, err)
+	assert.Equal(t, ecosystem.Npm, eco)
+	assert.Equal(t, Locked{"react": {"18.2.0"}, "chalk": {"5.3.0"}}, locked)
+
+	requirements := filepath.Join(dir, "requirements.txt")
+	require.Nil(t, os.WriteFile(requirements, []byte("flask==2.2.0\n"), 0o600))
+	eco, locked, err = Side{Path: requirements}.Read(dir)
+	require.Nil(t, err)
+	assert.Equal(t, ecosystem.Pypi, eco)
+	assert.Equal(t, Locked{"flask": {"2.2.0"}}, locked)
+
+	_, _, err = Side{Path: "go.sum"}.Read(dir)
+	assert.EqualError(t, err, "go.sum is not a supported lock file")
+	_, _, err = Side{Path: filepath.Join(dir, "poetry.lock")}.Read(dir)
+	assert.EqualError(t, err, filepath.Join(dir, "poetry.lock")+" not found")
+	_, _, err = Side{Path: "yarn.lock", Rev: "HEAD"}.Read(dir)
+	assert.EqualError(t, err, "couldn't find yarn.lock at the git revision HEAD")
+}
+
+func TestCompute(t *testing.T) {
+	changes := Compute(ecosystem.Npm,
+		Locked{"react": {"17.0.2"}, "lodash": {"4.17.21"}, "chalk": {"5.3.0"}, "debug": {"4.3.4"}},
+		Locked{"react": {"18.2.0"}, "lodash": {"4.17.20"}, "chalk": {"5.3.0"}, "js-tokens": {"4.0.0"}},
+	)
+	assert.Equal(t, Changes{
+		{Kind: Removed, Name: "debug", From: "4.3.4"},
+		{Kind: Added, Name: "js-tokens", To: "4.0.0"},
+		{Kind: Downgraded, Name: "lodash", From: "4.17.21", To: "4.17.20"},
+		{Kind: Upgraded, Name: "react", From: "17.0.2", To: "18.2.0"},
+	}, changes)
+	assert.Equal(t, 1, changes.Count(Added))
+	assert.Equal(t, 1, changes.Count(Removed))
+
+	names, versions := changes.NewVersions()
+	assert.Equal(t, []string{"js-tokens", "lodash", "react"}, names)
+	assert.Equal(t, []string{"4.0.0", "4.17.20", "18.2.0"}, versions)
+
+	// PEP 440 versions
+	changes = Compute(ecosystem.Pypi, Locked{"flask": {"2.2.0rc1"}}, Locked{"flask": {"2.2.0"}})
+	assert.Equal(t, Upgraded, changes[0].Kind)
+}
+
+func TestComputeNested(t *testing.T) {
+	_, before, err := Side{Path: filepath.Join("testdata", "before", "package-lock.json")}.Read(".")
+	require.Nil(t, err)
+	_, after, err := Side{Path: filepath.Join("testdata", "after", "package-lock.json")}.Read(".")
+	require.Nil(t, err)
+	assert.Equal(t, Locked{"debug": {"2.6.9", "4.3.5"}, "ms": {"2.1.3"}, "string-width": {"5.1.2"}, "wrap-ansi": {"8.1.0"}}, after)
+
+	// The hoisted packages, the aliases, and the workspaces are not changes
+	assert.Equal(t, Changes{
+		{Kind: Added, Name: "debug", To: "2.6.9"},
+		{Kind: Upgraded, Name: "debug", From: "4.3.4", To: "4.3.5"},
+		{Kind: Upgraded, Name: "string-width", From: "4.2.3", To: "5.1.2"},
+	}, Compute(ecosystem.Npm, before, after))
+}
+
+func TestAttach(t *testing.T) {
+	changes := Compute(ecosystem.Npm,
+		Locked{"react": {"17.0.2"}, "debug": {"4.3.4"}},
+		Locked{"react": {"18.2.0"}, "js-tokens": {"4.0.0"}},
+	)
+	verdicts := []listen.Verdict{{Code: verdictcode.TSN01, Message: "typosquat"}}
+	changes.Attach(listen.Response{
+		{Name: "js-tokens", Version: strPtr("4.0.0"), Verdicts: verdicts},
+		// Not a new package version
+		{Name: "react", Version: strPtr("17.0.2"), Verdicts: verdicts},
+	})
+
+	assert.False(t, changes[0].Analysed())
+	assert.True(t, changes[1].Analysed())
+	assert.Equal(t, verdicts, changes[1].Verdicts)
+	assert.False(t, changes[2].Analysed())
+	assert.Empty(t, changes[2].Verdicts)
+
+	assert.Equal(t, listen.Response{
+		{Name: "js-tokens", Version: strPtr("4.0.0"), Verdicts: verdicts},
+	}, changes.Response())
+}
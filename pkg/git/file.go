@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package git
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// ReadFile returns the contents of the input file at the input revision (eg., main, HEAD~1, v1.2.0)
+// of the git repository containing the input directory.
+//
+// The file path is relative to the root of the repository.
+func ReadFile(dir, rev, file string) ([]byte, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open the git repository at %s", dir)
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't resolve the git revision %s", rev)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the commit of the git revision %s", rev)
+	}
+	f, err := commit.File(strings.TrimPrefix(path.Clean("/"+file), "/"))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't find %s at the git revision %s", file, rev)
+	}
+	contents, err := f.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %s at the git revision %s", file, rev)
+	}
+
+	return []byte(contents), nil
+}
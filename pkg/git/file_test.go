@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func commitFile(t *testing.T, repo *git.Repository, dir, file, contents string) {
+	t.Helper()
+
+	require.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), 0o755))
+	require.Nil(t, os.WriteFile(filepath.Join(dir, file), []byte(contents), 0o600))
+	w, err := repo.Worktree()
+	require.Nil(t, err)
+	_, err = w.Add(file)
+	require.Nil(t, err)
+	_, err = w.Commit("update "+file, &git.CommitOptions{
+		Author: &object.Signature{Name: "lstn", Email: "lstn@listen.dev", When: time.Now()},
+	})
+	require.Nil(t, err)
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.Nil(t, err)
+
+	commitFile(t, repo, dir, "sub/package-lock.json", "old")
+	commitFile(t, repo, dir, "sub/package-lock.json", "new")
+
+	// From a subdirectory of the repository
+	contents, err := ReadFile(filepath.Join(dir, "sub"), "HEAD~1", "sub/package-lock.json")
+	require.Nil(t, err)
+	assert.Equal(t, "old", string(contents))
+
+	contents, err = ReadFile(dir, "HEAD", "./sub/package-lock.json")
+	require.Nil(t, err)
+	assert.Equal(t, "new", string(contents))
+
+	_, err = ReadFile(dir, "nope", "sub/package-lock.json")
+	assert.EqualError(t, err, "couldn't resolve the git revision nope")
+
+	_, err = ReadFile(dir, "HEAD", "poetry.lock")
+	assert.EqualError(t, err, "couldn't find poetry.lock at the git revision HEAD")
+
+	_, err = ReadFile(t.TempDir(), "HEAD", "poetry.lock")
+	assert.ErrorContains(t, err, "couldn't open the git repository")
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package listen
+
+import (
+	"fmt"
+
+	"github.com/listendev/lstn/pkg/cache"
+)
+
+// AddToBundle stores the response of the input request into the input verdicts bundle.
+//
+// It also stores every package version of the response on its own,
+// so that the bundle answers the verdicts requests for those package versions too.
+func AddToBundle[T Request](bundle *cache.Bundle, r T, res *Response, opts ...func(*options)) error {
+	o, err := newOptions(opts...)
+	if err != nil {
+		return err
+	}
+	if res == nil {
+		return fmt.Errorf("couldn't bundle an empty response")
+	}
+
+	if key, ok := cacheKey(r, o); ok {
+		if err := bundle.Add(key, res); err != nil {
+			return err
+		}
+	}
+	for _, p := range *res {
+		if p.Version == nil || *p.Version == "" {
+			continue
+		}
+		key := cache.Key{Ecosystem: o.ecosystem.String(), Name: p.Name, Version: *p.Version}
+		if err := bundle.Add(key, Response{p}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package listen
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotInBundle tells that the verdicts bundle does not cover a request.
+var ErrNotInBundle = errors.New("not in the verdicts bundle")
+
+// RequestError is the error querying the verdicts of a package version.
+type RequestError struct {
+	Name    string
+	Version string
+	Err     error
+}
+
+func (e *RequestError) Error() string {
+	name := e.Name
+	if e.Version != "" {
+		name += "@" + e.Version
+	}
+
+	return fmt.Sprintf("couldn't get the verdicts of %s: %s", name, e.Err.Error())
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// PartialResultsError tells that only some of the requests of a bulk succeeded.
+type PartialResultsError struct {
+	// Errors are the errors of the failed requests, in the order of the requests
+	Errors []*RequestError
+	// Total is the number of requests
+	Total int
+}
+
+func (e *PartialResultsError) Error() string {
+	packagesWord := "packages"
+	if e.Total == 1 {
+		packagesWord = "package"
+	}
+
+	return fmt.Sprintf("couldn't get the verdicts of %d out of %d %s", len(e.Errors), e.Total, packagesWord)
+}
+
+func (e *PartialResultsError) Unwrap() []error {
+	ret := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		ret[i] = err
+	}
+
+	return ret
+}
+
+// NotAnalysed returns the packages whose requests failed.
+func (e *PartialResultsError) NotAnalysed() []NotAnalysed {
+	ret := make([]NotAnalysed, len(e.Errors))
+	for i, err := range e.Errors {
+		ret[i] = NotAnalysed{Name: err.Name, Spec: err.Version, Reason: err.Err.Error()}
+	}
+
+	return ret
+}
@@ -18,15 +18,21 @@ package listen
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"runtime"
 	"strings"
+	"time"
 
 	"github.com/XANi/goneric"
+	"github.com/listendev/lstn/pkg/cache"
 	"github.com/listendev/lstn/pkg/cmd/flags"
 	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/listendev/lstn/pkg/ratelimit"
+	"github.com/listendev/lstn/pkg/transport"
 	"github.com/listendev/lstn/pkg/ua"
 	"github.com/listendev/pkg/ecosystem"
 )
@@ -97,6 +103,11 @@ func response(dec *json.Decoder, o *options) (*Response, []byte, error) {
 		return nil, nil, pkgcontext.OutputError(o.ctx, err)
 	}
 
+	return output(target, o)
+}
+
+// output returns the input Response, or its (eventually filtered) JSON when the options ask for it.
+func output(target *Response, o *options) (*Response, []byte, error) {
 	if o.json.IsJSON() {
 		allJSON := new(bytes.Buffer)
 		if err := json.NewEncoder(allJSON).Encode(target); err != nil {
@@ -117,39 +128,84 @@ func response(dec *json.Decoder, o *options) (*Response, []byte, error) {
 
 // request performs the HTTP request to the API
 //
+// It retries the requests failing with a 429 or 5xx status code, or because of a connection reset,
+// waiting for an exponential backoff or for the time the Retry-After header tells.
+// It gives up when the context is done.
+//
 // It assumes that the input Request is already well-formed.
-func request[T Request](ctx context.Context, r T, endpointURL, userAgent string) (*json.Decoder, *http.Response, error) {
+func request[T Request](ctx context.Context, r T, endpointURL, userAgent string, retries int) (*json.Decoder, *http.Response, error) {
 	// Prepare the request
 	pl, err := json.Marshal(r)
 	if err != nil {
 		return nil, nil, pkgcontext.OutputError(ctx, err)
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointURL, bytes.NewBuffer(pl))
-	if err != nil {
-		return nil, nil, pkgcontext.OutputError(ctx, err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
 	// Automatically generate user agent
 	if userAgent == "" {
 		userAgent = ua.Generate(true)
 	}
-	req.Header.Set("User-Agent", userAgent)
 
-	// Send the request
-	client := http.Client{}
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, nil, pkgcontext.OutputError(ctx, err)
+	var res *http.Response
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointURL, bytes.NewReader(pl))
+		if err != nil {
+			return nil, nil, pkgcontext.OutputError(ctx, err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", userAgent)
+
+		if err := ratelimit.Wait(ctx); err != nil {
+			return nil, nil, pkgcontext.OutputError(ctx, err)
+		}
+
+		// Send the request
+		res, err = transport.Client(ctx).Do(req)
+		retry := false
+		switch {
+		case err != nil:
+			retry = ctx.Err() == nil && isRetryableError(err)
+		case isRetryableStatus(res.StatusCode):
+			retry = true
+		}
+		if !retry || attempt >= retries {
+			if err != nil {
+				return nil, nil, pkgcontext.OutputError(ctx, err)
+			}
+
+			break
+		}
+
+		delay, ok := retryAfter(res, time.Now())
+		if !ok {
+			delay = backoff(attempt)
+		}
+		if res != nil {
+			drain(res)
+		}
+		if waitErr := wait(ctx, delay); waitErr != nil {
+			if err == nil {
+				err = fmt.Errorf("%s", http.StatusText(res.StatusCode))
+			}
+
+			if errors.Is(waitErr, context.DeadlineExceeded) && ctx.Err() == nil {
+				// The context deadline is closer than the next attempt
+				return nil, nil, fmt.Errorf("giving up after %d attempts: %w", attempt+1, err)
+			}
+
+			return nil, nil, pkgcontext.OutputError(ctx, waitErr)
+		}
 	}
 
 	dec := json.NewDecoder(res.Body)
 
 	// Bail out if status != 200
 	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+
 		target := &responseErrors{}
 		if err = dec.Decode(target); err != nil {
-			return nil, nil, pkgcontext.OutputError(ctx, err)
+			// Proxies and load balancers do not reply with the API errors
+			return nil, nil, pkgcontext.OutputErrorf(ctx, err, "%s", res.Status)
 		}
 		errorMessage := ""
 		if len(target.Errors) > 0 {
@@ -173,15 +229,83 @@ func Packages[T Request](r T, opts ...func(*options)) (*Response, []byte, error)
 		return nil, nil, pkgcontext.OutputError(o.ctx, err)
 	}
 
-	dec, res, err := request(o.ctx, r, endpointURL, o.userAgent)
+	// The ecosystem tells how to validate the version
+	if req, ok := any(r).(*VerdictsRequest); ok {
+		r, _ = any(req.withEcosystem(o.ecosystem)).(T)
+	}
+
+	if bundle := cache.BundleFromContext(o.ctx); bundle != nil {
+		return offline(r, bundle, o)
+	}
+
+	c := cache.FromContext(o.ctx)
+	key, cacheable := cacheKey(r, o)
+	if cacheable {
+		cached := Response{}
+		if c.Get(key, &cached) {
+			return output(&cached, o)
+		}
+	}
+
+	dec, res, err := request(o.ctx, r, endpointURL, o.userAgent, getRetries(o))
 	if err != nil {
 		return nil, nil, err
 	}
 	defer res.Body.Close()
 
-	return response(dec, o)
+	target := &Response{}
+	if err := dec.Decode(target); err != nil {
+		return nil, nil, pkgcontext.OutputError(o.ctx, err)
+	}
+	if cacheable {
+		// Failing to cache the verdicts is not a reason to fail getting them
+		_ = c.Put(key, target)
+	}
+
+	return output(target, o)
+}
+
+// offline answers the input request from the verdicts bundle.
+func offline[T Request](r T, bundle *cache.Bundle, o *options) (*Response, []byte, error) {
+	target := &Response{}
+	if key, ok := cacheKey(r, o); ok && bundle.Get(key, target) {
+		return output(target, o)
+	}
+	if req, ok := any(r).(*VerdictsRequest); ok {
+		return nil, nil, &RequestError{Name: req.Name, Version: req.Version, Err: ErrNotInBundle}
+	}
+
+	return nil, nil, ErrNotInBundle
+}
+
+// cacheKey returns the key of the input request in the verdicts cache.
+//
+// Only the requests for exact package versions and the lock file analyses are cacheable.
+func cacheKey[T Request](r T, o *options) (cache.Key, bool) {
+	if ok, _ := r.Ok(); !ok {
+		return cache.Key{}, false
+	}
+	switch req := any(r).(type) {
+	case *VerdictsRequest:
+		if req.Version == "" {
+			return cache.Key{}, false
+		}
+
+		return cache.Key{Endpoint: o.baseURL, Ecosystem: o.ecosystem.String(), Name: req.Name, Version: req.Version, Digest: req.Digest, Select: req.Select}, true
+	case *AnalysisRequest:
+		// The verdicts of a lock file analysis only depend on the lock file contents
+		sum := sha256.Sum256([]byte(req.Manifest.Encode()))
+
+		return cache.Key{Endpoint: o.baseURL, Ecosystem: o.ecosystem.String(), Digest: "sha256:" + hex.EncodeToString(sum[:])}, true
+	default:
+		return cache.Key{}, false
+	}
 }
 
+// BulkPackages queries the verdicts of every input request in parallel.
+//
+// When only some of the requests fail, it returns the packages of the successful ones
+// along with a *PartialResultsError telling the failed ones.
 func BulkPackages(requests []*VerdictsRequest, opts ...func(*options)) (*Response, []byte, error) {
 	o, err := newOptions(opts...)
 	if err != nil {
@@ -195,20 +319,41 @@ func BulkPackages(requests []*VerdictsRequest, opts ...func(*options)) (*Respons
 
 	// TODO: validate that all requests are ok
 
+	// The ecosystem tells how to validate the versions
+	requests = goneric.MapSlice(func(req *VerdictsRequest) *VerdictsRequest {
+		return req.withEcosystem(o.ecosystem)
+	}, requests)
+
 	endpointURL, err := getEndpointURLFromContext(requests[0], o)
 	if err != nil {
 		return nil, nil, pkgcontext.OutputError(o.ctx, err)
 	}
 
 	userAgent := ua.Generate(true)
+	retries := getRetries(o)
 
 	type returnWrap struct {
 		res *Package
 		err error
 	}
 
+	c := cache.FromContext(o.ctx)
+	bundle := cache.BundleFromContext(o.ctx)
+
 	cb := func(req *VerdictsRequest) returnWrap {
-		dec, res, reqErr := request(o.ctx, req, endpointURL, userAgent)
+		key, cacheable := cacheKey(req, o)
+		if bundle != nil {
+			if bundled := (Response{}); cacheable && bundle.Get(key, &bundled) && len(bundled) > 0 {
+				return returnWrap{&bundled[0], nil}
+			}
+
+			return returnWrap{nil, ErrNotInBundle}
+		}
+		if cached := (Response{}); cacheable && c.Get(key, &cached) && len(cached) > 0 {
+			return returnWrap{&cached[0], nil}
+		}
+
+		dec, res, reqErr := request(o.ctx, req, endpointURL, userAgent, retries)
 		if reqErr != nil {
 			return returnWrap{nil, reqErr}
 		}
@@ -218,6 +363,13 @@ func BulkPackages(requests []*VerdictsRequest, opts ...func(*options)) (*Respons
 		if decodeErr := dec.Decode(&ret); decodeErr != nil {
 			return returnWrap{nil, decodeErr}
 		}
+		if len(ret) == 0 {
+			return returnWrap{nil, fmt.Errorf("no package in the response")}
+		}
+		if cacheable {
+			// Failing to cache the verdicts is not a reason to fail getting them
+			_ = c.Put(key, ret)
+		}
 
 		// It's impossible to have more that one Package in every Response (a list of Package items) in this case
 		// Why? Because every VerdictsRequest contains an exact package version
@@ -225,7 +377,7 @@ func BulkPackages(requests []*VerdictsRequest, opts ...func(*options)) (*Respons
 		return returnWrap{&ret[0], nil}
 	}
 
-	returns := goneric.ParallelMapSlice(cb, runtime.NumCPU(), requests)
+	returns := goneric.ParallelMapSlice(cb, ratelimit.Concurrency(o.ctx), requests)
 
 	numReturns := len(returns)
 	if numReturns != numPackages {
@@ -233,13 +385,27 @@ func BulkPackages(requests []*VerdictsRequest, opts ...func(*options)) (*Respons
 	}
 
 	res := []Package{}
-	for _, ret := range returns {
-		if ret.err == nil {
-			res = append(res, *ret.res)
-		} else {
-			return nil, nil, pkgcontext.OutputError(o.ctx, ret.err)
+	partial := &PartialResultsError{Total: numPackages}
+	for i, ret := range returns {
+		if ret.err != nil {
+			partial.Errors = append(partial.Errors, &RequestError{Name: requests[i].Name, Version: requests[i].Version, Err: ret.err})
+
+			continue
 		}
+		res = append(res, *ret.res)
 	}
+	if len(partial.Errors) > 0 {
+		// Cancellations and timeouts are not partial results
+		if ctxErr := pkgcontext.Error(o.ctx, nil); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		// The packages the verdicts bundle does not cover are partial results even when they are all of them
+		if len(res) == 0 && bundle == nil {
+			return nil, nil, pkgcontext.OutputError(o.ctx, partial.Errors[0].Err)
+		}
+		err = partial
+	}
+
 	if o.json.IsJSON() {
 		allJSON := new(bytes.Buffer)
 		if err := json.NewEncoder(allJSON).Encode(res); err != nil {
@@ -252,9 +418,9 @@ func BulkPackages(requests []*VerdictsRequest, opts ...func(*options)) (*Respons
 			return nil, nil, pkgcontext.OutputError(o.ctx, err)
 		}
 
-		return nil, out.Bytes(), nil
+		return nil, out.Bytes(), err
 	}
 	cast := (Response)(res)
 
-	return &cast, nil, nil
+	return &cast, nil, err
 }
@@ -26,6 +26,7 @@ import (
 
 	"github.com/MakeNowJust/heredoc"
 	internaltesting "github.com/listendev/lstn/internal/testing"
+	"github.com/listendev/lstn/pkg/cache"
 	"github.com/listendev/lstn/pkg/cmd/flags"
 	pkgcontext "github.com/listendev/lstn/pkg/context"
 	"github.com/listendev/lstn/pkg/npm"
@@ -324,7 +325,12 @@ python-versions = "!=3.0.*,!=3.1.*,!=3.2.*,!=3.3.*,!=3.4.*,!=3.5.*,!=3.6.*,>=2.7
 files = [
 	{file = "colorama-0.4.6-py2.py3-none-any.whl", hash = "sha256:4f1d9991f5acc0ca119f9d443620b77f9d6b33703e51011c16baf57afb285fc6"},
 	{file = "colorama-0.4.6.tar.gz", hash = "sha256:08695f5cb7ed6e0531a20572697297273c47b8cae5a63ffc6d6ed5c201be6e44"},
-]`)))
+]
+
+[metadata]
+lock-version = "2.0"
+python-versions = "^3.10"
+content-hash = "a7d5b8c09e2f8b8a3fd7b3d6e3a0e7f0c3f4b6d0e6a7f8e9d0c1b2a3f4e5d6c7"`)))
 
 	req, err1 := NewAnalysisRequest(plj, WithRequestContext())
 	suite.Assert().Nil(err1)
@@ -389,3 +395,274 @@ func (suite *RequestsSuite) TestPyPiVerdictsRequest() {
 func TestRequestsSuite(t *testing.T) {
 	suite.Run(t, new(RequestsSuite))
 }
+
+func withFastBackoff(t *testing.T) {
+	t.Helper()
+
+	base, maxDelay := backoffBase, backoffMax
+	backoffBase, backoffMax = time.Millisecond, 5*time.Millisecond
+	t.Cleanup(func() {
+		backoffBase, backoffMax = base, maxDelay
+	})
+}
+
+func TestRequestRetries(t *testing.T) {
+	withFastBackoff(t)
+
+	body := `[{"name":"js-tokens","digest":"19203fb59991df98e3a287050d4647cdeaf32499","verdicts":[],"version":"4.0.0"}]`
+	req, err := NewVerdictsRequest([]string{"js-tokens", "4.0.0", "19203fb59991df98e3a287050d4647cdeaf32499"})
+	require.Nil(t, err)
+
+	cases := []struct {
+		desc     string
+		statuses []int
+		retries  int
+		wantErr  string
+		wantHits int
+	}{
+		{"retry on 503", []int{http.StatusServiceUnavailable, http.StatusOK}, 3, "", 2},
+		{"retry on 429", []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK}, 3, "", 3},
+		{"no retry on 400", []int{http.StatusBadRequest, http.StatusOK}, 3, "400 Bad Request", 1},
+		{"retries exhausted", []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}, 2, "502 Bad Gateway", 3},
+		{"retries disabled", []int{http.StatusBadGateway, http.StatusOK}, 0, "502 Bad Gateway", 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			hits := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tc.statuses[min(hits, len(tc.statuses)-1)]
+				hits++
+				if status == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "0")
+				}
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					_, _ = w.Write([]byte(body))
+				}
+			}))
+			defer server.Close()
+
+			res, _, err := Packages(req, WithBaseURL(server.URL), WithEcosystem(ecosystem.Npm), WithRetries(tc.retries))
+			assert.Equal(t, tc.wantHits, hits)
+			if tc.wantErr != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.wantErr, err.Error())
+				}
+
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, &Response{
+				Package{Name: "js-tokens", Version: strPtr("4.0.0"), Digest: strPtr("19203fb59991df98e3a287050d4647cdeaf32499"), Verdicts: []Verdict{}},
+			}, res)
+		})
+	}
+}
+
+func TestRequestRetriesRespectTheContext(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	req, err := NewVerdictsRequest([]string{"js-tokens", "4.0.0", "19203fb59991df98e3a287050d4647cdeaf32499"})
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err = Packages(req, WithContext(ctx), WithBaseURL(server.URL), WithEcosystem(ecosystem.Npm), WithRetries(3))
+	// Waiting for the Retry-After would go past the deadline
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, 1, hits)
+	if assert.Error(t, err) {
+		assert.Equal(t, "giving up after 1 attempts: Too Many Requests", err.Error())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"7", 7 * time.Second, true},
+		{"-1", 0, true},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{now.Add(-30 * time.Second).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+	for _, tc := range cases {
+		res := &http.Response{Header: http.Header{}}
+		if tc.value != "" {
+			res.Header.Set("Retry-After", tc.value)
+		}
+		got, ok := retryAfter(res, now)
+		assert.Equal(t, tc.ok, ok, tc.value)
+		assert.Equal(t, tc.want, got, tc.value)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 0; attempt < 40; attempt++ {
+		want := backoffMax
+		if attempt < 30 {
+			want = min(backoffBase<<attempt, backoffMax)
+		}
+		got := backoff(attempt)
+		assert.GreaterOrEqual(t, got, want/2)
+		assert.LessOrEqual(t, got, want)
+	}
+}
+
+func TestBulkPackagesPartialResults(t *testing.T) {
+	withFastBackoff(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := VerdictsRequest{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		switch req.Name {
+		case "js-tokens":
+			_, _ = w.Write([]byte(`[{"name":"js-tokens","verdicts":[],"version":"4.0.0"}]`))
+		case "loose-envify":
+			// No package at all
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"unknown package"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	reqs, err := NewBulkVerdictsRequestsFromStrings([]string{"js-tokens", "loose-envify", "unknown"}, []string{"4.0.0", "1.4.0", "1.0.0"}, "")
+	require.Nil(t, err)
+
+	res, _, err := BulkPackages(reqs, WithBaseURL(server.URL), WithEcosystem(ecosystem.Npm), WithRetries(0))
+	partialErr := &PartialResultsError{}
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, "couldn't get the verdicts of 2 out of 3 packages", err.Error())
+	assert.Equal(t, 3, partialErr.Total)
+	if assert.Len(t, partialErr.Errors, 2) {
+		assert.Equal(t, "couldn't get the verdicts of loose-envify@1.4.0: no package in the response", partialErr.Errors[0].Error())
+		assert.Equal(t, "couldn't get the verdicts of unknown@1.0.0: unknown package", partialErr.Errors[1].Error())
+	}
+	assert.Equal(t, []NotAnalysed{
+		{Name: "loose-envify", Spec: "1.4.0", Reason: "no package in the response"},
+		{Name: "unknown", Spec: "1.0.0", Reason: "unknown package"},
+	}, partialErr.NotAnalysed())
+	assert.Equal(t, &Response{
+		Package{Name: "js-tokens", Version: strPtr("4.0.0"), Verdicts: []Verdict{}},
+	}, res)
+
+	_, resJSON, err := BulkPackages(reqs, WithBaseURL(server.URL), WithEcosystem(ecosystem.Npm), WithRetries(0), WithJSONOptions(flags.JSONFlags{JSON: true}))
+	require.ErrorAs(t, err, &partialErr)
+	assert.JSONEq(t, `[{"name":"js-tokens","verdicts":[],"version":"4.0.0"}]`, string(resJSON))
+
+	// When every request fails, there are no partial results
+	res, _, err = BulkPackages(reqs[1:], WithBaseURL(server.URL), WithEcosystem(ecosystem.Npm), WithRetries(0))
+	assert.Nil(t, res)
+	assert.NotErrorAs(t, err, &partialErr)
+	if assert.Error(t, err) {
+		assert.Equal(t, "no package in the response", err.Error())
+	}
+}
+
+func TestPackagesCache(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = w.Write([]byte(`[{"name":"js-tokens","verdicts":[],"version":"4.0.0"}]`))
+	}))
+	defer server.Close()
+
+	ctx := context.WithValue(t.Context(), pkgcontext.VerdictsCacheKey, cache.New(t.TempDir(), time.Hour))
+	want := &Response{
+		Package{Name: "js-tokens", Version: strPtr("4.0.0"), Verdicts: []Verdict{}},
+	}
+
+	req, err := NewVerdictsRequest([]string{"js-tokens", "4.0.0"})
+	require.Nil(t, err)
+	for i := 0; i < 2; i++ {
+		res, _, err := Packages(req, WithContext(ctx), WithBaseURL(server.URL), WithEcosystem(ecosystem.Npm))
+		require.Nil(t, err)
+		assert.Equal(t, want, res)
+	}
+	assert.Equal(t, 1, hits)
+	// The input request stays untouched
+	assert.Equal(t, ecosystem.None, req.Ecosystem)
+
+	// The bulk requests share the cache
+	reqs, err := NewBulkVerdictsRequestsFromStrings([]string{"js-tokens"}, []string{"4.0.0"}, "")
+	require.Nil(t, err)
+	_, resJSON, err := BulkPackages(reqs, WithContext(ctx), WithBaseURL(server.URL), WithEcosystem(ecosystem.Npm), WithJSONOptions(flags.JSONFlags{JSON: true}))
+	require.Nil(t, err)
+	assert.JSONEq(t, `[{"name":"js-tokens","verdicts":[],"version":"4.0.0"}]`, string(resJSON))
+	assert.Equal(t, 1, hits)
+	assert.Equal(t, ecosystem.None, reqs[0].Ecosystem)
+
+	// Requests without an exact version are never cached
+	req, err = NewVerdictsRequest([]string{"js-tokens"})
+	require.Nil(t, err)
+	for i := 0; i < 2; i++ {
+		_, _, err := Packages(req, WithContext(ctx), WithBaseURL(server.URL), WithEcosystem(ecosystem.Npm))
+		require.Nil(t, err)
+	}
+	assert.Equal(t, 3, hits)
+}
+
+func TestPackagesOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s in offline mode", r.URL)
+	}))
+	defer server.Close()
+
+	bundle := cache.NewBundle()
+	req, err := NewVerdictsRequest([]string{"js-tokens", "4.0.0"})
+	require.Nil(t, err)
+	require.Nil(t, AddToBundle(bundle, req, &Response{
+		Package{Name: "js-tokens", Version: strPtr("4.0.0"), Verdicts: []Verdict{}},
+		Package{Name: "loose-envify", Version: strPtr("1.4.0"), Verdicts: []Verdict{}},
+	}, WithEcosystem(ecosystem.Npm)))
+
+	ctx := context.WithValue(t.Context(), pkgcontext.VerdictsBundleKey, bundle)
+	opts := []func(*options){WithContext(ctx), WithBaseURL(server.URL), WithEcosystem(ecosystem.Npm)}
+
+	// Every package version of the bundled responses is in the bundle on its own too
+	req, err = NewVerdictsRequest([]string{"loose-envify", "1.4.0"})
+	require.Nil(t, err)
+	res, _, err := Packages(req, opts...)
+	require.Nil(t, err)
+	assert.Equal(t, &Response{
+		Package{Name: "loose-envify", Version: strPtr("1.4.0"), Verdicts: []Verdict{}},
+	}, res)
+
+	req, err = NewVerdictsRequest([]string{"react", "18.2.0"})
+	require.Nil(t, err)
+	_, _, err = Packages(req, opts...)
+	require.ErrorIs(t, err, ErrNotInBundle)
+	assert.Equal(t, "couldn't get the verdicts of react@18.2.0: not in the verdicts bundle", err.Error())
+
+	reqs, err := NewBulkVerdictsRequestsFromStrings([]string{"js-tokens", "react"}, []string{"4.0.0", "18.2.0"}, "")
+	require.Nil(t, err)
+	res, _, err = BulkPackages(reqs, opts...)
+	partialErr := &PartialResultsError{}
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, []NotAnalysed{{Name: "react", Spec: "18.2.0", Reason: "not in the verdicts bundle"}}, partialErr.NotAnalysed())
+	assert.Equal(t, &Response{
+		Package{Name: "js-tokens", Version: strPtr("4.0.0"), Verdicts: []Verdict{}},
+	}, res)
+
+	// The packages the bundle does not cover are partial results even when they are all of them
+	res, _, err = BulkPackages(reqs[1:], opts...)
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, "couldn't get the verdicts of 1 out of 1 package", err.Error())
+	assert.Equal(t, &Response{}, res)
+}
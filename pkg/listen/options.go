@@ -29,6 +29,8 @@ type options struct {
 	ecosystem ecosystem.Ecosystem
 	ctx       context.Context
 	json      flags.JSONFlags
+	// retries overrides the number of retries from the configuration options
+	retries *int
 }
 
 func newOptions(opts ...func(*options)) (*options, error) {
@@ -80,3 +82,12 @@ func WithEcosystem(eco ecosystem.Ecosystem) func(*options) {
 		o.ecosystem = eco
 	}
 }
+
+// WithRetries sets the maximum number of retries of the failed requests.
+//
+// When not set, the retries come from the configuration options in the context.
+func WithRetries(retries int) func(*options) {
+	return func(o *options) {
+		o.retries = &retries
+	}
+}
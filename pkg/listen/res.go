@@ -43,6 +43,21 @@ type Package struct {
 
 type Response []Package
 
+// NotAnalysed is a dependency lstn didn't get the verdicts for,
+// because it couldn't resolve it to a package version in the registry or because its request failed.
+type NotAnalysed struct {
+	// Name is the name of the dependency
+	Name string `json:"name"`
+	// Spec is the version specification of the dependency
+	Spec string `json:"spec,omitempty"`
+	// Group is the dependency type (eg., dev) or group
+	Group string `json:"group,omitempty"`
+	// Source is the path of the manifest declaring the dependency (if any)
+	Source string `json:"source,omitempty"`
+	// Reason tells why lstn didn't analyse the dependency
+	Reason string `json:"reason"`
+}
+
 func (r Response) Verdicts() models.Verdicts {
 	res := models.Verdicts{}
 	for _, p := range r {
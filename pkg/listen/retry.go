@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package listen
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+)
+
+// defaultRetries is the number of retries when the configuration options do not tell it.
+const defaultRetries = 3
+
+var (
+	// backoffBase is the delay before the first retry.
+	backoffBase = 500 * time.Millisecond
+	// backoffMax is the maximum delay between two attempts.
+	backoffMax = 10 * time.Second
+)
+
+// getRetries returns the number of retries from the options, or from the configuration options in their context.
+func getRetries(o *options) int {
+	if o.retries != nil {
+		return max(*o.retries, 0)
+	}
+	if cfg, ok := o.ctx.Value(pkgcontext.ConfigKey).(*flags.ConfigFlags); ok {
+		return max(cfg.Retries, 0)
+	}
+
+	return defaultRetries
+}
+
+// isRetryableStatus tells whether the response status code is worth another attempt.
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// isRetryableError tells whether the error sending the request is worth another attempt.
+func isRetryableError(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed)
+}
+
+// retryAfter parses the Retry-After header, in seconds or as an HTTP date.
+func retryAfter(res *http.Response, now time.Time) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+	value := res.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(secs)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// backoff returns the delay before the input retry (starting from zero),
+// growing exponentially up to backoffMax, with full jitter.
+func backoff(attempt int) time.Duration {
+	d := backoffMax
+	if attempt < 30 {
+		d = min(backoffBase<<attempt, backoffMax)
+	}
+
+	//nolint:gosec // The jitter does not need a secure random source
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// wait sleeps for the input delay, unless the context is done first.
+//
+// It does not sleep at all when the delay would go past the context deadline.
+func wait(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(d).After(deadline) {
+		return context.DeadlineExceeded
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// drain discards the rest of the response body and closes it, so that its connection gets reused.
+func drain(res *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+	res.Body.Close()
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/listendev/pkg/models/category"
 	"github.com/listendev/pkg/verdictcode"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -234,6 +235,50 @@ func TestNewBulkVerdictsRequestsFromMap(t *testing.T) {
 	}
 }
 
+func TestVerdictsRequestOkVersions(t *testing.T) {
+	tests := []struct {
+		eco     ecosystem.Ecosystem
+		version string
+		ok      bool
+	}{
+		{ecosystem.Npm, "18.2.0", true},
+		{ecosystem.Npm, "1.0.0-beta.1", true},
+		{ecosystem.Npm, "2.0", false},
+		{ecosystem.Npm, "1.0.post1", false},
+		{ecosystem.None, "1.0.post1", false},
+		{ecosystem.Pypi, "2.0", true},
+		{ecosystem.Pypi, "5.0rc1", true},
+		{ecosystem.Pypi, "1!2.0.post1.dev3", true},
+		{ecosystem.Pypi, "1.0+ubuntu.1", true},
+		{ecosystem.Pypi, "not-a-version", false},
+		{ecosystem.Pypi, "1..2", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.eco.String()+"/"+tc.version, func(t *testing.T) {
+			req := &VerdictsRequest{Name: "pkg", Version: tc.version, Ecosystem: tc.eco}
+			ok, err := req.Ok()
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.ok, err == nil)
+		})
+	}
+}
+
+func TestNewBulkVerdictsRequestsFromStrings(t *testing.T) {
+	reqs, err := NewBulkVerdictsRequestsFromStrings([]string{"requests", "requests"}, []string{"2.31.0", "2.32.0rc1"}, "")
+	require.Nil(t, err)
+	if assert.Len(t, reqs, 2) {
+		assert.Equal(t, "2.31.0", reqs[0].Version)
+		assert.Equal(t, "2.32.0rc1", reqs[1].Version)
+		assert.Same(t, reqs[0].Context, reqs[1].Context)
+	}
+
+	_, err = NewBulkVerdictsRequestsFromStrings([]string{"requests"}, []string{}, "")
+	if assert.Error(t, err) {
+		assert.Equal(t, "couldn't create a request set because of mismatching lengths", err.Error())
+	}
+}
+
 func TestNewContext(t *testing.T) {
 	analysisCtx1 := NewContext()
 	j1, e1 := json.Marshal(analysisCtx1)
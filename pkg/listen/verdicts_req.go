@@ -22,6 +22,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/listendev/lstn/pkg/jsonpath"
 	"github.com/listendev/lstn/pkg/validate"
+	"github.com/listendev/pkg/ecosystem"
 )
 
 type Request interface {
@@ -31,11 +32,13 @@ type Request interface {
 
 // VerdictsRequest represents the payload for the verdicts listen.dev API endpoint.
 type VerdictsRequest struct {
-	Name    string   `json:"name"              name:"name"                 validate:"mandatory"`
-	Version string   `json:"version,omitempty" validate:"omitempty,semver"`
+	Name    string   `json:"name"              name:"name" validate:"mandatory"`
+	Version string   `json:"version,omitempty" validate:"omitempty,ecosystem_version=Ecosystem"`
 	Digest  string   `json:"digest,omitempty"  validate:"omitempty,digest"`
 	Select  string   `json:"select,omitempty"`
 	Context *Context `json:"context,omitempty"`
+	// Ecosystem tells the format of the version (eg., PEP 440 for PyPi)
+	Ecosystem ecosystem.Ecosystem `json:"-"`
 }
 
 func fillVerdictsRequest(r *VerdictsRequest, args []string) (*VerdictsRequest, error) {
@@ -78,6 +81,21 @@ func NewVerdictsRequest(args []string) (*VerdictsRequest, error) {
 }
 
 func NewBulkVerdictsRequestsFromMap(deps map[string]*semver.Version, selection string) ([]*VerdictsRequest, error) {
+	versions := make(map[string]string, len(deps))
+	for name, vers := range deps {
+		versions[name] = ""
+		if vers != nil {
+			versions[name] = vers.String()
+		}
+	}
+
+	return NewBulkVerdictsRequestsFromVersions(versions, selection)
+}
+
+// NewBulkVerdictsRequestsFromVersions creates a request set from the input map of package names to exact versions.
+//
+// It does not make assumptions on the versions format, so it works for every ecosystem (eg., PEP 440 versions).
+func NewBulkVerdictsRequestsFromVersions(deps map[string]string, selection string) ([]*VerdictsRequest, error) {
 	if len(deps) == 0 {
 		return nil, fmt.Errorf("couldn't create a request set from empty dependencies map")
 	}
@@ -88,8 +106,8 @@ func NewBulkVerdictsRequestsFromMap(deps map[string]*semver.Version, selection s
 	reqs := make([]*VerdictsRequest, len(deps))
 	for name, vers := range deps {
 		inputs := []string{name}
-		if vers != nil {
-			inputs = append(inputs, vers.String())
+		if vers != "" {
+			inputs = append(inputs, vers)
 		}
 		var reqErr error
 		reqs[i], reqErr = NewVerdictsRequestWithContext(inputs, c)
@@ -105,6 +123,18 @@ func NewBulkVerdictsRequestsFromMap(deps map[string]*semver.Version, selection s
 }
 
 func NewBulkVerdictsRequests(names []string, versions semver.Collection, selection string) ([]*VerdictsRequest, error) {
+	vers := make([]string, len(versions))
+	for i, v := range versions {
+		vers[i] = v.String()
+	}
+
+	return NewBulkVerdictsRequestsFromStrings(names, vers, selection)
+}
+
+// NewBulkVerdictsRequestsFromStrings creates a request set from the input package names and their exact versions.
+//
+// Like NewBulkVerdictsRequestsFromVersions, it does not make assumptions on the versions format.
+func NewBulkVerdictsRequestsFromStrings(names []string, versions []string, selection string) ([]*VerdictsRequest, error) {
 	if len(names) != len(versions) {
 		return nil, fmt.Errorf("couldn't create a request set because of mismatching lengths")
 	}
@@ -113,7 +143,7 @@ func NewBulkVerdictsRequests(names []string, versions semver.Collection, selecti
 
 	reqs := make([]*VerdictsRequest, len(versions))
 	for i, v := range versions {
-		inputs := []string{names[i], v.String()}
+		inputs := []string{names[i], v}
 		var reqErr error
 		reqs[i], reqErr = NewVerdictsRequestWithContext(inputs, c)
 		if reqErr != nil {
@@ -125,6 +155,16 @@ func NewBulkVerdictsRequests(names []string, versions semver.Collection, selecti
 	return reqs, nil
 }
 
+// withEcosystem returns a copy of the request for the packages of the input ecosystem.
+//
+// It leaves the receiving request untouched.
+func (req *VerdictsRequest) withEcosystem(eco ecosystem.Ecosystem) *VerdictsRequest {
+	ret := *req
+	ret.Ecosystem = eco
+
+	return &ret
+}
+
 func (req VerdictsRequest) IsRequest() bool {
 	return true
 }
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/listendev/pkg/ecosystem"
+	upstream "github.com/listendev/pkg/lockfile"
+)
+
+// Lockfile enumerates the lock files lstn knows how to process.
+//
+// It extends the lock files of github.com/listendev/pkg/lockfile with the ones it does not support yet.
+type Lockfile upstream.Lockfile
+
+// The lock files github.com/listendev/pkg/lockfile supports.
+const (
+	PackageLockJSON = Lockfile(upstream.PackageLockJSON)
+	PoetryLock      = Lockfile(upstream.PoetryLock)
+)
+
+// The lock files github.com/listendev/pkg/lockfile does not support yet.
+//
+// Their values are far from the upstream ones, so that the lock files upstream adds later do not collide with them.
+const (
+	YarnLock Lockfile = iota + 100
+	PnpmLock
+	NpmShrinkwrapJSON
+	BunLock
+	UvLock
+	PdmLock
+	PipfileLock
+	RequirementsTxt
+)
+
+var upstreamLockfiles = []Lockfile{PackageLockJSON, PoetryLock}
+
+var filenames = map[Lockfile]string{
+	YarnLock:          "yarn.lock",
+	PnpmLock:          "pnpm-lock.yaml",
+	NpmShrinkwrapJSON: "npm-shrinkwrap.json",
+	BunLock:           "bun.lock",
+	UvLock:            "uv.lock",
+	PdmLock:           "pdm.lock",
+	PipfileLock:       "Pipfile.lock",
+	RequirementsTxt:   "requirements.txt",
+}
+
+var ecosystems = map[Lockfile]ecosystem.Ecosystem{
+	YarnLock:          ecosystem.Npm,
+	PnpmLock:          ecosystem.Npm,
+	NpmShrinkwrapJSON: ecosystem.Npm,
+	BunLock:           ecosystem.Npm,
+	UvLock:            ecosystem.Pypi,
+	PdmLock:           ecosystem.Pypi,
+	PipfileLock:       ecosystem.Pypi,
+	RequirementsTxt:   ecosystem.Pypi,
+}
+
+// String returns the file name of the lock file.
+func (l Lockfile) String() string {
+	if name, ok := filenames[l]; ok {
+		return name
+	}
+
+	return upstream.Lockfile(l).String()
+}
+
+// Ecosystem returns the ecosystem the input lock file belongs to.
+func Ecosystem(l Lockfile) ecosystem.Ecosystem {
+	if eco, ok := ecosystems[l]; ok {
+		return eco
+	}
+
+	return upstream.Ecosystem(upstream.Lockfile(l))
+}
+
+// FromPath detects the lock file type from the base name of the input path.
+func FromPath(p string) (Lockfile, bool) {
+	base := filepath.Base(p)
+	for _, l := range upstreamLockfiles {
+		if base == l.String() {
+			return l, true
+		}
+	}
+	for l, name := range filenames {
+		if base == name {
+			return l, true
+		}
+	}
+
+	return 0, false
+}
+
+// Existing groups the input paths by lock file type.
+//
+// It skips the paths that do not correspond to any supported lock file.
+// For every supported lock file that does not exist it returns an error instead.
+//
+// It leaves the lock files github.com/listendev/pkg/lockfile supports to it.
+func Existing(paths []string) (map[Lockfile][]string, map[Lockfile][]error) {
+	existing := map[Lockfile][]string{}
+	errors := map[Lockfile][]error{}
+
+	upstreamExisting, upstreamErrors := upstream.Existing(paths)
+	for l, p := range upstreamExisting {
+		existing[Lockfile(l)] = p
+	}
+	for l, errs := range upstreamErrors {
+		errors[Lockfile(l)] = errs
+	}
+
+	seen := map[string]bool{}
+	for _, p := range paths {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+
+		l, ok := FromPath(p)
+		if _, extended := filenames[l]; !ok || !extended {
+			continue
+		}
+		if info, err := os.Stat(p); err != nil || info.IsDir() {
+			errors[l] = append(errors[l], fmt.Errorf("%s not found", p))
+
+			continue
+		}
+		existing[l] = append(existing[l], p)
+	}
+
+	return existing, errors
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package lockfile
+
+import (
+	"testing"
+
+	"github.com/listendev/pkg/ecosystem"
+	upstream "github.com/listendev/pkg/lockfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromPath(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Lockfile
+		found bool
+	}{
+		{"package-lock.json", PackageLockJSON, true},
+		{"/some/dir/poetry.lock", PoetryLock, true},
+		{"sub/yarn.lock", YarnLock, true},
+		{"pnpm-lock.yaml", PnpmLock, true},
+		{"npm-shrinkwrap.json", NpmShrinkwrapJSON, true},
+		{"bun.lock", BunLock, true},
+		{"uv.lock", UvLock, true},
+		{"/py/pdm.lock", PdmLock, true},
+		{"Pipfile.lock", PipfileLock, true},
+		{"pipfile.lock", 0, false},
+		{"/py/requirements.txt", RequirementsTxt, true},
+		{"requirements-dev.txt", 0, false},
+		{"bun.lockb", 0, false},
+		{"yarn.lock/unk", 0, false},
+		{"unsupported-lockfile.json", 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, found := FromPath(tc.input)
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEcosystem(t *testing.T) {
+	assert.Equal(t, ecosystem.Npm, Ecosystem(PackageLockJSON))
+	assert.Equal(t, ecosystem.Npm, Ecosystem(YarnLock))
+	assert.Equal(t, ecosystem.Npm, Ecosystem(PnpmLock))
+	assert.Equal(t, ecosystem.Pypi, Ecosystem(PoetryLock))
+	assert.Equal(t, ecosystem.Pypi, Ecosystem(UvLock))
+	assert.Equal(t, ecosystem.Pypi, Ecosystem(PdmLock))
+	assert.Equal(t, ecosystem.Pypi, Ecosystem(PipfileLock))
+	assert.Equal(t, ecosystem.Pypi, Ecosystem(RequirementsTxt))
+	assert.Equal(t, ecosystem.None, Ecosystem(Lockfile(0)))
+	assert.Equal(t, "yarn.lock", YarnLock.String())
+	// The upstream lock files keep their upstream names
+	assert.Equal(t, upstream.PackageLockJSON.String(), PackageLockJSON.String())
+}
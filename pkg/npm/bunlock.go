@@ -0,0 +1,249 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// bunWorkspace represents a workspace package of a bun.lock file.
+type bunWorkspace struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+// bunPackageInfo represents the metadata Bun stores about a package.
+type bunPackageInfo struct {
+	Dependencies         map[string]string `json:"dependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+type bunLockFile struct {
+	LockfileVersion *int                         `json:"lockfileVersion"`
+	Workspaces      map[string]bunWorkspace      `json:"workspaces"`
+	Packages        map[string][]json.RawMessage `json:"packages"`
+}
+
+// bunLock represents a (text) bun.lock file.
+type bunLock struct {
+	version int
+	root    bunWorkspace
+	// tree maps the node_modules paths to the locked packages
+	tree map[string]*lockedPackage
+	convertedLock
+}
+
+// Version returns the version of the bun.lock format.
+func (b *bunLock) Version() int {
+	return b.version
+}
+
+// stripJSONC removes the comments and the trailing commas from the input JSONC contents.
+func stripJSONC(in []byte) []byte {
+	out := make([]byte, 0, len(in))
+	inString := false
+	for i := 0; i < len(in); i++ {
+		c := in[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(in) {
+				i++
+				out = append(out, in[i])
+			} else if c == '"' {
+				inString = false
+			}
+
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(in) && in[i+1] == '/':
+			for i < len(in) && in[i] != '\n' {
+				i++
+			}
+			if i < len(in) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(in) && in[i+1] == '*':
+			i += 2
+			for i+1 < len(in) && (in[i] != '*' || in[i+1] != '/') {
+				i++
+			}
+			i++
+		case c == ',':
+			// Drop the comma when the next meaningful character closes an object or an array
+			j := i + 1
+			for j < len(in) && strings.ContainsRune(" \t\r\n", rune(in[j])) {
+				j++
+			}
+			if j < len(in) && (in[j] == '}' || in[j] == ']') {
+				continue
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+// splitBunPath splits the input bun.lock package key into the names of the nested packages.
+//
+// For example, "wrap-ansi/@babel/runtime" means "node_modules/wrap-ansi/node_modules/@babel/runtime".
+func splitBunPath(key string) []string {
+	segments := strings.Split(key, "/")
+	ret := []string{}
+	for i := 0; i < len(segments); i++ {
+		if strings.HasPrefix(segments[i], "@") && i+1 < len(segments) {
+			ret = append(ret, segments[i]+"/"+segments[i+1])
+			i++
+
+			continue
+		}
+		ret = append(ret, segments[i])
+	}
+
+	return ret
+}
+
+// parseBunLock parses the contents of a bun.lock file.
+func parseBunLock(b []byte) (*bunLock, error) {
+	file := bunLockFile{}
+	if err := json.Unmarshal(stripJSONC(b), &file); err != nil {
+		return nil, fmt.Errorf("invalid bun lockfile")
+	}
+	if file.LockfileVersion == nil {
+		return nil, fmt.Errorf("missing bun lockfile version")
+	}
+
+	ret := &bunLock{
+		version: *file.LockfileVersion,
+		root:    file.Workspaces[""],
+		tree:    map[string]*lockedPackage{},
+	}
+
+	keys := make([]string, 0, len(file.Packages))
+	for k := range file.Packages {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		entry := file.Packages[k]
+		if len(entry) == 0 {
+			return nil, fmt.Errorf("missing resolution for %s", k)
+		}
+		var resolution string
+		if err := json.Unmarshal(entry[0], &resolution); err != nil {
+			return nil, fmt.Errorf("invalid resolution for %s", k)
+		}
+		realName, version := splitYarnSpecifier(resolution)
+		if version == "" {
+			return nil, fmt.Errorf("missing version for %s", k)
+		}
+		// Workspaces, git repositories, tarballs, and local paths are not in the registry
+		if hasProtocol(version) {
+			continue
+		}
+
+		names := splitBunPath(k)
+		name := names[len(names)-1]
+		pkg := &lockedPackage{
+			name:    name,
+			version: version,
+		}
+		if realName != name {
+			pkg.realName = realName
+		}
+		// Registry packages come as [resolution, registry, info, integrity]
+		if len(entry) > 2 {
+			info := bunPackageInfo{}
+			if err := json.Unmarshal(entry[2], &info); err == nil {
+				pkg.dependencies = info.Dependencies
+				pkg.optionalDependencies = info.OptionalDependencies
+			}
+		}
+		if len(entry) > 3 {
+			_ = json.Unmarshal(entry[3], &pkg.integrity)
+		}
+
+		p := ""
+		for _, n := range names {
+			p = childPath(p, n)
+		}
+		ret.tree[p] = pkg
+	}
+
+	ret.markDev(file.Workspaces)
+
+	return ret, nil
+}
+
+// markDev marks as development packages the ones that only the development dependencies of the workspaces require.
+func (b *bunLock) markDev(workspaces map[string]bunWorkspace) {
+	paths := map[*lockedPackage]string{}
+	for p, pkg := range b.tree {
+		paths[pkg] = p
+	}
+
+	prod := []*lockedPackage{}
+	dev := []*lockedPackage{}
+	for _, w := range workspaces {
+		for name := range w.Dependencies {
+			prod = append(prod, lookup(b.tree, "", name))
+		}
+		for name := range w.OptionalDependencies {
+			prod = append(prod, lookup(b.tree, "", name))
+		}
+		for name := range w.DevDependencies {
+			dev = append(dev, lookup(b.tree, "", name))
+		}
+	}
+
+	markDev(prod, dev, func(current *lockedPackage) []*lockedPackage {
+		ret := []*lockedPackage{}
+		for name := range current.deps() {
+			ret = append(ret, lookup(b.tree, paths[current], name))
+		}
+
+		return ret
+	})
+}
+
+// newBunLock parses the contents of a bun.lock file
+// and converts it into the equivalent package-lock.json.
+func newBunLock(b []byte) (*bunLock, error) {
+	ret, err := parseBunLock(b)
+	if err != nil {
+		return nil, err
+	}
+
+	ret.convertedLock.lock, err = newPackageLockJSONFromTree(ret.root.Name, ret.root.Version, ret.tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBunLockFromReader(t *testing.T) {
+	fixture, errFixture := os.Open("testdata/bun.lock")
+	require.Nil(t, errFixture)
+	defer fixture.Close()
+
+	lock, err := NewBunLockFromReader(fixture)
+	require.Nil(t, err)
+	require.IsType(t, &bunLock{}, lock)
+
+	assert.True(t, lock.Ok())
+	assert.Equal(t, 1, lock.Version())
+
+	deps := lock.Deps()
+	assert.Len(t, deps, 10)
+	assert.NotContains(t, deps, "lib")
+	assert.Equal(t, "7.20.13", deps["@babel/runtime"].Version)
+	assert.Equal(t, "4.3.4", deps["debug"].Version)
+	assert.Equal(t, "2.6.9", deps["wrap-ansi/node_modules/debug"].Version)
+	assert.Equal(t, "2.0.0", deps["wrap-ansi/node_modules/debug/node_modules/ms"].Version)
+	assert.Equal(t, "4.2.3", deps["string-width-cjs"].Version)
+
+	b, err := base64.StdEncoding.DecodeString(lock.Encode())
+	require.Nil(t, err)
+	converted := struct {
+		Name     string                    `json:"name"`
+		Packages map[string]map[string]any `json:"packages"`
+	}{}
+	require.Nil(t, json.Unmarshal(b, &converted))
+	assert.Equal(t, "sample", converted.Name)
+	assert.Equal(t, "string-width", converted.Packages["node_modules/string-width-cjs"]["name"])
+	assert.Equal(t, true, converted.Packages["node_modules/typescript"]["dev"])
+	assert.NotContains(t, converted.Packages["node_modules/@babel/runtime"], "dev")
+	assert.Equal(t, "sha512-sGkPx+VjMtmA6MX27oU4cBkPMS0z5F9Hr3OsQKKBfvArWJd9MM5wPvBK4VnoUsrnHqpYxzEl+vYUq7U6vNr09Q==", converted.Packages["node_modules/ms"]["integrity"])
+}
+
+func TestNewBunLockFromBytesErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		input   string
+		wantErr string
+	}{
+		{
+			desc:    "binary",
+			input:   "#!/usr/bin/env bun\nbun-lockfile-format-v0\n",
+			wantErr: "couldn't decode from the input bun.lock contents: invalid bun lockfile",
+		},
+		{
+			desc:    "missing-version",
+			input:   `{"packages": {}}`,
+			wantErr: "couldn't decode from the input bun.lock contents: missing bun lockfile version",
+		},
+		{
+			desc:    "missing-package-version",
+			input:   `{"lockfileVersion": 1, "packages": {"ms": ["ms"]}}`,
+			wantErr: "couldn't decode from the input bun.lock contents: missing version for ms",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := NewBunLockFromBytes([]byte(tc.input))
+			assert.Nil(t, res)
+			if assert.Error(t, err) {
+				assert.Equal(t, tc.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestStripJSONC(t *testing.T) {
+	input := `{
+  // comment
+  "a": "x, }", /* another comment */
+  "b": [1, 2,],
+}`
+	out := map[string]any{}
+	require.Nil(t, json.Unmarshal(stripJSONC([]byte(input)), &out))
+	assert.Equal(t, map[string]any{"a": "x, }", "b": []any{float64(1), float64(2)}}, out)
+}
+
+func TestSplitBunPath(t *testing.T) {
+	assert.Equal(t, []string{"ms"}, splitBunPath("ms"))
+	assert.Equal(t, []string{"@babel/runtime"}, splitBunPath("@babel/runtime"))
+	assert.Equal(t, []string{"wrap-ansi", "@babel/runtime", "ms"}, splitBunPath("wrap-ansi/@babel/runtime/ms"))
+}
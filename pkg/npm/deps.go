@@ -17,15 +17,17 @@ package npm
 
 import (
 	"context"
+	"fmt"
 	"maps"
 	"reflect"
-	"runtime"
 	"slices"
 	"sort"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/XANi/goneric"
 	npmdeptype "github.com/listendev/lstn/pkg/npm/deptype"
+	"github.com/listendev/lstn/pkg/ratelimit"
 )
 
 // Deps gets you the package lock dependencies.
@@ -42,6 +44,82 @@ func (p *packageLockJSON) Deps() map[string]PackageLockDependency {
 	}
 }
 
+// LockedVersion is a package version that a lock file pins.
+type LockedVersion struct {
+	Name    string
+	Version string
+}
+
+// LockedVersions returns the package versions that the input lock file dependencies pin, sorted by name and version, once.
+//
+// It maps the node_modules paths (eg., foo/node_modules/bar) and the aliases (eg., string-width-cjs) to the actual package names.
+// It skips the workspace packages and the dependencies without a version (eg., the links).
+func LockedVersions(deps map[string]PackageLockDependency) []LockedVersion {
+	seen := map[LockedVersion]bool{}
+	ret := []LockedVersion{}
+	for p, dep := range deps {
+		v, ok := lockedVersion(p, dep)
+		if ok && !seen[v] {
+			seen[v] = true
+			ret = append(ret, v)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Name != ret[j].Name {
+			return ret[i].Name < ret[j].Name
+		}
+
+		return ret[i].Version < ret[j].Version
+	})
+
+	return ret
+}
+
+// LockedShasums returns the hex SHA1 digests that the input lock file dependencies record, keyed by package name@version.
+//
+// Like LockedVersions, it maps the node_modules paths and the aliases to the actual package names.
+func LockedShasums(deps map[string]PackageLockDependency) map[string]string {
+	ret := map[string]string{}
+	for p, dep := range deps {
+		v, ok := lockedVersion(p, dep)
+		if !ok {
+			continue
+		}
+		if shasum := dep.Shasum(); shasum != "" {
+			ret[v.Name+"@"+v.Version] = shasum
+		}
+	}
+
+	return ret
+}
+
+// lockedVersion returns the package version that the input lock file dependency at path p pins, if any.
+func lockedVersion(p string, dep PackageLockDependency) (LockedVersion, bool) {
+	if dep.Link || dep.Version == "" {
+		return LockedVersion{}, false
+	}
+
+	name := p
+	if i := strings.LastIndex(p, "node_modules/"); i >= 0 {
+		name = p[i+len("node_modules/"):]
+	} else if strings.Count(p, "/") > 1 || (strings.Contains(p, "/") && !strings.HasPrefix(p, "@")) {
+		// Eg., packages/a is the directory of a workspace
+		return LockedVersion{}, false
+	}
+	if dep.Name != "" {
+		name = dep.Name
+	}
+	version := dep.Version
+	// The version 1 and 2 lock files tell the aliases in the version (eg., npm:string-width@4.2.3)
+	if spec, ok := strings.CutPrefix(version, "npm:"); ok {
+		if i := strings.LastIndex(spec, "@"); i > 0 {
+			name, version = spec[:i], spec[i+1:]
+		}
+	}
+
+	return LockedVersion{Name: name, Version: version}, true
+}
+
 func (p *packageJSON) getDepsByType(t npmdeptype.Enum) map[string]string {
 	// TODO: assert t != All
 
@@ -77,20 +155,168 @@ type dep struct {
 	name        string
 	version     *semver.Version
 	constraints *semver.Constraints
+	// tag is the distribution tag (eg., latest) when the dependency is not specified by a semver range
+	tag string
+	// alias is the name of the dependency in the package.json when it is an alias (eg., "foo": "npm:bar@^1")
+	alias string
+	// override is the dependency replacing this one when its resolved version satisfies the selector
+	override *dep
+	selector *semver.Constraints
+	// spec is the version specification of the dependency in the package.json (or in the overrides)
+	spec string
+	// err is the error resolving the dependency
+	err error
 }
 
-func getDepInstance(packageName, versionConstraint string) *dep {
-	constraints, err := semver.NewConstraint(versionConstraint)
-	// TODO: support URLs as dependencies (https://docs.npmjs.com/cli/v9/configuring-npm/package-json#dependencies)
-	// TODO: those do not match as semver version constraints...
+// ResolutionError is the error resolving a dependency against the npm registry.
+type ResolutionError struct {
+	// Name is the name of the dependency in the package.json
+	Name string
+	// Spec is the version specification of the dependency
+	Spec string
+	// Type is the dependency type
+	Type npmdeptype.Enum
+	// Source is the path of the package.json declaring the dependency, relative to the root one
+	Source string
+	Err    error
+}
+
+func (e *ResolutionError) Error() string {
+	return fmt.Sprintf("couldn't resolve %s@%s: %s", e.Name, e.Spec, e.Err.Error())
+}
+
+func (e *ResolutionError) Unwrap() error {
+	return e.Err
+}
+
+// key returns the name of the dependency in the package.json.
+func (d *dep) key() string {
+	if d.alias != "" {
+		return d.alias
+	}
+
+	return d.name
+}
+
+// resolve picks a version of the dependency, among the ones in the registry satisfying its specification.
+func (d *dep) resolve(ctx context.Context, strategy VersionResolutionStrategy) (*semver.Version, error) {
+	if d.tag != "" {
+		version, err := GetDistTagFromRegistry(ctx, d.name, d.tag)
+		if err != nil {
+			return nil, err
+		}
+
+		return strategy(d.key(), semver.Collection{version}), nil
+	}
+
+	// Get all the versions matching the constraint
+	collect, err := GetVersionsFromRegistry(ctx, d.name, d.constraints)
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	version := strategy(d.key(), collect)
+	if version == nil {
+		return nil, fmt.Errorf("no version of %s satisfies %s", d.name, d.constraints.String())
 	}
 
-	return &dep{
-		name:        packageName,
-		constraints: constraints,
+	return version, nil
+}
+
+func getDepInstance(packageName, versionConstraint string) *dep {
+	ret, _ := parseSpec(packageName, versionConstraint)
+
+	return ret
+}
+
+// bundledSpec returns the version specification of a bundled dependency.
+func (p *packageJSON) bundledSpec(name string) string {
+	for _, deps := range []map[string]string{p.Dependencies, p.OptionalDependencies} {
+		if spec, ok := deps[name]; ok {
+			return spec
+		}
 	}
+
+	return ""
+}
+
+// dependencies returns the dependencies of the input type lstn can resolve against the npm registry, after applying the overrides,
+// and the ones it cannot.
+func (p *packageJSON) dependencies(t npmdeptype.Enum) ([]*dep, []UnresolvableDependency) {
+	deps := []*dep{}
+	unresolvable := []UnresolvableDependency{}
+
+	overrides := p.overrides()
+	workspaces := p.workspaceNames()
+	for name, spec := range p.getDepsByType(t) {
+		fail := func(spec, reason string) {
+			unresolvable = append(unresolvable, UnresolvableDependency{Name: name, Spec: spec, Type: t, Source: p.Path(), Reason: reason})
+		}
+
+		// Bundled dependencies take their version specification from the other dependency types
+		if t == npmdeptype.BundleDependencies {
+			if spec = p.bundledSpec(name); spec == "" {
+				fail(spec, reasonBundled)
+
+				continue
+			}
+		}
+		if workspaces[name] {
+			fail(spec, reasonWorkspace)
+
+			continue
+		}
+
+		d, reason := parseSpec(name, spec)
+		if d == nil {
+			fail(spec, reason)
+
+			continue
+		}
+		d.spec = spec
+
+		if o, ok := overrides[d.name]; ok {
+			replacement, reason := parseSpec(name, o.spec)
+			if replacement != nil {
+				replacement.spec = o.spec
+			}
+			switch {
+			case o.selector != nil:
+				// We can only tell whether the override applies once we know the resolved version
+				if replacement != nil {
+					d.override, d.selector = replacement, o.selector
+				}
+			case replacement == nil:
+				fail(o.spec, reason)
+
+				continue
+			default:
+				d = replacement
+			}
+		}
+
+		deps = append(deps, d)
+	}
+
+	return deps, unresolvable
+}
+
+// Unresolvable returns the dependencies that do not come from the npm registry,
+// or whose version specification lstn doesn't understand.
+func (p *packageJSON) Unresolvable() []UnresolvableDependency {
+	ret := []UnresolvableDependency{}
+	for _, t := range npmdeptype.AllTypes {
+		_, unresolvable := p.dependencies(t)
+		ret = append(ret, unresolvable...)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Type != ret[j].Type {
+			return ret[i].Type < ret[j].Type
+		}
+
+		return ret[i].Name < ret[j].Name
+	})
+
+	return ret
 }
 
 func getDepsMapFromDepList(list []*dep, t npmdeptype.Enum, out map[npmdeptype.Enum]map[string]*semver.Version) {
@@ -174,42 +400,61 @@ func (p *packageJSON) FilterOutByNames(names ...string) {
 	}
 }
 
-func (p *packageJSON) Deps(ctx context.Context, resolve VersionResolutionStrategy) map[npmdeptype.Enum]map[string]*semver.Version {
+// Deps resolves the dependencies against the npm registry.
+//
+// It returns the resolved versions by dependency type, and the errors resolving the dependencies.
+func (p *packageJSON) Deps(ctx context.Context, resolve VersionResolutionStrategy) (map[npmdeptype.Enum]map[string]*semver.Version, []*ResolutionError) {
 	ret := map[npmdeptype.Enum]map[string]*semver.Version{}
+	errs := []*ResolutionError{}
 	for _, t := range npmdeptype.AllTypes {
-		depsByType := p.getDepsByType(t)
+		deps, _ := p.dependencies(t)
 
-		if len(depsByType) == 0 {
+		if len(deps) == 0 {
 			continue
 		}
 
-		// TODO: process the overrides field
-
-		// Create a slice of dep instances
-		deps := goneric.MapToSlice(getDepInstance, depsByType)
-
 		// Resolve version constraints with parallel requests to the registry
 		resolutions := goneric.ParallelMapSlice(func(input *dep) *dep {
-			if input == nil {
-				return nil
-			}
+			ret := &dep{name: input.key(), spec: input.spec}
 
-			// Get all the versions matching the constraint
-			collect, err := GetVersionsFromRegistry(ctx, input.name, input.constraints)
-			// TODO: understand what to do when the HTTP call to the registry fails
-			// TODO: how to propagate the error `err`?
+			version, err := input.resolve(ctx, resolve)
 			if err != nil {
-				return nil
+				ret.err = err
+
+				return ret
 			}
 
-			return &dep{
-				name:    input.name,
-				version: resolve(collect),
+			// Apply the override when the resolved version satisfies its selector
+			if input.override != nil && input.selector.Check(version) {
+				input = input.override
+				ret.spec = input.spec
+				if version, err = input.resolve(ctx, resolve); err != nil {
+					ret.err = err
+
+					return ret
+				}
 			}
-		}, runtime.NumCPU(), deps)
 
+			ret.name = input.name
+			ret.version = version
+
+			return ret
+		}, ratelimit.Concurrency(ctx), deps)
+
+		for _, res := range resolutions {
+			if res.err != nil {
+				errs = append(errs, &ResolutionError{Name: res.name, Spec: res.spec, Type: t, Source: p.Path(), Err: res.err})
+			}
+		}
 		getDepsMapFromDepList(resolutions, t, ret)
 	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Type != errs[j].Type {
+			return errs[i].Type < errs[j].Type
+		}
 
-	return ret
+		return errs[i].Name < errs[j].Name
+	})
+
+	return ret, errs
 }
@@ -16,11 +16,14 @@
 package npm
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Masterminds/semver/v3"
 	npmdeptype "github.com/listendev/lstn/pkg/npm/deptype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFilterOutByNames(t *testing.T) {
@@ -229,3 +232,70 @@ func TestGetDepInstance(t *testing.T) {
 		})
 	}
 }
+
+func TestLockedVersions(t *testing.T) {
+	cases := []struct {
+		dir  string
+		want []LockedVersion
+	}{
+		{
+			dir: "v3",
+			want: []LockedVersion{
+				{Name: "debug", Version: "2.6.9"},
+				{Name: "debug", Version: "3.2.7"},
+				{Name: "debug", Version: "4.3.4"},
+				{Name: "string-width", Version: "4.2.3"},
+				{Name: "string-width", Version: "5.1.2"},
+				{Name: "wrap-ansi", Version: "8.1.0"},
+			},
+		},
+		{
+			dir: "v1",
+			want: []LockedVersion{
+				{Name: "debug", Version: "3.2.7"},
+				{Name: "debug", Version: "4.3.4"},
+				{Name: "ms", Version: "2.1.3"},
+				{Name: "string-width", Version: "4.2.3"},
+				{Name: "string-width", Version: "5.1.2"},
+				{Name: "wrap-ansi", Version: "8.1.0"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.dir, func(t *testing.T) {
+			b, err := os.ReadFile(filepath.Join("testdata", "nested", tc.dir, "package-lock.json"))
+			require.Nil(t, err)
+			lock, err := NewPackageLockJSONFromBytes(b)
+			require.Nil(t, err)
+			assert.Equal(t, tc.want, LockedVersions(lock.Deps()))
+		})
+	}
+}
+
+func TestLockedShasums(t *testing.T) {
+	deps := map[string]PackageLockDependency{
+		"wrap-ansi": {Version: "8.1.0", Integrity: "sha512-PRWFHuSU3eDtQJPvnNY7Jcket1j0t5OuOsFzPPzsekD52Zl8qUfFIPEiswXqIvHWGVHOgX+7G/vCNNhehwxfkQ=="},
+		// Nested into the node_modules directory of another dependency
+		"wrap-ansi/node_modules/debug": {Version: "3.2.7", Integrity: "sha1-ELr/AOcO70Xu/afUcBbwWhjeF8M="},
+		// Installed under an alias
+		"string-width-cjs": {Name: "string-width", Version: "4.2.3", Integrity: "sha1-aBpNwXez1AZ2pZJnCIbGoACH4sM="},
+		// Nested into the node_modules directory of a workspace
+		"packages/a/node_modules/debug": {Version: "2.6.9", Integrity: "sha1-SDxDFa6Ly9mwp/UmlHRn1sBh6q4="},
+		"packages/a":                    {Name: "a", Version: "0.1.0", Integrity: "sha1-SDxDFa6Ly9mwp/UmlHRn1sBh6q4="},
+		"a":                             {Link: true},
+	}
+
+	assert.Equal(t, map[string]string{
+		"debug@3.2.7":        "10baff00e70eef45eefda7d47016f05a18de17c3",
+		"string-width@4.2.3": "681a4dc177b3d40676a592670886c6a00087e2c3",
+		"debug@2.6.9":        "483c4315ae8bcbd9b0a7f526947467d6c061eaae",
+	}, LockedShasums(deps))
+
+	// The version 1 and 2 lock files tell the aliases in the version
+	assert.Equal(t, map[string]string{
+		"string-width@4.2.3": "681a4dc177b3d40676a592670886c6a00087e2c3",
+	}, LockedShasums(map[string]PackageLockDependency{
+		"string-width-cjs": {Version: "npm:string-width@4.2.3", Integrity: "sha1-aBpNwXez1AZ2pZJnCIbGoACH4sM="},
+	}))
+}
@@ -18,21 +18,31 @@ package npm
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"maps"
 	"net/http"
 	"slices"
 	"sort"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/listendev/lstn/pkg/cmd/flags"
 	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/listendev/lstn/pkg/ratelimit"
+	"github.com/listendev/lstn/pkg/transport"
 	"github.com/listendev/lstn/pkg/ua"
 )
 
+// ErrUnauthorized is the error for the requests the npm registry denies because of missing or wrong credentials.
+var ErrUnauthorized = errors.New("the NPM registry denied the access")
+
 // GetFromRegistry asks to the npm registry for the details of a package
 // by name, and optionally, by version.
+//
+// When the context contains the npm configuration, it routes the request to the registry configured for the package,
+// with the credentials configured for that registry.
 func GetFromRegistry(ctx context.Context, name, version string) (io.ReadCloser, string, error) {
 	// Obtain the local options from the context
 	opts, err := pkgcontext.GetOptionsFromContext(ctx, pkgcontext.ConfigKey)
@@ -43,12 +53,14 @@ func GetFromRegistry(ctx context.Context, name, version string) (io.ReadCloser,
 	if !ok {
 		return nil, "", fmt.Errorf("couldn't find the registry configuration")
 	}
-	npmRegistryBaseURL := cfgFlags.NPM
+	npmrc, _ := ctx.Value(pkgcontext.NpmrcKey).(*Npmrc)
+	npmRegistryBaseURL := npmrc.Registry(name, cfgFlags.NPM)
 
 	if name == "" {
 		return nil, npmRegistryBaseURL, pkgcontext.OutputError(ctx, fmt.Errorf("the name is mandatory to query the npm registry"))
 	}
-	suffix := name
+	// Like npm does, escape the slash of the scoped package names (private registries require it)
+	suffix := strings.Replace(name, "/", "%2f", 1)
 	if version != "" {
 		suffix += fmt.Sprintf("/%s", version)
 	}
@@ -60,13 +72,25 @@ func GetFromRegistry(ctx context.Context, name, version string) (io.ReadCloser,
 	}
 
 	req.Header.Set("User-Agent", ua.Generate(true))
+	npmrc.Authorize(req, npmRegistryBaseURL)
 
-	res, err := http.DefaultClient.Do(req)
+	if err := ratelimit.Wait(ctx); err != nil {
+		return nil, npmRegistryBaseURL, pkgcontext.OutputError(ctx, err)
+	}
+
+	res, err := transport.Client(ctx).Do(req)
 	if err != nil {
 		return nil, npmRegistryBaseURL, pkgcontext.OutputErrorf(ctx, err, "couldn't perform the request to %s", req.URL)
 	}
 
+	if res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden {
+		res.Body.Close()
+
+		return nil, npmRegistryBaseURL, fmt.Errorf("%w to %s (check the credentials in the %s files)", ErrUnauthorized, req.URL, NpmrcFilename)
+	}
 	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+
 		return nil, npmRegistryBaseURL, pkgcontext.OutputErrorf(ctx, err, "the NPM registry response for %s was not ok", req.URL)
 	}
 
@@ -78,6 +102,9 @@ func GetVersionsFromRegistry(ctx context.Context, name string, constraints *semv
 	if URL == "" {
 		return nil, err
 	}
+	if errors.Is(err, ErrUnauthorized) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("package %s doesn't exist on registry %s", name, URL)
 	}
@@ -114,3 +141,43 @@ func GetVersionsFromRegistryResponse(body io.ReadCloser, constraints *semver.Con
 
 	return versions, nil
 }
+
+// GetDistTagFromRegistry asks to the npm registry for the version a distribution tag (eg., latest) of a package points to.
+func GetDistTagFromRegistry(ctx context.Context, name, tag string) (*semver.Version, error) {
+	body, URL, err := GetFromRegistry(ctx, name, "")
+	if URL == "" {
+		return nil, err
+	}
+	if errors.Is(err, ErrUnauthorized) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("package %s doesn't exist on registry %s", name, URL)
+	}
+
+	return GetDistTagFromRegistryResponse(body, tag)
+}
+
+func GetDistTagFromRegistryResponse(body io.ReadCloser, tag string) (*semver.Version, error) {
+	defer body.Close()
+	type response struct {
+		Name     string            `json:"name"`
+		DistTags map[string]string `json:"dist-tags"`
+	}
+	res := &response{}
+
+	if err := json.NewDecoder(body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("couldn't decode the registry response")
+	}
+
+	raw, ok := res.DistTags[tag]
+	if !ok {
+		return nil, fmt.Errorf("package %s has no %s distribution tag", res.Name, tag)
+	}
+	v, err := semver.NewVersion(raw)
+	if err != nil {
+		return nil, fmt.Errorf("couln't parse version %s for package %s", raw, res.Name)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// NpmrcFilename is the name of the npm configuration files.
+const NpmrcFilename = ".npmrc"
+
+// defaultRegistry is the default value of the npm registry flag.
+const defaultRegistry = "https://registry.npmjs.org"
+
+// envRe matches the environment variables in the npm configuration values (eg., ${NPM_TOKEN}, ${NPM_TOKEN?}).
+var envRe = regexp.MustCompile(`\$\{([^{}?]+)(\?)?\}`)
+
+// npmrcCredentials are the credentials for a registry.
+type npmrcCredentials struct {
+	token string
+	// auth is the base64 encoding of username:password
+	auth     string
+	username string
+	// password is base64 encoded
+	password string
+}
+
+func (c npmrcCredentials) empty() bool {
+	return c.token == "" && c.auth == "" && (c.username == "" || c.password == "")
+}
+
+// header returns the value of the Authorization header.
+func (c npmrcCredentials) header() string {
+	switch {
+	case c.token != "":
+		return "Bearer " + c.token
+	case c.auth != "":
+		return "Basic " + c.auth
+	case c.username != "" && c.password != "":
+		password, err := base64.StdEncoding.DecodeString(c.password)
+		if err != nil {
+			return ""
+		}
+
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.username+":"+string(password)))
+	}
+
+	return ""
+}
+
+// Npmrc is the npm configuration of the registries and of their credentials.
+type Npmrc struct {
+	registry string
+	// scopes maps the scopes (eg., @myorg) to their registries
+	scopes map[string]string
+	// credentials maps the registries (without protocol, eg., //npm.pkg.github.com/) to their credentials
+	credentials map[string]*npmrcCredentials
+	// legacy are the credentials not bound to a registry (eg., _authToken)
+	legacy     npmrcCredentials
+	alwaysAuth bool
+}
+
+func newNpmrc() *Npmrc {
+	return &Npmrc{
+		scopes:      map[string]string{},
+		credentials: map[string]*npmrcCredentials{},
+	}
+}
+
+// expand replaces the environment variables in the input value.
+//
+// Like npm does, it leaves the undefined ones as they are, unless they end with a question mark.
+func expand(value string) string {
+	return envRe.ReplaceAllStringFunc(value, func(match string) string {
+		m := envRe.FindStringSubmatch(match)
+		if v, ok := os.LookupEnv(m[1]); ok {
+			return v
+		}
+		if m[2] != "" {
+			return ""
+		}
+
+		return match
+	})
+}
+
+// nerfDart returns the registry URL without its protocol, always ending with a slash (eg., //registry.npmjs.org/).
+func nerfDart(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	path := parsed.Path
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+
+	return "//" + parsed.Host + path
+}
+
+// parse reads the input npm configuration, overriding the current settings.
+func (n *Npmrc) parse(contents []byte) {
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = expand(strings.TrimSpace(key))
+		value = expand(strings.Trim(strings.TrimSpace(value), `"'`))
+
+		switch {
+		case key == "registry":
+			n.registry = strings.TrimSuffix(value, "/")
+		case key == "always-auth":
+			n.alwaysAuth = value == "true"
+		case strings.HasPrefix(key, "@") && strings.HasSuffix(key, ":registry"):
+			n.scopes[strings.TrimSuffix(key, ":registry")] = strings.TrimSuffix(value, "/")
+		case strings.HasPrefix(key, "//"):
+			// Registry-specific settings (eg., //npm.pkg.github.com/:_authToken)
+			i := strings.LastIndex(key, ":")
+			if i < 0 {
+				continue
+			}
+			registry, field := key[:i], key[i+1:]
+			if !strings.HasSuffix(registry, "/") {
+				registry += "/"
+			}
+			if n.credentials[registry] == nil {
+				n.credentials[registry] = &npmrcCredentials{}
+			}
+			n.credentials[registry].set(field, value)
+		default:
+			n.legacy.set(key, value)
+		}
+	}
+}
+
+func (c *npmrcCredentials) set(field, value string) {
+	switch field {
+	case "_authToken":
+		c.token = value
+	case "_auth":
+		c.auth = value
+	case "username":
+		c.username = value
+	case "_password":
+		c.password = value
+	}
+}
+
+// GetNpmrc reads the user npm configuration and the project one in dir, the latter taking precedence.
+//
+// The user configuration is the one in the NPM_CONFIG_USERCONFIG environment variable, or the .npmrc in the home directory.
+func GetNpmrc(dir string) (*Npmrc, error) {
+	ret := newNpmrc()
+
+	paths := []string{}
+	if userConfig := os.Getenv("NPM_CONFIG_USERCONFIG"); userConfig != "" {
+		paths = append(paths, userConfig)
+	} else if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, NpmrcFilename))
+	}
+	if dir != "" {
+		paths = append(paths, filepath.Join(dir, NpmrcFilename))
+	}
+
+	for _, p := range paths {
+		contents, err := os.ReadFile(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, fmt.Errorf("couldn't read the %s file", p)
+		}
+		ret.parse(contents)
+	}
+
+	return ret, nil
+}
+
+// Registry returns the URL of the registry serving the input package.
+//
+// The registries configured for the scopes take precedence.
+// The configured registry replaces the fallback one only when the latter is the default npm registry.
+func (n *Npmrc) Registry(name, fallback string) string {
+	if n == nil {
+		return fallback
+	}
+	if scope, _, found := strings.Cut(name, "/"); found && strings.HasPrefix(scope, "@") {
+		if registry, ok := n.scopes[scope]; ok {
+			return registry
+		}
+	}
+	if n.registry != "" && strings.TrimSuffix(fallback, "/") == defaultRegistry {
+		return n.registry
+	}
+
+	return fallback
+}
+
+// Authorize sets the credentials of the registry the request is for, if any.
+//
+// It uses the credentials not bound to any registry for the default registry,
+// and for every registry with the always-auth setting.
+func (n *Npmrc) Authorize(req *http.Request, registry string) {
+	if n == nil {
+		return
+	}
+
+	// Pick the credentials of the longest registry URL prefix of the request URL
+	target := nerfDart(req.URL.String())
+	var creds *npmrcCredentials
+	longest := 0
+	for prefix, c := range n.credentials {
+		if strings.HasPrefix(target, prefix) && len(prefix) > longest && !c.empty() {
+			creds, longest = c, len(prefix)
+		}
+	}
+	if creds == nil && !n.legacy.empty() && (n.alwaysAuth || nerfDart(registry) == nerfDart(n.Registry("", defaultRegistry))) {
+		creds = &n.legacy
+	}
+	if creds == nil {
+		return
+	}
+
+	if header := creds.header(); header != "" {
+		req.Header.Set("Authorization", header)
+	}
+}
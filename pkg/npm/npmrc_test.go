@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNpmrcParse(t *testing.T) {
+	t.Setenv("NPM_TOKEN", "secret")
+
+	n := newNpmrc()
+	n.parse([]byte(heredoc.Doc(`
+		; the default registry
+		registry=https://artifactory.example.com/api/npm/npm/
+		@myorg:registry=https://npm.pkg.github.com
+		//npm.pkg.github.com/:_authToken=${NPM_TOKEN}
+		//artifactory.example.com/api/npm/npm/:_auth="dXNlcjpwYXNz"
+		//other.example.com/:username=user
+		//other.example.com/:_password=cGFzcw==
+		//unset.example.com/:_authToken=${UNSET_TOKEN?}
+		always-auth=true
+		# a comment
+	`)))
+
+	assert.Equal(t, "https://artifactory.example.com/api/npm/npm", n.registry)
+	assert.Equal(t, map[string]string{"@myorg": "https://npm.pkg.github.com"}, n.scopes)
+	assert.True(t, n.alwaysAuth)
+	assert.Equal(t, "secret", n.credentials["//npm.pkg.github.com/"].token)
+	assert.Equal(t, "dXNlcjpwYXNz", n.credentials["//artifactory.example.com/api/npm/npm/"].auth)
+	assert.Equal(t, "Basic dXNlcjpwYXNz", n.credentials["//other.example.com/"].header())
+	assert.True(t, n.credentials["//unset.example.com/"].empty())
+
+	// The later configurations override the previous ones
+	n.parse([]byte("registry=https://registry.example.com\n"))
+	assert.Equal(t, "https://registry.example.com", n.registry)
+	assert.Equal(t, "secret", n.credentials["//npm.pkg.github.com/"].token)
+}
+
+func TestExpand(t *testing.T) {
+	t.Setenv("NPM_TOKEN", "secret")
+
+	assert.Equal(t, "secret", expand("${NPM_TOKEN}"))
+	assert.Equal(t, "Bearer secret", expand("Bearer ${NPM_TOKEN?}"))
+	assert.Equal(t, "${UNSET_TOKEN}", expand("${UNSET_TOKEN}"))
+	assert.Equal(t, "", expand("${UNSET_TOKEN?}"))
+}
+
+func TestNpmrcRegistry(t *testing.T) {
+	var n *Npmrc
+	assert.Equal(t, defaultRegistry, n.Registry("react", defaultRegistry))
+
+	n = newNpmrc()
+	n.parse([]byte("registry=https://artifactory.example.com/npm\n@myorg:registry=https://npm.pkg.github.com/\n"))
+
+	assert.Equal(t, "https://npm.pkg.github.com", n.Registry("@myorg/utils", defaultRegistry))
+	assert.Equal(t, "https://npm.pkg.github.com", n.Registry("@myorg/utils", "https://custom.example.com"))
+	assert.Equal(t, "https://artifactory.example.com/npm", n.Registry("@types/react", defaultRegistry))
+	assert.Equal(t, "https://artifactory.example.com/npm", n.Registry("react", defaultRegistry+"/"))
+	// The registry explicitly set with the flags takes precedence
+	assert.Equal(t, "https://custom.example.com", n.Registry("react", "https://custom.example.com"))
+}
+
+func TestNpmrcAuthorize(t *testing.T) {
+	n := newNpmrc()
+	n.parse([]byte(heredoc.Doc(`
+		//npm.pkg.github.com/:_authToken=ghp_token
+		//artifactory.example.com/api/npm/:_auth=ZGVmYXVsdDpwYXNz
+		//artifactory.example.com/api/npm/private/:_authToken=private_token
+		_authToken=legacy_token
+	`)))
+
+	authorization := func(u, registry string) string {
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		require.Nil(t, err)
+		n.Authorize(req, registry)
+
+		return req.Header.Get("Authorization")
+	}
+
+	assert.Equal(t, "Bearer ghp_token", authorization("https://npm.pkg.github.com/@myorg%2futils", "https://npm.pkg.github.com"))
+	assert.Equal(t, "Basic ZGVmYXVsdDpwYXNz", authorization("https://artifactory.example.com/api/npm/public/react", "https://artifactory.example.com/api/npm/public"))
+	// The longest matching registry wins
+	assert.Equal(t, "Bearer private_token", authorization("https://artifactory.example.com/api/npm/private/react", "https://artifactory.example.com/api/npm/private"))
+	// The legacy credentials only go to the default registry, unless always-auth is on
+	assert.Equal(t, "Bearer legacy_token", authorization("https://registry.npmjs.org/react", defaultRegistry))
+	assert.Equal(t, "", authorization("https://example.com/react", "https://example.com"))
+	n.parse([]byte("always-auth=true\n"))
+	assert.Equal(t, "Bearer legacy_token", authorization("https://example.com/react", "https://example.com"))
+}
+
+func TestGetNpmrc(t *testing.T) {
+	user := filepath.Join(t.TempDir(), "npmrc")
+	require.Nil(t, os.WriteFile(user, []byte("registry=https://user.example.com\n//npm.pkg.github.com/:_authToken=user_token\n"), 0o600))
+	t.Setenv("NPM_CONFIG_USERCONFIG", user)
+
+	project := t.TempDir()
+	require.Nil(t, os.WriteFile(filepath.Join(project, NpmrcFilename), []byte("registry=https://project.example.com\n"), 0o600))
+
+	n, err := GetNpmrc(project)
+	require.Nil(t, err)
+	assert.Equal(t, "https://project.example.com", n.registry)
+	assert.Equal(t, "user_token", n.credentials["//npm.pkg.github.com/"].token)
+
+	// Missing files are fine
+	t.Setenv("NPM_CONFIG_USERCONFIG", filepath.Join(t.TempDir(), "missing"))
+	n, err = GetNpmrc(t.TempDir())
+	require.Nil(t, err)
+	assert.Equal(t, "", n.registry)
+}
+
+func TestGetFromRegistryWithNpmrc(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ghp_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+		if r.URL.RawPath != "/@myorg%2futils" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		fmt.Fprint(w, `{"name": "@myorg/utils", "versions": {"1.0.0": {}, "1.1.0": {}}}`)
+	}))
+	defer server.Close()
+
+	cfg, err := flags.NewConfigFlags()
+	require.Nil(t, err)
+	ctx := context.WithValue(t.Context(), pkgcontext.ConfigKey, cfg)
+
+	// Without credentials
+	n := newNpmrc()
+	n.parse([]byte("@myorg:registry=" + server.URL + "\n"))
+	_, err = GetVersionsFromRegistry(context.WithValue(ctx, pkgcontext.NpmrcKey, n), "@myorg/utils", nil)
+	require.ErrorIs(t, err, ErrUnauthorized)
+
+	// With the credentials for the scope registry
+	n.parse([]byte(nerfDart(server.URL) + ":_authToken=ghp_token\n"))
+	versions, err := GetVersionsFromRegistry(context.WithValue(ctx, pkgcontext.NpmrcKey, n), "@myorg/utils", nil)
+	require.Nil(t, err)
+	assert.Len(t, versions, 2)
+
+	// The base64 of the username and password
+	assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("user:pass")), npmrcCredentials{username: "user", password: "cGFzcw=="}.header())
+}
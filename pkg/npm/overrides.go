@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	npmdeptype "github.com/listendev/lstn/pkg/npm/deptype"
+)
+
+// override is the version specification replacing the one of a dependency.
+type override struct {
+	spec string
+	// selector restricts the override to the versions satisfying it (eg., the ^1 in the foo@^1 key)
+	selector *semver.Constraints
+}
+
+// overrides returns the overrides of the root package.json by package name.
+//
+// It only considers the ones applying to the direct dependencies (ie., the top-level ones, and the . of the nested ones),
+// and it replaces the references to the direct dependencies (eg., $foo) with their specifications.
+func (p *packageJSON) overrides() map[string]override {
+	// Only the root package.json can override the dependencies
+	root := p
+	if p.root != nil {
+		root = p.root
+	}
+
+	ret := map[string]override{}
+	for key, value := range root.Overrides {
+		spec := ""
+		switch v := value.(type) {
+		case string:
+			spec = v
+		case map[string]any:
+			if s, ok := v["."].(string); ok {
+				spec = s
+			}
+		}
+		if spec == "" {
+			continue
+		}
+
+		if strings.HasPrefix(spec, "$") {
+			ref := strings.TrimPrefix(spec, "$")
+			spec = ""
+			for _, t := range []npmdeptype.Enum{npmdeptype.Dependencies, npmdeptype.DevDependencies, npmdeptype.OptionalDependencies, npmdeptype.PeerDependencies} {
+				if s, ok := root.getDepsByType(t)[ref]; ok {
+					spec = s
+
+					break
+				}
+			}
+			// Like npm does, ignore the references to packages that are not direct dependencies
+			if spec == "" {
+				continue
+			}
+		}
+
+		name, o := key, override{spec: spec}
+		if i := strings.LastIndex(key, "@"); i > 0 {
+			selector, err := semver.NewConstraint(key[i+1:])
+			if err != nil {
+				continue
+			}
+			name, o.selector = key[:i], selector
+		}
+		ret[name] = o
+	}
+
+	return ret
+}
@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pnpmImporterDep represents a dependency of a pnpm importer.
+//
+// Lockfile version 5 only contains its version,
+// while the newer lockfile versions contain its specifier, too.
+type pnpmImporterDep struct {
+	Specifier string `yaml:"specifier"`
+	Version   string `yaml:"version"`
+}
+
+func (d *pnpmImporterDep) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		d.Version = value.Value
+
+		return nil
+	}
+	type plain pnpmImporterDep
+
+	return value.Decode((*plain)(d))
+}
+
+// pnpmImporter represents a project (or a workspace package) of a pnpm-lock.yaml.
+type pnpmImporter struct {
+	Dependencies         map[string]pnpmImporterDep `yaml:"dependencies"`
+	DevDependencies      map[string]pnpmImporterDep `yaml:"devDependencies"`
+	OptionalDependencies map[string]pnpmImporterDep `yaml:"optionalDependencies"`
+}
+
+type pnpmResolution struct {
+	Integrity string `yaml:"integrity"`
+	Tarball   string `yaml:"tarball"`
+}
+
+// pnpmPackage represents an entry of the packages (or snapshots) section of a pnpm-lock.yaml.
+type pnpmPackage struct {
+	Resolution           pnpmResolution    `yaml:"resolution"`
+	Name                 string            `yaml:"name"`
+	Version              string            `yaml:"version"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+	Optional             bool              `yaml:"optional"`
+}
+
+type pnpmLockFile struct {
+	LockfileVersion string                  `yaml:"lockfileVersion"`
+	Importers       map[string]pnpmImporter `yaml:"importers"`
+	// Lockfiles without workspaces keep the dependencies of the project at the top level
+	pnpmImporter `yaml:",inline"`
+	Packages     map[string]pnpmPackage `yaml:"packages"`
+	Snapshots    map[string]pnpmPackage `yaml:"snapshots"`
+}
+
+// pnpmLock represents a pnpm-lock.yaml file.
+type pnpmLock struct {
+	version  int
+	packages []*lockedPackage
+	// byPath maps the pnpm dependency paths to the locked packages
+	byPath map[string]*lockedPackage
+	// aliases maps the alias names and the dependency paths to the locked packages installed under an alias
+	aliases map[string]*lockedPackage
+	// importers are the projects depending on the locked packages
+	importers []lockedImporter
+	convertedLock
+}
+
+// Version returns the major version of the pnpm-lock.yaml format.
+func (p *pnpmLock) Version() int {
+	return p.version
+}
+
+func (p *pnpmLock) Ok() bool {
+	return p.version > 0 && p.convertedLock.Ok()
+}
+
+// pnpmPath converts the input dependency reference into a pnpm dependency path.
+//
+// The reference is either a version (possibly followed by the peer dependencies suffix),
+// or a dependency path itself (aliases), or a local path (eg., "link:../pkg").
+func (p *pnpmLock) pnpmPath(name, ref string) string {
+	if strings.HasPrefix(ref, "/") || strings.HasPrefix(ref, "link:") {
+		return ref
+	}
+	switch p.version {
+	case 5:
+		if hasProtocol(ref) {
+			return ref
+		}
+
+		return "/" + name + "/" + ref
+	case 6:
+		if hasProtocol(ref) {
+			return ref
+		}
+
+		return "/" + name + "@" + ref
+	default:
+		// Aliases reference the actual package (eg., "string-width@4.2.3")
+		plain, _, _ := strings.Cut(ref, "(")
+		if strings.LastIndex(plain, "@") > 0 {
+			return ref
+		}
+
+		return name + "@" + ref
+	}
+}
+
+// parsePnpmPath splits the input pnpm dependency path into the package name and version.
+func (p *pnpmLock) parsePnpmPath(path string) (string, string) {
+	path = strings.TrimPrefix(path, "/")
+	if p.version == 5 {
+		segments := strings.Split(path, "/")
+		n := 1
+		if strings.HasPrefix(path, "@") {
+			n = 2
+		}
+		if len(segments) <= n {
+			return path, ""
+		}
+		version, _, _ := strings.Cut(segments[n], "_")
+
+		return strings.Join(segments[:n], "/"), version
+	}
+
+	plain, _, _ := strings.Cut(path, "(")
+
+	return splitYarnSpecifier(plain)
+}
+
+func (p *pnpmLock) resolve(_ *lockedPackage, name, ref string) *lockedPackage {
+	path := p.pnpmPath(name, ref)
+	if alias, ok := p.aliases[name+" "+path]; ok {
+		return alias
+	}
+
+	return p.byPath[path]
+}
+
+// alias creates the locked package installed under the input name
+// when it is an alias of the actual package the reference points to.
+func (p *pnpmLock) alias(name, ref string) {
+	path := p.pnpmPath(name, ref)
+	target, ok := p.byPath[path]
+	if !ok || target.name == name {
+		return
+	}
+	key := name + " " + path
+	if _, ok := p.aliases[key]; ok {
+		return
+	}
+	alias := *target
+	alias.name = name
+	alias.realName = target.name
+	p.aliases[key] = &alias
+	p.packages = append(p.packages, &alias)
+}
+
+// parsePnpmLock parses the contents of a pnpm-lock.yaml file.
+func parsePnpmLock(b []byte) (*pnpmLock, error) {
+	file := pnpmLockFile{}
+	if err := yaml.Unmarshal(b, &file); err != nil {
+		return nil, fmt.Errorf("invalid pnpm lockfile")
+	}
+	major, _, _ := strings.Cut(strings.Trim(file.LockfileVersion, `'"`), ".")
+	version, err := strconv.Atoi(major)
+	if err != nil {
+		return nil, fmt.Errorf("missing pnpm lockfile version")
+	}
+	switch version {
+	case 5, 6, 9:
+	default:
+		return nil, fmt.Errorf("unsupported pnpm lockfile version %s", file.LockfileVersion)
+	}
+
+	ret := &pnpmLock{
+		version: version,
+		byPath:  map[string]*lockedPackage{},
+		aliases: map[string]*lockedPackage{},
+	}
+
+	// Since lockfile version 9 the dependencies of the packages live into the snapshots
+	entries := file.Packages
+	if version >= 9 {
+		entries = file.Snapshots
+	}
+	paths := make([]string, 0, len(entries))
+	for path := range entries {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		entry := entries[path]
+		name, version := ret.parsePnpmPath(path)
+		metadata := entry
+		if ret.version >= 9 {
+			plain, _, _ := strings.Cut(path, "(")
+			metadata = file.Packages[plain]
+		}
+		if metadata.Name != "" {
+			name = metadata.Name
+		}
+		if metadata.Version != "" {
+			version = metadata.Version
+		}
+		if name == "" || version == "" {
+			return nil, fmt.Errorf("missing name or version for %s", path)
+		}
+		ret.byPath[path] = &lockedPackage{
+			name:                 name,
+			version:              version,
+			resolved:             metadata.Resolution.Tarball,
+			integrity:            metadata.Resolution.Integrity,
+			optional:             entry.Optional || metadata.Optional,
+			dependencies:         entry.Dependencies,
+			optionalDependencies: entry.OptionalDependencies,
+		}
+		ret.packages = append(ret.packages, ret.byPath[path])
+	}
+
+	importers := file.Importers
+	if len(importers) == 0 {
+		importers = map[string]pnpmImporter{".": file.pnpmImporter}
+	}
+
+	// Create the packages installed under an alias
+	for _, importer := range importers {
+		for _, deps := range []map[string]pnpmImporterDep{importer.Dependencies, importer.DevDependencies, importer.OptionalDependencies} {
+			for name, dep := range deps {
+				ret.alias(name, dep.Version)
+			}
+		}
+	}
+	for _, path := range paths {
+		entry := entries[path]
+		for _, deps := range []map[string]string{entry.Dependencies, entry.OptionalDependencies} {
+			for name, ref := range deps {
+				ret.alias(name, ref)
+			}
+		}
+	}
+
+	ret.markDev(importers)
+
+	for path, importer := range importers {
+		deps := map[string]string{}
+		for _, d := range []map[string]pnpmImporterDep{importer.Dependencies, importer.DevDependencies, importer.OptionalDependencies} {
+			for name, dep := range d {
+				deps[name] = dep.Version
+			}
+		}
+		if path == "." {
+			path = ""
+		}
+		ret.importers = append(ret.importers, lockedImporter{path: path, deps: deps})
+	}
+
+	return ret, nil
+}
+
+// markDev marks as development packages the ones that only the development dependencies of the importers require.
+func (p *pnpmLock) markDev(importers map[string]pnpmImporter) {
+	prod := []*lockedPackage{}
+	dev := []*lockedPackage{}
+	for _, importer := range importers {
+		for name, dep := range importer.Dependencies {
+			prod = append(prod, p.resolve(nil, name, dep.Version))
+		}
+		for name, dep := range importer.OptionalDependencies {
+			prod = append(prod, p.resolve(nil, name, dep.Version))
+		}
+		for name, dep := range importer.DevDependencies {
+			dev = append(dev, p.resolve(nil, name, dep.Version))
+		}
+	}
+
+	markDev(prod, dev, func(current *lockedPackage) []*lockedPackage {
+		ret := []*lockedPackage{}
+		for name, ref := range current.deps() {
+			ret = append(ret, p.resolve(current, name, ref))
+		}
+
+		return ret
+	})
+}
+
+// newPnpmLock parses the contents of a pnpm-lock.yaml file
+// and converts it into the equivalent package-lock.json.
+func newPnpmLock(b []byte) (*pnpmLock, error) {
+	ret, err := parsePnpmLock(b)
+	if err != nil {
+		return nil, err
+	}
+
+	ret.convertedLock.lock, err = newPackageLockJSONFromLocked("", "", ret.packages, ret.importers, ret.resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPnpmLockFromReader(t *testing.T) {
+	tests := []struct {
+		dir     string
+		version int
+	}{
+		{"v5", 5},
+		{"v6", 6},
+		{"v9", 9},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.dir, func(t *testing.T) {
+			fixture, errFixture := os.Open(path.Join("testdata", "pnpm", tc.dir, "pnpm-lock.yaml"))
+			require.Nil(t, errFixture)
+			defer fixture.Close()
+
+			lock, err := NewPnpmLockFromReader(fixture)
+			require.Nil(t, err)
+			require.IsType(t, &pnpmLock{}, lock)
+
+			assert.True(t, lock.Ok())
+			assert.Equal(t, tc.version, lock.Version())
+
+			deps := lock.Deps()
+			assert.Len(t, deps, 9)
+			assert.Equal(t, "4.3.4", deps["debug"].Version)
+			assert.Equal(t, "2.1.2", deps["ms"].Version)
+			assert.Equal(t, "7.0.0", deps["wrap-ansi"].Version)
+			assert.Equal(t, "2.6.9", deps["wrap-ansi/node_modules/debug"].Version)
+			assert.Equal(t, "2.0.0", deps["wrap-ansi/node_modules/debug/node_modules/ms"].Version)
+			assert.Equal(t, "2.3.2", deps["fsevents"].Version)
+			assert.Equal(t, "4.9.5", deps["typescript"].Version)
+			assert.Equal(t, "4.2.3", deps["string-width"].Version)
+			assert.Equal(t, "4.2.3", deps["string-width-cjs"].Version)
+
+			b, err := base64.StdEncoding.DecodeString(lock.Encode())
+			require.Nil(t, err)
+			converted := struct {
+				Packages map[string]map[string]any `json:"packages"`
+			}{}
+			require.Nil(t, json.Unmarshal(b, &converted))
+			packages := converted.Packages
+			assert.Equal(t, "string-width", packages["node_modules/string-width-cjs"]["name"])
+			assert.Equal(t, true, packages["node_modules/typescript"]["dev"])
+			assert.NotContains(t, packages["node_modules/debug"], "dev")
+			assert.Equal(t, true, packages["node_modules/fsevents"]["optional"])
+			assert.Equal(t, "sha512-PRWFHuSU3eDtQJPvnNY7Jcket1j0t5OuOsFzPPzsekD52Zl8qUfFIPEiswXqIvHWGVHOgX+7G/vCNNhehwxfkQ==", packages["node_modules/debug"]["integrity"])
+		})
+	}
+}
+
+func TestNewPnpmLockFromBytesErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		input   string
+		wantErr string
+	}{
+		{
+			desc:    "missing-version",
+			input:   "packages: {}\n",
+			wantErr: "couldn't decode from the input pnpm-lock.yaml contents: missing pnpm lockfile version",
+		},
+		{
+			desc:    "unsupported-version",
+			input:   "lockfileVersion: '7.0'\n",
+			wantErr: "couldn't decode from the input pnpm-lock.yaml contents: unsupported pnpm lockfile version 7.0",
+		},
+		{
+			desc:    "invalid",
+			input:   "- lockfileVersion\n",
+			wantErr: "couldn't decode from the input pnpm-lock.yaml contents: invalid pnpm lockfile",
+		},
+		{
+			desc: "missing-package-version",
+			input: heredoc.Doc(`
+				lockfileVersion: '6.0'
+
+				packages:
+				  /ms:
+				    resolution: {integrity: sha512-xxx}
+			`),
+			wantErr: "couldn't decode from the input pnpm-lock.yaml contents: missing name or version for /ms",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := NewPnpmLockFromBytes([]byte(tc.input))
+			assert.Nil(t, res)
+			if assert.Error(t, err) {
+				assert.Equal(t, tc.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestParsePnpmPath(t *testing.T) {
+	tests := []struct {
+		version int
+		path    string
+		name    string
+		ver     string
+	}{
+		{5, "/ms/2.1.2", "ms", "2.1.2"},
+		{5, "/@babel/core/7.20.0_supports-color@5.5.0", "@babel/core", "7.20.0"},
+		{6, "/ms@2.1.2", "ms", "2.1.2"},
+		{6, "/@babel/core@7.20.0(supports-color@5.5.0)", "@babel/core", "7.20.0"},
+		{9, "ms@2.1.2", "ms", "2.1.2"},
+		{9, "@babel/core@7.20.0(supports-color@5.5.0)", "@babel/core", "7.20.0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			p := &pnpmLock{version: tc.version}
+			name, ver := p.parsePnpmPath(tc.path)
+			assert.Equal(t, tc.name, name)
+			assert.Equal(t, tc.ver, ver)
+		})
+	}
+}
+
+func TestNewPnpmLockFromReaderImporters(t *testing.T) {
+	fixture, errFixture := os.Open(path.Join("testdata", "pnpm", "importers", "pnpm-lock.yaml"))
+	require.Nil(t, errFixture)
+	defer fixture.Close()
+
+	lock, err := NewPnpmLockFromReader(fixture)
+	require.Nil(t, err)
+
+	deps := lock.Deps()
+	assert.Len(t, deps, 2)
+	assert.Equal(t, "4.17.21", deps["lodash"].Version)
+	assert.Equal(t, "3.10.1", deps["packages/a/node_modules/lodash"].Version)
+	assert.NotContains(t, deps, "packages/b/node_modules/lodash")
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	npmdeptype "github.com/listendev/lstn/pkg/npm/deptype"
+)
+
+const (
+	aliasPrefix     = "npm:"
+	workspacePrefix = "workspace:"
+)
+
+var (
+	// gitHostedRe matches the shortcuts for the git repositories (eg., github:user/repo, user/repo#semver:^1).
+	gitHostedRe = regexp.MustCompile(`^((github|gitlab|bitbucket|gist):)?[^@\s/:]+/[^\s/:]+(#.*)?$`)
+	// distTagRe matches the names of the distribution tags (eg., latest, next).
+	distTagRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._-]*$`)
+)
+
+// The reasons why a dependency cannot be resolved against the npm registry.
+const (
+	reasonLocal     = "local package"
+	reasonWorkspace = "workspace package"
+	reasonGit       = "git repository"
+	reasonTarball   = "tarball URL"
+	reasonInvalid   = "invalid version specification"
+	reasonBundled   = "bundled without a version specification"
+)
+
+// UnresolvableDependency is a dependency that does not come from the npm registry,
+// or whose version specification lstn doesn't understand.
+type UnresolvableDependency struct {
+	// Name is the name of the dependency in the package.json
+	Name string
+	// Spec is the version specification of the dependency in the package.json
+	Spec string
+	// Type is the dependency type
+	Type npmdeptype.Enum
+	// Source is the path of the package.json declaring the dependency, relative to the root one
+	Source string
+	// Reason tells why lstn cannot resolve the dependency
+	Reason string
+}
+
+// parseSpec parses the specification of a dependency (eg., ^1.2.0, npm:foo@^1, latest, file:../foo).
+//
+// It returns the reason why the dependency cannot be resolved against the npm registry when the spec is not a semver range,
+// a distribution tag, or an alias of them.
+func parseSpec(name, spec string) (*dep, string) {
+	spec = strings.TrimSpace(spec)
+
+	switch {
+	case strings.HasPrefix(spec, aliasPrefix):
+		// The spec of an alias is like npm:@scope/name@range, the range being optional
+		target := strings.TrimPrefix(spec, aliasPrefix)
+		targetName, targetSpec := target, ""
+		// The names of the scoped packages start with an @ too
+		start := 0
+		if strings.HasPrefix(target, "@") {
+			start = 1
+		}
+		if i := strings.Index(target[start:], "@"); i >= 0 {
+			targetName, targetSpec = target[:start+i], target[start+i+1:]
+		}
+		if targetName == "" || strings.HasPrefix(targetSpec, aliasPrefix) {
+			return nil, reasonInvalid
+		}
+		ret, reason := parseSpec(targetName, targetSpec)
+		if ret != nil {
+			ret.alias = name
+		}
+
+		return ret, reason
+	case strings.HasPrefix(spec, workspacePrefix):
+		return nil, reasonWorkspace
+	case strings.HasPrefix(spec, "file:"), strings.HasPrefix(spec, "link:"),
+		strings.HasPrefix(spec, "./"), strings.HasPrefix(spec, "../"), strings.HasPrefix(spec, "/"), strings.HasPrefix(spec, "~/"):
+		return nil, reasonLocal
+	case strings.HasPrefix(spec, "git+"), strings.HasPrefix(spec, "git://"), gitHostedRe.MatchString(spec):
+		return nil, reasonGit
+	case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+		return nil, reasonTarball
+	}
+
+	// An empty spec means any version, like the star does
+	if spec == "" {
+		spec = "*"
+	}
+	if constraints, err := semver.NewConstraint(spec); err == nil {
+		return &dep{name: name, constraints: constraints}, ""
+	}
+	if distTagRe.MatchString(spec) {
+		return &dep{name: name, tag: spec}, ""
+	}
+
+	return nil, reasonInvalid
+}
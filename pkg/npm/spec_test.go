@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpec(t *testing.T) {
+	cases := []struct {
+		des        string
+		name       string
+		spec       string
+		wantName   string
+		wantAlias  string
+		wantTag    string
+		wantRange  string
+		wantReason string
+	}{
+		{des: "range", name: "react", spec: "^17.0.2", wantName: "react", wantRange: "^17.0.2"},
+		{des: "exact version", name: "react", spec: "18.2.0", wantName: "react", wantRange: "18.2.0"},
+		{des: "empty", name: "react", spec: "", wantName: "react", wantRange: "*"},
+		{des: "distribution tag", name: "lodash", spec: "latest", wantName: "lodash", wantTag: "latest"},
+		{des: "alias", name: "string-width-cjs", spec: "npm:string-width@^4.2.0", wantName: "string-width", wantAlias: "string-width-cjs", wantRange: "^4.2.0"},
+		{des: "scoped alias", name: "vue-reactivity", spec: "npm:@vue/reactivity@3.2.47", wantName: "@vue/reactivity", wantAlias: "vue-reactivity", wantRange: "3.2.47"},
+		{des: "alias without range", name: "foo", spec: "npm:@scope/bar", wantName: "@scope/bar", wantAlias: "foo", wantRange: "*"},
+		{des: "alias of a tag", name: "foo", spec: "npm:bar@next", wantName: "bar", wantAlias: "foo", wantTag: "next"},
+		{des: "alias of an alias", name: "foo", spec: "npm:bar@npm:baz@1", wantReason: reasonInvalid},
+		{des: "workspace", name: "@monorepo/b", spec: "workspace:^", wantReason: reasonWorkspace},
+		{des: "file", name: "dyl", spec: "file:.../dyl", wantReason: reasonLocal},
+		{des: "link", name: "dyl", spec: "link:../dyl", wantReason: reasonLocal},
+		{des: "relative path", name: "dyl", spec: "../dyl", wantReason: reasonLocal},
+		{des: "git URL", name: "cli", spec: "git+ssh://git@github.com:npm/cli#semver:^5.0", wantReason: reasonGit},
+		{des: "git protocol", name: "cli", spec: "git://github.com/npm/cli.git#v1.0.27", wantReason: reasonGit},
+		{des: "github shortcut", name: "express", spec: "expressjs/express", wantReason: reasonGit},
+		{des: "github prefix", name: "express", spec: "github:expressjs/express#4.x", wantReason: reasonGit},
+		{des: "tarball URL", name: "asd", spec: "http://asdf.com/asfg.tar.gz", wantReason: reasonTarball},
+		{des: "invalid", name: "asd", spec: "not a valid spec", wantReason: reasonInvalid},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.des, func(t *testing.T) {
+			res, reason := parseSpec(tc.name, tc.spec)
+			assert.Equal(t, tc.wantReason, reason)
+			if tc.wantReason != "" {
+				assert.Nil(t, res)
+
+				return
+			}
+			require.NotNil(t, res)
+			assert.Equal(t, tc.wantName, res.name)
+			assert.Equal(t, tc.wantAlias, res.alias)
+			assert.Equal(t, tc.wantTag, res.tag)
+			if tc.wantRange != "" {
+				require.NotNil(t, res.constraints)
+				assert.Equal(t, tc.wantRange, res.constraints.String())
+			} else {
+				assert.Nil(t, res.constraints)
+			}
+		})
+	}
+}
+
+func TestGetDistTagFromRegistryResponse(t *testing.T) {
+	body := io.NopCloser(strings.NewReader(`{"name": "lodash", "dist-tags": {"latest": "4.17.21", "next": "5.0.0-beta.1"}}`))
+	v, err := GetDistTagFromRegistryResponse(body, "next")
+	require.Nil(t, err)
+	assert.Equal(t, "5.0.0-beta.1", v.String())
+
+	body = io.NopCloser(strings.NewReader(`{"name": "lodash", "dist-tags": {"latest": "4.17.21"}}`))
+	_, err = GetDistTagFromRegistryResponse(body, "canary")
+	if assert.Error(t, err) {
+		assert.Equal(t, "package lodash has no canary distribution tag", err.Error())
+	}
+}
@@ -0,0 +1,357 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// lockedPackage represents a package version pinned by a lock file
+// which is not a package-lock.json (eg., yarn.lock).
+type lockedPackage struct {
+	// name is the name the package gets installed as
+	name string
+	// realName is the actual name of the package when it gets installed under an alias
+	realName             string
+	version              string
+	resolved             string
+	integrity            string
+	dev                  bool
+	optional             bool
+	dependencies         map[string]string
+	optionalDependencies map[string]string
+}
+
+// lockedImporter represents a project (or one of its workspaces) depending on locked packages.
+type lockedImporter struct {
+	// path is the directory of the importer relative to the root project (empty for the root one)
+	path string
+	// deps maps the names of the direct dependencies of the importer to their specifiers
+	deps map[string]string
+}
+
+// lockedPackageResolver finds the locked package satisfying
+// the dependency specifier of the input (dependant) locked package.
+type lockedPackageResolver func(from *lockedPackage, name, specifier string) *lockedPackage
+
+type packageLockEntry struct {
+	Name                 string            `json:"name,omitempty"`
+	Version              string            `json:"version,omitempty"`
+	Resolved             string            `json:"resolved,omitempty"`
+	Integrity            string            `json:"integrity,omitempty"`
+	Dev                  bool              `json:"dev,omitempty"`
+	Optional             bool              `json:"optional,omitempty"`
+	Dependencies         map[string]string `json:"dependencies,omitempty"`
+	OptionalDependencies map[string]string `json:"optionalDependencies,omitempty"`
+}
+
+// convertedLock holds the package-lock.json equivalent to a lock file of another format.
+type convertedLock struct {
+	lock *packageLockJSON
+}
+
+// Deps gets you the dependencies of the package-lock.json equivalent to the lock file.
+func (c *convertedLock) Deps() map[string]PackageLockDependency {
+	if c.lock == nil {
+		return nil
+	}
+
+	return c.lock.Deps()
+}
+
+// Encode encodes the package-lock.json equivalent to the lock file in a base64 string.
+//
+// Notice the npm analysis endpoint only understands the package-lock.json format.
+func (c *convertedLock) Encode() string {
+	if c.lock == nil {
+		return ""
+	}
+
+	return base64.StdEncoding.EncodeToString(c.lock.bytes)
+}
+
+func (c *convertedLock) Ok() bool {
+	return c.lock != nil && c.lock.Ok()
+}
+
+// maxNesting limits how deep packages can get nested into node_modules directories.
+//
+// It only guards against pathological dependency cycles.
+const maxNesting = 64
+
+func (p *lockedPackage) deps() map[string]string {
+	ret := make(map[string]string, len(p.dependencies)+len(p.optionalDependencies))
+	for name, spec := range p.dependencies {
+		ret[name] = spec
+	}
+	for name, spec := range p.optionalDependencies {
+		ret[name] = spec
+	}
+
+	return ret
+}
+
+func (p *lockedPackage) entry() packageLockEntry {
+	return packageLockEntry{
+		Name:                 p.realName,
+		Version:              p.version,
+		Resolved:             p.resolved,
+		Integrity:            p.integrity,
+		Dev:                  p.dev,
+		Optional:             p.optional,
+		Dependencies:         p.dependencies,
+		OptionalDependencies: p.optionalDependencies,
+	}
+}
+
+// higherVersion tells whether the version of a is higher than the version of b.
+//
+// It falls back to comparing the version strings when they are not semantic versions.
+func higherVersion(a, b *lockedPackage) bool {
+	va, errA := semver.NewVersion(a.version)
+	vb, errB := semver.NewVersion(b.version)
+	if errA != nil || errB != nil {
+		return a.version > b.version
+	}
+
+	return va.GreaterThan(vb)
+}
+
+func parentPath(p string) string {
+	i := strings.LastIndex(p, "/node_modules/")
+	if i < 0 {
+		return ""
+	}
+
+	return p[:i]
+}
+
+func childPath(p, name string) string {
+	if p == "" {
+		return "node_modules/" + name
+	}
+
+	return p + "/node_modules/" + name
+}
+
+// markDev marks as development packages the ones only reachable from the dev input packages.
+//
+// The next function returns the packages the input package depends on.
+func markDev(prod, dev []*lockedPackage, next func(*lockedPackage) []*lockedPackage) {
+	visit := func(queue []*lockedPackage) map[*lockedPackage]bool {
+		seen := map[*lockedPackage]bool{}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			if current == nil || seen[current] {
+				continue
+			}
+			seen[current] = true
+			queue = append(queue, next(current)...)
+		}
+
+		return seen
+	}
+
+	reachable := visit(prod)
+	for pkg := range visit(dev) {
+		if !reachable[pkg] {
+			pkg.dev = true
+		}
+	}
+}
+
+// lookup finds the package the input node_modules path gets when requiring the input name.
+//
+// It walks up the tree like node does.
+func lookup(tree map[string]*lockedPackage, from, name string) *lockedPackage {
+	for anc := from; ; anc = parentPath(anc) {
+		if t, ok := tree[childPath(anc, name)]; ok {
+			return t
+		}
+		if anc == "" {
+			return nil
+		}
+	}
+}
+
+// hoist lays out the input locked packages into a node_modules tree.
+//
+// It mimics what npm does: for every package name it puts one version at the top level
+// while it nests the other ones into the node_modules directory of their dependants.
+// The version landing at the top level is the one the root importer depends on,
+// or the one that no other package depends on (so likely a direct dependency),
+// or the one most packages depend on, or the highest one.
+// The versions the other importers (ie., the workspaces) depend on
+// get nested into the node_modules directory of the importer when they differ from the top level one.
+//
+// It returns the mapping from the node_modules paths to the locked packages.
+func hoist(pkgs []*lockedPackage, importers []lockedImporter, resolve lockedPackageResolver) map[string]*lockedPackage {
+	dependants := map[*lockedPackage]int{}
+	for _, p := range pkgs {
+		for name, spec := range p.deps() {
+			if t := resolve(p, name, spec); t != nil && t != p {
+				dependants[t]++
+			}
+		}
+	}
+
+	direct := map[*lockedPackage]bool{}
+	for _, i := range importers {
+		if i.path != "" {
+			continue
+		}
+		for name, spec := range i.deps {
+			if t := resolve(nil, name, spec); t != nil {
+				direct[t] = true
+			}
+		}
+	}
+
+	byName := map[string][]*lockedPackage{}
+	for _, p := range pkgs {
+		byName[p.name] = append(byName[p.name], p)
+	}
+
+	tree := map[string]*lockedPackage{}
+	queue := []string{}
+	for name, candidates := range byName {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			a, b := candidates[i], candidates[j]
+			if direct[a] != direct[b] {
+				return direct[a]
+			}
+			if (dependants[a] == 0) != (dependants[b] == 0) {
+				return dependants[a] == 0
+			}
+			if dependants[a] != dependants[b] {
+				return dependants[a] > dependants[b]
+			}
+
+			return higherVersion(a, b)
+		})
+		p := childPath("", name)
+		tree[p] = candidates[0]
+		queue = append(queue, p)
+	}
+	sort.Strings(queue)
+
+	sort.SliceStable(importers, func(i, j int) bool {
+		return importers[i].path < importers[j].path
+	})
+	for _, i := range importers {
+		if i.path == "" {
+			continue
+		}
+		names := make([]string, 0, len(i.deps))
+		for name := range i.deps {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			target := resolve(nil, name, i.deps[name])
+			if target == nil || lookup(tree, i.path, name) == target {
+				continue
+			}
+			nested := childPath(i.path, name)
+			tree[nested] = target
+			queue = append(queue, nested)
+		}
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if strings.Count(current, "node_modules/") >= maxNesting {
+			continue
+		}
+
+		p := tree[current]
+		deps := p.deps()
+		names := make([]string, 0, len(deps))
+		for name := range deps {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			target := resolve(p, name, deps[name])
+			if target == nil {
+				continue
+			}
+			if lookup(tree, current, name) == target {
+				continue
+			}
+			nested := childPath(current, name)
+			tree[nested] = target
+			queue = append(queue, nested)
+		}
+	}
+
+	return tree
+}
+
+// newPackageLockJSONFromLocked creates a package-lock.json (lockfile version 3)
+// equivalent to the input locked packages the input importers depend on.
+func newPackageLockJSONFromLocked(name, version string, pkgs []*lockedPackage, importers []lockedImporter, resolve lockedPackageResolver) (*packageLockJSON, error) {
+	return newPackageLockJSONFromTree(name, version, hoist(pkgs, importers, resolve))
+}
+
+// newPackageLockJSONFromTree creates a package-lock.json (lockfile version 3)
+// from the input mapping of node_modules paths to locked packages.
+func newPackageLockJSONFromTree(name, version string, tree map[string]*lockedPackage) (*packageLockJSON, error) {
+	packages := map[string]packageLockEntry{
+		"": {
+			Name:    name,
+			Version: version,
+		},
+	}
+	for p, pkg := range tree {
+		packages[p] = pkg.entry()
+	}
+
+	b, err := json.Marshal(struct {
+		Name            string                      `json:"name,omitempty"`
+		Version         string                      `json:"version,omitempty"`
+		LockfileVersion int                         `json:"lockfileVersion"`
+		Requires        bool                        `json:"requires"`
+		Packages        map[string]packageLockEntry `json:"packages"`
+	}{
+		Name:            name,
+		Version:         version,
+		LockfileVersion: 3,
+		Requires:        true,
+		Packages:        packages,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &packageLockJSON{}
+	if err := json.Unmarshal(b, ret); err != nil {
+		return nil, err
+	}
+	ret.bytes = b
+
+	return ret, nil
+}
@@ -18,6 +18,9 @@ package npm
 import (
 	"bytes"
 	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -26,31 +29,51 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/listendev/lstn/pkg/fs"
 	listentype "github.com/listendev/lstn/pkg/listen/type"
+	"github.com/listendev/lstn/pkg/lockfile"
 	npmdeptype "github.com/listendev/lstn/pkg/npm/deptype"
 	"github.com/listendev/lstn/pkg/validate"
-	"github.com/listendev/pkg/lockfile"
 	"github.com/listendev/pkg/manifest"
 )
 
 var _ PackageLockJSON = (*packageLockJSON)(nil)
 
+var _ PackageLockJSON = (*yarnLock)(nil)
+
+var _ PackageLockJSON = (*pnpmLock)(nil)
+
+var _ PackageLockJSON = (*bunLock)(nil)
+
 type packageJSON struct {
+	Name                 string            `json:"name"`
 	Dependencies         map[string]string `json:"dependencies"`
 	DevDependencies      map[string]string `json:"devDependencies"`
 	PeerDependencies     map[string]string `json:"peerDependencies"`
 	BundleDependencies   []string          `json:"bundleDependencies"`
 	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	Overrides            map[string]any    `json:"overrides"`
+	// Workspaces is either a list of glob patterns or an object with a packages list of glob patterns
+	WorkspaceGlobs json.RawMessage `json:"workspaces"`
+
+	// dir is the directory of the package.json relative to the root one
+	dir string
+	// root is the package.json of the project the workspace belongs to (nil for the root one)
+	root *packageJSON
+	// workspaces are the package.json of the workspaces of the root one
+	workspaces []*packageJSON
 }
 
 type PackageJSON interface {
 	FilterOutByTypes(...npmdeptype.Enum)
 	FilterOutByNames(...string)
-	Deps(context.Context, VersionResolutionStrategy) map[npmdeptype.Enum]map[string]*semver.Version
+	Deps(context.Context, VersionResolutionStrategy) (map[npmdeptype.Enum]map[string]*semver.Version, []*ResolutionError)
+	Unresolvable() []UnresolvableDependency
+	Workspaces() []PackageJSON
+	Path() string
 }
 
-// The VersionResolutionStrategy is a function that, given a version constraints,
-// returns back an exact version.
-type VersionResolutionStrategy func(semver.Collection) *semver.Version
+// The VersionResolutionStrategy is a function that, given the name of a package
+// and its versions satisfying some constraints, returns back an exact version.
+type VersionResolutionStrategy func(string, semver.Collection) *semver.Version
 
 type LockfileVersion struct {
 	Value int `json:"lockfileVersion" name:"lockfile version" validate:"gte=1,lte=3"`
@@ -90,12 +113,20 @@ func (p *packageLockJSON) UnmarshalJSON(data []byte) error {
 	switch p.Value {
 	case 1:
 		p.packageLockJSONVersion1 = &packageLockJSONVersion1{}
+		if err := json.Unmarshal(data, p.packageLockJSONVersion1); err != nil {
+			return err
+		}
+		p.packageLockJSONVersion1.Dependencies = map[string]PackageLockDependency{}
 
-		return json.Unmarshal(data, p.packageLockJSONVersion1)
+		return unmarshalNested(data, p.packageLockJSONVersion1.Dependencies)
 	case 2:
 		p.packageLockJSONVersion2 = &packageLockJSONVersion2{}
+		if err := json.Unmarshal(data, p.packageLockJSONVersion2); err != nil {
+			return err
+		}
+		p.packageLockJSONVersion2.Dependencies = map[string]PackageLockDependency{}
 
-		return json.Unmarshal(data, p.packageLockJSONVersion2)
+		return unmarshalNested(data, p.packageLockJSONVersion2.Dependencies)
 	case 3:
 		p.packageLockJSONVersion3 = &packageLockJSONVersion3{}
 		if err := json.Unmarshal(data, p.packageLockJSONVersion3); err != nil {
@@ -118,15 +149,72 @@ func (p *packageLockJSON) UnmarshalJSON(data []byte) error {
 	}
 }
 
+// nestedPackageLockDependency is a dependency of a version 1 or 2 package-lock.json,
+// along with the dependencies nested into its node_modules directory.
+type nestedPackageLockDependency struct {
+	PackageLockDependency
+	Dependencies map[string]nestedPackageLockDependency `json:"dependencies"`
+}
+
+// unmarshalNested flattens the nested dependencies of a version 1 or 2 package-lock.json
+// into the input dependencies, keying them by their node_modules path like version 3 does (eg., foo/node_modules/bar).
+func unmarshalNested(data []byte, deps map[string]PackageLockDependency) error {
+	lock := struct {
+		Dependencies map[string]nestedPackageLockDependency `json:"dependencies"`
+	}{}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return err
+	}
+
+	var flatten func(prefix string, nested map[string]nestedPackageLockDependency)
+	flatten = func(prefix string, nested map[string]nestedPackageLockDependency) {
+		for name, dep := range nested {
+			deps[prefix+name] = dep.PackageLockDependency
+			flatten(prefix+name+"/node_modules/", dep.Dependencies)
+		}
+	}
+	flatten("", lock.Dependencies)
+
+	return nil
+}
+
 type PackageLockJSON interface {
 	listentype.AnalysisRequester
 	Deps() map[string]PackageLockDependency
 	Version() int
 }
 
+// PackageLockDependency is a dependency of a package-lock.json.
+//
+// The lock files key them by their node_modules path (eg., foo/node_modules/bar), without the top-level node_modules directory.
 type PackageLockDependency struct {
-	Version  string `json:"version"`
-	Resolved string `json:"resolved"`
+	// Name is the actual name of the package when it gets installed under an alias (version 3 only)
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Resolved  string `json:"resolved"`
+	Integrity string `json:"integrity"`
+	Link      bool   `json:"link"`
+}
+
+// Shasum returns the hex SHA1 digest in the integrity of the locked dependency.
+//
+// It returns an empty string when the integrity does not contain a SHA1 digest.
+func (d PackageLockDependency) Shasum() string {
+	// The integrity is a Subresource Integrity string (eg., sha1-<base64> sha512-<base64>)
+	for _, sri := range strings.Fields(d.Integrity) {
+		algo, digest, found := strings.Cut(sri, "-")
+		if !found || algo != "sha1" {
+			continue
+		}
+		b, err := base64.StdEncoding.DecodeString(digest)
+		if err != nil || len(b) != sha1.Size {
+			continue
+		}
+
+		return hex.EncodeToString(b)
+	}
+
+	return ""
 }
 
 type Package struct {
@@ -186,11 +274,22 @@ func NewPackageLockJSONFromDir(ctx context.Context, dir string) (PackageLockJSON
 
 // NewPackageLockJSONFromReader creates a PackageLockJSON instance from by reading the contents of a package-lock.json file.
 func NewPackageLockJSONFromReader(reader io.Reader) (PackageLockJSON, error) {
+	return newPackageLockJSONFromReader(reader, lockfile.PackageLockJSON)
+}
+
+// NewNpmShrinkwrapJSONFromReader creates a PackageLockJSON instance from by reading the contents of a npm-shrinkwrap.json file.
+//
+// The npm-shrinkwrap.json files have the same format of the package-lock.json ones.
+func NewNpmShrinkwrapJSONFromReader(reader io.Reader) (PackageLockJSON, error) {
+	return newPackageLockJSONFromReader(reader, lockfile.NpmShrinkwrapJSON)
+}
+
+func newPackageLockJSONFromReader(reader io.Reader, lf lockfile.Lockfile) (PackageLockJSON, error) {
 	ret := &packageLockJSON{}
 	var b bytes.Buffer
 	r := io.TeeReader(reader, &b)
 	if err := json.NewDecoder(r).Decode(ret); err != nil {
-		return nil, fmt.Errorf("couldn't decode from the input %s contents", lockfile.PackageLockJSON.String())
+		return nil, fmt.Errorf("couldn't decode from the input %s contents", lf.String())
 	}
 	ret.bytes = b.Bytes()
 
@@ -217,17 +316,152 @@ func GetPackageLockJSONFromDir(dir string) (PackageLockJSON, error) {
 	return NewPackageLockJSONFromReader(reader)
 }
 
+// NewYarnLockFromReader creates a PackageLockJSON instance from by reading the contents of a yarn.lock file.
+func NewYarnLockFromReader(reader io.Reader) (PackageLockJSON, error) {
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the input %s contents", lockfile.YarnLock.String())
+	}
+
+	return NewYarnLockFromBytes(b)
+}
+
+func NewYarnLockFromBytes(b []byte) (PackageLockJSON, error) {
+	return newYarnLockFromBytes(b, nil)
+}
+
+func newYarnLockFromBytes(b []byte, importers []lockedImporter) (PackageLockJSON, error) {
+	ret, err := newYarnLock(b, importers)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode from the input %s contents: %w", lockfile.YarnLock.String(), err)
+	}
+
+	return ret, nil
+}
+
+// GetYarnLockFromDir creates a PackageLockJSON instance from the existing yarn.lock in dir, if any.
+//
+// It uses the package.json in dir, if any, to lay out the packages the workspaces depend on.
+func GetYarnLockFromDir(dir string) (PackageLockJSON, error) {
+	reader, err := fs.Read(dir, lockfile.YarnLock.String())
+	if err != nil {
+		return nil, err
+	}
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the input %s contents", lockfile.YarnLock.String())
+	}
+
+	var importers []lockedImporter
+	if p, err := readPackageJSON(dir); err == nil && p.loadWorkspaces(dir) == nil {
+		importers = p.importers()
+	}
+
+	return newYarnLockFromBytes(b, importers)
+}
+
+// NewPnpmLockFromReader creates a PackageLockJSON instance from by reading the contents of a pnpm-lock.yaml file.
+func NewPnpmLockFromReader(reader io.Reader) (PackageLockJSON, error) {
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the input %s contents", lockfile.PnpmLock.String())
+	}
+
+	return NewPnpmLockFromBytes(b)
+}
+
+func NewPnpmLockFromBytes(b []byte) (PackageLockJSON, error) {
+	ret, err := newPnpmLock(b)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode from the input %s contents: %w", lockfile.PnpmLock.String(), err)
+	}
+
+	return ret, nil
+}
+
+// GetPnpmLockFromDir creates a PackageLockJSON instance from the existing pnpm-lock.yaml in dir, if any.
+func GetPnpmLockFromDir(dir string) (PackageLockJSON, error) {
+	reader, err := fs.Read(dir, lockfile.PnpmLock.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPnpmLockFromReader(reader)
+}
+
+// GetNpmShrinkwrapJSONFromDir creates a PackageLockJSON instance from the existing npm-shrinkwrap.json in dir, if any.
+func GetNpmShrinkwrapJSONFromDir(dir string) (PackageLockJSON, error) {
+	reader, err := fs.Read(dir, lockfile.NpmShrinkwrapJSON.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNpmShrinkwrapJSONFromReader(reader)
+}
+
+// NewBunLockFromReader creates a PackageLockJSON instance from by reading the contents of a bun.lock file.
+func NewBunLockFromReader(reader io.Reader) (PackageLockJSON, error) {
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the input %s contents", lockfile.BunLock.String())
+	}
+
+	return NewBunLockFromBytes(b)
+}
+
+func NewBunLockFromBytes(b []byte) (PackageLockJSON, error) {
+	ret, err := newBunLock(b)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode from the input %s contents: %w", lockfile.BunLock.String(), err)
+	}
+
+	return ret, nil
+}
+
+// GetBunLockFromDir creates a PackageLockJSON instance from the existing bun.lock in dir, if any.
+func GetBunLockFromDir(dir string) (PackageLockJSON, error) {
+	reader, err := fs.Read(dir, lockfile.BunLock.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBunLockFromReader(reader)
+}
+
 // GetPackageJSONFromDir creates a PackageJSON instance from the existing package.json in dir, if any.
+//
+// It also reads the package.json of the workspaces it declares, recursively.
 func GetPackageJSONFromDir(dir string) (PackageJSON, error) {
+	ret, err := readPackageJSON(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := ret.loadWorkspaces(dir); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func readPackageJSON(dir string) (*packageJSON, error) {
 	reader, err := fs.Read(dir, manifest.PackageJSON.String())
 	if err != nil {
 		return nil, err
 	}
 
-	return NewPackageJSONFromReader(reader)
+	return newPackageJSONFromReader(reader)
 }
 
 func NewPackageJSONFromReader(reader io.Reader) (PackageJSON, error) {
+	ret, err := newPackageJSONFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func newPackageJSONFromReader(reader io.Reader) (*packageJSON, error) {
 	ret := &packageJSON{}
 	if err := json.NewDecoder(reader).Decode(ret); err != nil {
 		return nil, fmt.Errorf("couldn't instantiate from the input %s contents", manifest.PackageJSON.String())
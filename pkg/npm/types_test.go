@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -279,6 +280,7 @@ func TestNewPackageJSONFromReader(t *testing.T) {
 	]
 }`),
 			output: &packageJSON{
+				Name: "xxx",
 				Dependencies: map[string]string{
 					"@isaacs/import-jsx":       "^4.0.1",
 					"@types/react":             "^17.0.52",
@@ -364,3 +366,18 @@ func TestNewPackageLockJSONFromReader(t *testing.T) {
 
 	assert.Equal(t, b.Bytes(), lockFromDir.(*packageLockJSON).bytes)
 }
+
+func TestNewNpmShrinkwrapJSONFromReader(t *testing.T) {
+	dir, err := filepath.Abs("testdata")
+	require.Nil(t, err)
+	lock, err := GetNpmShrinkwrapJSONFromDir(dir)
+	require.Nil(t, err)
+	require.IsType(t, &packageLockJSON{}, lock)
+	assert.True(t, lock.Ok())
+
+	invalid, err := NewNpmShrinkwrapJSONFromReader(strings.NewReader("{"))
+	assert.Nil(t, invalid)
+	if assert.Error(t, err) {
+		assert.Equal(t, "couldn't decode from the input npm-shrinkwrap.json contents", err.Error())
+	}
+}
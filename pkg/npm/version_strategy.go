@@ -22,7 +22,7 @@ import (
 )
 
 // DefaultVersionResolutionStrategy returns the highest semantic version in a collection of versions.
-func DefaultVersionResolutionStrategy(versions semver.Collection) *semver.Version {
+func DefaultVersionResolutionStrategy(_ string, versions semver.Collection) *semver.Version {
 	sort.Sort(versions)
 
 	if versions.Len() == 0 {
@@ -31,3 +31,34 @@ func DefaultVersionResolutionStrategy(versions semver.Collection) *semver.Versio
 
 	return versions[versions.Len()-1]
 }
+
+// LowestVersionResolutionStrategy returns the lowest semantic version in a collection of versions.
+func LowestVersionResolutionStrategy(_ string, versions semver.Collection) *semver.Version {
+	sort.Sort(versions)
+
+	if versions.Len() == 0 {
+		return nil
+	}
+
+	return versions[0]
+}
+
+// LockfileVersionResolutionStrategy returns a strategy that picks the version a lock file pins for a package,
+// when it is in the collection of versions (ie., it satisfies the version constraints).
+//
+// Otherwise, it falls back to the input strategy.
+func LockfileVersionResolutionStrategy(deps map[string]PackageLockDependency, fallback VersionResolutionStrategy) VersionResolutionStrategy {
+	return func(name string, versions semver.Collection) *semver.Version {
+		if dep, ok := deps[name]; ok {
+			if locked, err := semver.NewVersion(dep.Version); err == nil {
+				for _, v := range versions {
+					if v.Equal(locked) {
+						return v
+					}
+				}
+			}
+		}
+
+		return fallback(name, versions)
+	}
+}
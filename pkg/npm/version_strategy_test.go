@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getCollection(t *testing.T, versions ...string) semver.Collection {
+	t.Helper()
+
+	ret := semver.Collection{}
+	for _, v := range versions {
+		ver, err := semver.NewVersion(v)
+		require.Nil(t, err)
+		ret = append(ret, ver)
+	}
+
+	return ret
+}
+
+func TestVersionResolutionStrategies(t *testing.T) {
+	assert.Equal(t, "2.0.0", DefaultVersionResolutionStrategy("pkg", getCollection(t, "1.2.0", "2.0.0", "1.0.0")).String())
+	assert.Equal(t, "1.0.0", LowestVersionResolutionStrategy("pkg", getCollection(t, "1.2.0", "2.0.0", "1.0.0")).String())
+	assert.Nil(t, DefaultVersionResolutionStrategy("pkg", semver.Collection{}))
+	assert.Nil(t, LowestVersionResolutionStrategy("pkg", semver.Collection{}))
+}
+
+func TestLockfileVersionResolutionStrategy(t *testing.T) {
+	resolve := LockfileVersionResolutionStrategy(map[string]PackageLockDependency{
+		"react":             {Version: "18.2.0"},
+		"react-dom":         {Version: "17.0.2"},
+		"node_modules/glob": {Version: "7.2.3"},
+	}, LowestVersionResolutionStrategy)
+
+	// The locked version
+	assert.Equal(t, "18.2.0", resolve("react", getCollection(t, "18.0.0", "18.2.0", "18.3.1")).String())
+	// The locked version does not satisfy the constraints: fallback
+	assert.Equal(t, "18.0.0", resolve("react-dom", getCollection(t, "18.0.0", "18.3.1")).String())
+	// The package is not locked: fallback
+	assert.Equal(t, "1.0.0", resolve("chalk", getCollection(t, "1.0.0", "5.3.0")).String())
+	assert.Nil(t, resolve("chalk", semver.Collection{}))
+}
+
+func TestPackageLockDependencyShasum(t *testing.T) {
+	cases := map[string]string{
+		"sha1-tGhzbR9KWJHzhYW6jo+yn5HDy5Y=": "b468736d1f4a5891f38585ba8e8fb29f91c3cb96",
+		"sha512-MRgo/Xw8kJtkFBbX2HbaNTEUjJaa4apHBX8Wbzh2KJAY3ckkcgGCmYpnixArvGEpz6+NrbDlgqZtKqBDJaKnjA== sha1-tGhzbR9KWJHzhYW6jo+yn5HDy5Y=": "b468736d1f4a5891f38585ba8e8fb29f91c3cb96",
+		"sha512-MRgo/Xw8kJtkFBbX2HbaNTEUjJaa4apHBX8Wbzh2KJAY3ckkcgGCmYpnixArvGEpz6+NrbDlgqZtKqBDJaKnjA==":                                   "",
+		"sha1-notbase64!": "",
+		"":                "",
+	}
+
+	for integrity, want := range cases {
+		assert.Equal(t, want, PackageLockDependency{Integrity: integrity}.Shasum(), integrity)
+	}
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/listendev/pkg/manifest"
+)
+
+// Path returns the path of the package.json relative to the root one.
+func (p *packageJSON) Path() string {
+	return filepath.Join(p.dir, manifest.PackageJSON.String())
+}
+
+// Workspaces returns the package.json of the workspaces, recursively.
+//
+// It returns nothing for the package.json of a workspace.
+func (p *packageJSON) Workspaces() []PackageJSON {
+	ret := make([]PackageJSON, 0, len(p.workspaces))
+	for _, w := range p.workspaces {
+		ret = append(ret, w)
+	}
+
+	return ret
+}
+
+// workspacePatterns returns the glob patterns of the workspaces field.
+func (p *packageJSON) workspacePatterns() ([]string, error) {
+	if len(p.WorkspaceGlobs) == 0 {
+		return []string{}, nil
+	}
+
+	patterns := []string{}
+	if err := json.Unmarshal(p.WorkspaceGlobs, &patterns); err == nil {
+		return patterns, nil
+	}
+	// Yarn also supports an object with the list of patterns in its packages field
+	object := struct {
+		Packages []string `json:"packages"`
+	}{}
+	if err := json.Unmarshal(p.WorkspaceGlobs, &object); err != nil {
+		return nil, fmt.Errorf("couldn't decode the workspaces in %s", p.Path())
+	}
+
+	return object.Packages, nil
+}
+
+// loadWorkspaces reads the package.json of the workspaces matching the glob patterns in the workspaces field,
+// and the ones of the workspaces they declare in turn.
+func (p *packageJSON) loadWorkspaces(rootDir string) error {
+	visited := map[string]bool{filepath.Clean(rootDir): true}
+
+	var load func(current *packageJSON) error
+	load = func(current *packageJSON) error {
+		patterns, err := current.workspacePatterns()
+		if err != nil {
+			return err
+		}
+
+		matches := []string{}
+		excluded := map[string]bool{}
+		for _, pattern := range patterns {
+			negated := strings.HasPrefix(pattern, "!")
+			found, err := filepath.Glob(filepath.Join(rootDir, current.dir, strings.TrimPrefix(pattern, "!")))
+			if err != nil {
+				return fmt.Errorf("invalid workspace pattern %s in %s", pattern, current.Path())
+			}
+			for _, f := range found {
+				if negated {
+					excluded[filepath.Clean(f)] = true
+				} else {
+					matches = append(matches, filepath.Clean(f))
+				}
+			}
+		}
+
+		for _, m := range matches {
+			if visited[m] || excluded[m] {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(m, manifest.PackageJSON.String())); err != nil {
+				continue
+			}
+			visited[m] = true
+
+			w, err := readPackageJSON(m)
+			if err != nil {
+				return fmt.Errorf("couldn't read the workspace in %s: %w", m, err)
+			}
+			w.dir, _ = filepath.Rel(rootDir, m)
+			w.root = p
+			p.workspaces = append(p.workspaces, w)
+
+			if err := load(w); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return load(p)
+}
+
+// workspaceNames returns the names of the packages in the workspaces of the project.
+func (p *packageJSON) workspaceNames() map[string]bool {
+	root := p
+	if p.root != nil {
+		root = p.root
+	}
+
+	ret := map[string]bool{}
+	for _, w := range root.workspaces {
+		if w.Name != "" {
+			ret[w.Name] = true
+		}
+	}
+
+	return ret
+}
+
+// importers returns the project and its workspaces as the importers of the locked packages.
+func (p *packageJSON) importers() []lockedImporter {
+	ret := []lockedImporter{}
+	for _, current := range append([]*packageJSON{p}, p.workspaces...) {
+		deps := map[string]string{}
+		for _, d := range []map[string]string{current.Dependencies, current.DevDependencies, current.OptionalDependencies} {
+			for name, spec := range d {
+				deps[name] = spec
+			}
+		}
+		ret = append(ret, lockedImporter{path: filepath.ToSlash(current.dir), deps: deps})
+	}
+
+	return ret
+}
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	npmdeptype "github.com/listendev/lstn/pkg/npm/deptype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getWorkspacesFromTestdata(t *testing.T) PackageJSON {
+	t.Helper()
+
+	dir, err := filepath.Abs("testdata/workspaces")
+	require.Nil(t, err)
+	ret, err := GetPackageJSONFromDir(dir)
+	require.Nil(t, err)
+
+	return ret
+}
+
+func TestGetPackageJSONFromDirWithWorkspaces(t *testing.T) {
+	p := getWorkspacesFromTestdata(t)
+
+	paths := []string{p.Path()}
+	for _, w := range p.Workspaces() {
+		paths = append(paths, w.Path())
+		assert.Empty(t, w.Workspaces())
+	}
+	assert.Equal(t, []string{
+		"package.json",
+		"packages/a/package.json",
+		"packages/b/package.json",
+		"packages/b/nested/package.json",
+	}, paths)
+}
+
+func TestUnresolvable(t *testing.T) {
+	p := getWorkspacesFromTestdata(t)
+
+	assert.Equal(t, []UnresolvableDependency{
+		{Name: "cli", Spec: "git+ssh://git@github.com:npm/cli#semver:^5.0", Type: npmdeptype.Dependencies, Source: "package.json", Reason: reasonGit},
+		{Name: "invalid", Spec: "not a valid spec", Type: npmdeptype.Dependencies, Source: "package.json", Reason: reasonInvalid},
+		{Name: "local", Spec: "file:../local", Type: npmdeptype.Dependencies, Source: "package.json", Reason: reasonLocal},
+		{Name: "tarball", Spec: "https://example.com/tarball.tgz", Type: npmdeptype.Dependencies, Source: "package.json", Reason: reasonTarball},
+		{Name: "@monorepo/b", Spec: "workspace:*", Type: npmdeptype.DevDependencies, Source: "package.json", Reason: reasonWorkspace},
+	}, p.Unresolvable())
+
+	workspaces := p.Workspaces()
+	assert.Equal(t, []UnresolvableDependency{
+		{Name: "@monorepo/b", Spec: "^1.0.0", Type: npmdeptype.Dependencies, Source: "packages/a/package.json", Reason: reasonWorkspace},
+	}, workspaces[0].Unresolvable())
+	assert.Empty(t, workspaces[1].Unresolvable())
+
+	bundled := &packageJSON{
+		Dependencies:       map[string]string{"ink": "^3.2.0"},
+		BundleDependencies: []string{"ink", "treport"},
+	}
+	assert.Equal(t, []UnresolvableDependency{
+		{Name: "treport", Spec: "", Type: npmdeptype.BundleDependencies, Source: "package.json", Reason: reasonBundled},
+	}, bundled.Unresolvable())
+}
+
+func TestOverrides(t *testing.T) {
+	p := getWorkspacesFromTestdata(t)
+
+	overrides := p.(*packageJSON).overrides()
+	assert.Len(t, overrides, 2)
+	assert.Equal(t, "17.0.2", overrides["react"].spec)
+	assert.Nil(t, overrides["react"].selector)
+	assert.Equal(t, "7.1.0", overrides["glob"].spec)
+	assert.Equal(t, "^7", overrides["glob"].selector.String())
+
+	// The workspaces get the overrides of the root package.json
+	assert.Equal(t, overrides, p.Workspaces()[0].(*packageJSON).overrides())
+}
+
+func TestDepsWithWorkspaces(t *testing.T) {
+	registry := map[string]string{
+		"react":        `"versions": {"17.0.1": {}, "17.0.2": {}, "18.2.0": {}}`,
+		"glob":         `"versions": {"7.1.0": {}, "7.2.3": {}, "8.1.0": {}}`,
+		"lodash":       `"versions": {"4.17.20": {}, "4.17.21": {}}, "dist-tags": {"latest": "4.17.21"}`,
+		"string-width": `"versions": {"4.2.3": {}, "5.1.2": {}}`,
+		"chalk":        `"versions": {"4.1.2": {}, "5.3.0": {}}`,
+		"typescript":   `"versions": {"5.4.5": {}, "5.5.4": {}}`,
+		"left-pad":     `"versions": {"1.3.0": {}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/")
+		doc, ok := registry[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		fmt.Fprintf(w, `{"name": "%s", %s}`, name, doc)
+	}))
+	defer server.Close()
+
+	cfg, err := flags.NewConfigFlags()
+	require.Nil(t, err)
+	cfg.Registry.NPM = server.URL
+	ctx := context.WithValue(t.Context(), pkgcontext.ConfigKey, cfg)
+
+	p := getWorkspacesFromTestdata(t)
+
+	got := map[string]map[string]string{}
+	gotErrs := []string{}
+	for _, pkg := range append([]PackageJSON{p}, p.Workspaces()...) {
+		got[pkg.Path()] = map[string]string{}
+		res, errs := pkg.Deps(ctx, DefaultVersionResolutionStrategy)
+		for _, deps := range res {
+			for name, v := range deps {
+				got[pkg.Path()][name] = v.String()
+			}
+		}
+		for _, e := range errs {
+			assert.Equal(t, npmdeptype.Dependencies, e.Type)
+			assert.Equal(t, "packages/b/nested/package.json", e.Source)
+			gotErrs = append(gotErrs, e.Error())
+		}
+	}
+
+	assert.Equal(t, map[string]map[string]string{
+		"package.json": {
+			"react":        "17.0.2",
+			"glob":         "7.1.0",
+			"lodash":       "4.17.21",
+			"string-width": "4.2.3",
+		},
+		"packages/a/package.json": {
+			"react": "17.0.2",
+			"chalk": "4.1.2",
+		},
+		"packages/b/package.json": {
+			"typescript": "5.4.5",
+		},
+		"packages/b/nested/package.json": {
+			"chalk": "5.3.0",
+		},
+	}, got)
+	assert.Equal(t, []string{
+		fmt.Sprintf("couldn't resolve ghost@^1.0.0: package ghost doesn't exist on registry %s", server.URL),
+		"couldn't resolve left-pad@^2.0.0: no version of left-pad satisfies ^2.0.0",
+	}, gotErrs)
+}
@@ -0,0 +1,248 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const yarnBerryMetadataKey = "__metadata"
+
+// yarnBerryEntry represents a package entry of a Yarn Berry (v2+) yarn.lock.
+//
+// It does not decode the checksum field on purpose: it is the digest of the zip archive
+// Yarn Berry stores into its cache (prefixed by the cache key, eg., 10c0/),
+// not the one of the tarball in the registry. So it cannot become the integrity of the package.
+type yarnBerryEntry struct {
+	Version          string                     `yaml:"version"`
+	Resolution       string                     `yaml:"resolution"`
+	Dependencies     map[string]string          `yaml:"dependencies"`
+	DependenciesMeta map[string]map[string]bool `yaml:"dependenciesMeta"`
+}
+
+// hasProtocol tells whether the input range starts with a protocol (eg., "npm:", "patch:").
+func hasProtocol(rng string) bool {
+	i := strings.Index(rng, ":")
+	if i <= 0 {
+		return false
+	}
+	for _, r := range rng[:i] {
+		if (r < 'a' || r > 'z') && r != '+' && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func protocolOf(rng string) string {
+	if !hasProtocol(rng) {
+		return ""
+	}
+
+	return rng[:strings.Index(rng, ":")]
+}
+
+// normalizeBerryRange makes the input range comparable with the descriptors in a Yarn Berry yarn.lock.
+//
+// Yarn Berry omits the default "npm:" protocol in some places and URL-encodes the patched descriptors.
+func normalizeBerryRange(rng string) string {
+	if decoded, err := url.PathUnescape(rng); err == nil {
+		rng = decoded
+	}
+	if strings.HasPrefix(rng, "patch:") {
+		source, rest, found := strings.Cut(strings.TrimPrefix(rng, "patch:"), "#")
+		name, sourceRange := splitYarnSpecifier(source)
+		ret := "patch:" + name + "@" + normalizeBerryRange(sourceRange)
+		if found {
+			ret += "#" + rest
+		}
+
+		return ret
+	}
+	if !hasProtocol(rng) {
+		return "npm:" + rng
+	}
+
+	return rng
+}
+
+// patchSource returns the descriptor of the package the input patch range applies to.
+func patchSource(rng string) string {
+	if decoded, err := url.PathUnescape(rng); err == nil {
+		rng = decoded
+	}
+	source, _, _ := strings.Cut(strings.TrimPrefix(rng, "patch:"), "#")
+
+	return source
+}
+
+func (y *yarnLock) resolveBerry(name, rng string) *lockedPackage {
+	if p := y.specifiers[name+"@"+normalizeBerryRange(rng)]; p != nil {
+		return p
+	}
+	// Fallback to the unpatched package
+	if protocolOf(rng) == "patch" {
+		sourceName, sourceRange := splitYarnSpecifier(patchSource(rng))
+		if sourceRange != "" {
+			return y.resolveBerry(sourceName, sourceRange)
+		}
+	}
+
+	return nil
+}
+
+// parseYarnBerry parses the contents of a Yarn Berry (v2+) yarn.lock file.
+func parseYarnBerry(b []byte) (*yarnLock, error) {
+	entries := map[string]yarnBerryEntry{}
+	if err := yaml.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("invalid yarn lockfile")
+	}
+	metadata, ok := entries[yarnBerryMetadataKey]
+	if !ok {
+		return nil, fmt.Errorf("unsupported yarn lockfile version")
+	}
+	version, err := strconv.Atoi(metadata.Version)
+	if err != nil || version < 2 {
+		return nil, fmt.Errorf("unsupported yarn lockfile version")
+	}
+	delete(entries, yarnBerryMetadataKey)
+
+	ret := &yarnLock{
+		version:    version,
+		berry:      true,
+		specifiers: map[string]*lockedPackage{},
+	}
+
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// Patched packages reuse the package they patch, so process them last
+	byResolution := map[string]*lockedPackage{}
+	patched := []string{}
+	for _, k := range keys {
+		entry := entries[k]
+		if entry.Resolution == "" {
+			return nil, fmt.Errorf("missing resolution for %s", k)
+		}
+		_, resolutionRange := splitYarnSpecifier(entry.Resolution)
+		switch protocolOf(resolutionRange) {
+		case "workspace":
+			// Workspaces are not in the registry but they depend on the locked packages
+			path := strings.TrimPrefix(resolutionRange, "workspace:")
+			if path == "." {
+				path = ""
+			}
+			ret.importers = append(ret.importers, lockedImporter{path: path, deps: entry.Dependencies})
+
+			continue
+		case "link", "portal":
+			// Local packages are not in the registry
+			continue
+		case "patch":
+			patched = append(patched, k)
+
+			continue
+		}
+		if err := ret.addBerryEntry(k, entry, byResolution); err != nil {
+			return nil, err
+		}
+	}
+	for _, k := range patched {
+		entry := entries[k]
+		_, resolutionRange := splitYarnSpecifier(entry.Resolution)
+		if p, ok := byResolution[normalizeBerryResolution(patchSource(resolutionRange))]; ok {
+			for _, d := range strings.Split(k, ",") {
+				name, rng := splitYarnSpecifier(strings.TrimSpace(d))
+				ret.specifiers[name+"@"+normalizeBerryRange(rng)] = p
+			}
+
+			continue
+		}
+		if err := ret.addBerryEntry(k, entry, byResolution); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+func normalizeBerryResolution(resolution string) string {
+	name, rng := splitYarnSpecifier(resolution)
+
+	return name + "@" + normalizeBerryRange(rng)
+}
+
+// addBerryEntry creates the locked packages for the descriptors of the input yarn.lock entry.
+func (y *yarnLock) addBerryEntry(key string, entry yarnBerryEntry, byResolution map[string]*lockedPackage) error {
+	if entry.Version == "" {
+		return fmt.Errorf("missing version for %s", key)
+	}
+
+	realName, _ := splitYarnSpecifier(entry.Resolution)
+
+	deps := map[string]string{}
+	optionalDeps := map[string]string{}
+	for name, rng := range entry.Dependencies {
+		if entry.DependenciesMeta[name]["optional"] {
+			optionalDeps[name] = rng
+		} else {
+			deps[name] = rng
+		}
+	}
+	if len(deps) == 0 {
+		deps = nil
+	}
+	if len(optionalDeps) == 0 {
+		optionalDeps = nil
+	}
+
+	// Descriptors of the same entry may install the package under different names (aliases)
+	byName := map[string]*lockedPackage{}
+	for _, d := range strings.Split(key, ",") {
+		name, rng := splitYarnSpecifier(strings.TrimSpace(d))
+		p, ok := byName[name]
+		if !ok {
+			// The converted package has no integrity since the yarn.lock lacks the one of the registry tarball
+			p = &lockedPackage{
+				name:                 name,
+				version:              entry.Version,
+				dependencies:         deps,
+				optionalDependencies: optionalDeps,
+			}
+			if realName != name {
+				p.realName = realName
+			}
+			byName[name] = p
+			y.packages = append(y.packages, p)
+			if _, exists := byResolution[normalizeBerryResolution(entry.Resolution)]; !exists || realName == name {
+				byResolution[normalizeBerryResolution(entry.Resolution)] = p
+			}
+		}
+		y.specifiers[name+"@"+normalizeBerryRange(rng)] = p
+	}
+
+	return nil
+}
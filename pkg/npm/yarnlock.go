@@ -0,0 +1,293 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yarnLock represents a yarn.lock file.
+//
+// It supports both the classic (v1) format and the YAML format of Yarn Berry (v2+).
+type yarnLock struct {
+	version  int
+	berry    bool
+	packages []*lockedPackage
+	// specifiers maps every "name@range" specifier to the locked package satisfying it
+	specifiers map[string]*lockedPackage
+	// importers are the projects depending on the locked packages
+	importers []lockedImporter
+	convertedLock
+}
+
+// Version returns the version of the yarn.lock format.
+func (y *yarnLock) Version() int {
+	return y.version
+}
+
+func (y *yarnLock) Ok() bool {
+	return y.version > 0 && y.convertedLock.Ok()
+}
+
+func (y *yarnLock) resolve(_ *lockedPackage, name, specifier string) *lockedPackage {
+	if y.berry {
+		return y.resolveBerry(name, specifier)
+	}
+
+	return y.specifiers[name+"@"+specifier]
+}
+
+// splitYarnSpecifier splits a "name@range" specifier into its name and range.
+func splitYarnSpecifier(s string) (string, string) {
+	if len(s) < 2 {
+		return s, ""
+	}
+	i := strings.Index(s[1:], "@")
+	if i < 0 {
+		return s, ""
+	}
+
+	return s[:i+1], s[i+2:]
+}
+
+// aliasOf returns the actual package name when the input range is an npm alias (eg., "npm:pkg@^1.0.0").
+func aliasOf(rng string) string {
+	if !strings.HasPrefix(rng, "npm:") {
+		return ""
+	}
+	name, rest := splitYarnSpecifier(strings.TrimPrefix(rng, "npm:"))
+	if rest == "" {
+		return ""
+	}
+
+	return name
+}
+
+func unquoteYarn(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		if u, err := strconv.Unquote(s); err == nil {
+			return u
+		}
+
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
+
+// splitYarnKeyValue splits a line like `name "value"` into its key and value.
+func splitYarnKeyValue(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, `"`) {
+		end := strings.Index(line[1:], `"`)
+		if end >= 0 {
+			return line[1 : end+1], unquoteYarn(line[end+2:])
+		}
+	}
+	i := strings.IndexAny(line, " \t")
+	if i < 0 {
+		return line, ""
+	}
+
+	return line[:i], unquoteYarn(line[i+1:])
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// parseYarnClassic parses the contents of a yarn.lock v1 file.
+func parseYarnClassic(b []byte) (*yarnLock, error) {
+	ret := &yarnLock{
+		specifiers: map[string]*lockedPackage{},
+	}
+
+	type block struct {
+		specifiers []string
+		fields     map[string]string
+		deps       map[string]map[string]string
+	}
+	blocks := []*block{}
+
+	var current *block
+	var section string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	num := 0
+	for scanner.Scan() {
+		num++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			if strings.Contains(trimmed, "yarn lockfile v") {
+				v, err := strconv.Atoi(strings.TrimSpace(trimmed[strings.Index(trimmed, "yarn lockfile v")+len("yarn lockfile v"):]))
+				if err == nil {
+					ret.version = v
+				}
+			}
+
+			continue
+		}
+
+		switch indentation(line) {
+		case 0:
+			if !strings.HasSuffix(trimmed, ":") {
+				return nil, fmt.Errorf("unexpected content at line %d", num)
+			}
+			current = &block{
+				fields: map[string]string{},
+				deps:   map[string]map[string]string{},
+			}
+			for _, s := range splitOutsideQuotes(strings.TrimSuffix(trimmed, ":"), ',') {
+				current.specifiers = append(current.specifiers, unquoteYarn(s))
+			}
+			blocks = append(blocks, current)
+			section = ""
+		case 2:
+			if current == nil {
+				return nil, fmt.Errorf("unexpected indentation at line %d", num)
+			}
+			if strings.HasSuffix(trimmed, ":") {
+				section = unquoteYarn(strings.TrimSuffix(trimmed, ":"))
+				current.deps[section] = map[string]string{}
+
+				continue
+			}
+			section = ""
+			k, v := splitYarnKeyValue(trimmed)
+			current.fields[k] = v
+		default:
+			if current == nil || section == "" {
+				return nil, fmt.Errorf("unexpected indentation at line %d", num)
+			}
+			k, v := splitYarnKeyValue(trimmed)
+			current.deps[section][k] = v
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if ret.version != 1 {
+		return nil, fmt.Errorf("unsupported yarn lockfile version")
+	}
+
+	for _, blk := range blocks {
+		version, ok := blk.fields["version"]
+		if !ok || version == "" {
+			return nil, fmt.Errorf("missing version for %s", strings.Join(blk.specifiers, ", "))
+		}
+		// Specifiers of the same block may install the package under different names (aliases)
+		byName := map[string]*lockedPackage{}
+		for _, s := range blk.specifiers {
+			name, rng := splitYarnSpecifier(s)
+			p, ok := byName[name]
+			if !ok {
+				p = &lockedPackage{
+					name:                 name,
+					realName:             aliasOf(rng),
+					version:              version,
+					resolved:             blk.fields["resolved"],
+					integrity:            blk.fields["integrity"],
+					dependencies:         blk.deps["dependencies"],
+					optionalDependencies: blk.deps["optionalDependencies"],
+				}
+				byName[name] = p
+				ret.packages = append(ret.packages, p)
+			}
+			ret.specifiers[s] = p
+		}
+	}
+
+	return ret, nil
+}
+
+// splitOutsideQuotes splits the input string by sep, ignoring the separators between double quotes.
+func splitOutsideQuotes(s string, sep rune) []string {
+	ret := []string{}
+	quoted := false
+	start := 0
+	for i, r := range s {
+		switch r {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				ret = append(ret, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	return append(ret, strings.TrimSpace(s[start:]))
+}
+
+// isYarnClassic tells whether the input contents are in the yarn.lock v1 format.
+func isYarnClassic(b []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "#") {
+			return false
+		}
+		if strings.Contains(line, "yarn lockfile v1") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newYarnLock parses the contents of a yarn.lock file
+// and converts it into the equivalent package-lock.json.
+//
+// It detects automatically whether the input is a classic or a Berry yarn.lock.
+//
+// Since the classic yarn.lock does not record the dependencies of the workspaces,
+// it lays out the packages for the input importers, if any.
+func newYarnLock(b []byte, importers []lockedImporter) (*yarnLock, error) {
+	var ret *yarnLock
+	var err error
+	if isYarnClassic(b) || len(bytes.TrimSpace(b)) == 0 {
+		ret, err = parseYarnClassic(b)
+	} else {
+		ret, err = parseYarnBerry(b)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !ret.berry {
+		ret.importers = importers
+	}
+
+	ret.convertedLock.lock, err = newPackageLockJSONFromLocked("", "", ret.packages, ret.importers, ret.resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,314 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package npm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewYarnLockFromReader(t *testing.T) {
+	fixture, errFixture := os.Open("testdata/yarn.lock")
+	require.Nil(t, errFixture)
+	defer fixture.Close()
+
+	lock, err := NewYarnLockFromReader(fixture)
+	require.Nil(t, err)
+	require.IsType(t, &yarnLock{}, lock)
+
+	assert.True(t, lock.Ok())
+	assert.Equal(t, 1, lock.Version())
+
+	deps := lock.Deps()
+	assert.Len(t, deps, 13)
+	assert.Equal(t, "7.20.13", deps["@babel/runtime"].Version)
+	assert.Equal(t, "4.3.4", deps["debug"].Version)
+	assert.Equal(t, "2.1.2", deps["ms"].Version)
+	assert.Equal(t, "2.6.9", deps["wrap-ansi/node_modules/debug"].Version)
+	assert.Equal(t, "2.0.0", deps["wrap-ansi/node_modules/debug/node_modules/ms"].Version)
+	assert.Equal(t, "4.2.3", deps["string-width-cjs"].Version)
+	assert.Equal(t, "4.2.3", deps["string-width"].Version)
+	assert.Equal(t, "2.3.2", deps["fsevents"].Version)
+	assert.Equal(t, "https://registry.yarnpkg.com/chalk/-/chalk-4.1.2.tgz#aac4e2b7734a740867aeb16bf02aad556a1e7a01", deps["chalk"].Resolved)
+
+	// The analysis request carries the equivalent package-lock.json
+	b, err := base64.StdEncoding.DecodeString(lock.Encode())
+	require.Nil(t, err)
+	converted := map[string]any{}
+	require.Nil(t, json.Unmarshal(b, &converted))
+	assert.Equal(t, float64(3), converted["lockfileVersion"])
+	packages, ok := converted["packages"].(map[string]any)
+	require.True(t, ok)
+	alias, ok := packages["node_modules/string-width-cjs"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "string-width", alias["name"])
+	wrapAnsi, ok := packages["node_modules/wrap-ansi"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"fsevents": "~2.3.2"}, wrapAnsi["optionalDependencies"])
+
+	pkgLock, err := NewPackageLockJSONFromBytes(b)
+	require.Nil(t, err)
+	assert.True(t, pkgLock.Ok())
+}
+
+func TestNewYarnLockFromBytesErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		input   string
+		wantErr string
+	}{
+		{
+			desc:    "empty",
+			input:   "",
+			wantErr: "couldn't decode from the input yarn.lock contents: unsupported yarn lockfile version",
+		},
+		{
+			desc: "missing-version",
+			input: heredoc.Doc(`
+				# yarn lockfile v1
+
+				ms@2.1.2:
+				  resolved "https://registry.yarnpkg.com/ms/-/ms-2.1.2.tgz"
+			`),
+			wantErr: "couldn't decode from the input yarn.lock contents: missing version for ms@2.1.2",
+		},
+		{
+			desc: "unexpected-indentation",
+			input: heredoc.Doc(`
+				# yarn lockfile v1
+
+				  version "2.1.2"
+			`),
+			wantErr: "couldn't decode from the input yarn.lock contents: unexpected indentation at line 3",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := NewYarnLockFromReader(strings.NewReader(tc.input))
+			assert.Nil(t, res)
+			if assert.Error(t, err) {
+				assert.Equal(t, tc.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestSplitYarnSpecifier(t *testing.T) {
+	tests := []struct {
+		input string
+		name  string
+		rng   string
+	}{
+		{"ms@2.1.2", "ms", "2.1.2"},
+		{"@babel/runtime@^7.20.0", "@babel/runtime", "^7.20.0"},
+		{"string-width-cjs@npm:string-width@^4.2.0", "string-width-cjs", "npm:string-width@^4.2.0"},
+		{"ms", "ms", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			name, rng := splitYarnSpecifier(tc.input)
+			assert.Equal(t, tc.name, name)
+			assert.Equal(t, tc.rng, rng)
+		})
+	}
+}
+
+func TestNewYarnLockFromReaderBerry(t *testing.T) {
+	fixture, errFixture := os.Open("testdata/yarn-berry.lock")
+	require.Nil(t, errFixture)
+	defer fixture.Close()
+
+	lock, err := NewYarnLockFromReader(fixture)
+	require.Nil(t, err)
+	require.IsType(t, &yarnLock{}, lock)
+
+	assert.True(t, lock.Ok())
+	assert.Equal(t, 8, lock.Version())
+
+	deps := lock.Deps()
+	assert.Len(t, deps, 9)
+	assert.NotContains(t, deps, "my-app")
+	assert.Equal(t, "7.20.13", deps["@babel/runtime"].Version)
+	assert.Equal(t, "4.3.4", deps["debug"].Version)
+	assert.Equal(t, "2.1.2", deps["ms"].Version)
+	assert.Equal(t, "2.6.9", deps["wrap-ansi/node_modules/debug"].Version)
+	assert.Equal(t, "2.0.0", deps["wrap-ansi/node_modules/debug/node_modules/ms"].Version)
+	assert.Equal(t, "4.2.3", deps["string-width-cjs"].Version)
+	assert.Equal(t, "2.3.2", deps["fsevents"].Version)
+	assert.Equal(t, "0.13.11", deps["regenerator-runtime"].Version)
+
+	b, err := base64.StdEncoding.DecodeString(lock.Encode())
+	require.Nil(t, err)
+	converted := map[string]any{}
+	require.Nil(t, json.Unmarshal(b, &converted))
+	packages, ok := converted["packages"].(map[string]any)
+	require.True(t, ok)
+	alias, ok := packages["node_modules/string-width-cjs"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "string-width", alias["name"])
+	// The checksums of Yarn Berry are the ones of its cache archives, not of the registry tarballs
+	debug, ok := packages["node_modules/debug"].(map[string]any)
+	require.True(t, ok)
+	assert.NotContains(t, debug, "integrity")
+	wrapAnsi, ok := packages["node_modules/wrap-ansi"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"fsevents": "patch:fsevents@npm%3A~2.3.2#optional!builtin<compat/fsevents>"}, wrapAnsi["optionalDependencies"])
+}
+
+func TestNewYarnLockFromBytesBerryErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		input   string
+		wantErr string
+	}{
+		{
+			desc: "no-metadata",
+			input: heredoc.Doc(`
+				"ms@npm:2.1.2":
+				  version: 2.1.2
+				  resolution: "ms@npm:2.1.2"
+			`),
+			wantErr: "couldn't decode from the input yarn.lock contents: unsupported yarn lockfile version",
+		},
+		{
+			desc: "missing-resolution",
+			input: heredoc.Doc(`
+				__metadata:
+				  version: 6
+
+				"ms@npm:2.1.2":
+				  version: 2.1.2
+			`),
+			wantErr: "couldn't decode from the input yarn.lock contents: missing resolution for ms@npm:2.1.2",
+		},
+		{
+			desc:    "invalid",
+			input:   "ms@2.1.2:\n  version \"2.1.2\"\n",
+			wantErr: "couldn't decode from the input yarn.lock contents: invalid yarn lockfile",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := NewYarnLockFromBytes([]byte(tc.input))
+			assert.Nil(t, res)
+			if assert.Error(t, err) {
+				assert.Equal(t, tc.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestNormalizeBerryRange(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"^4.3.1", "npm:^4.3.1"},
+		{"npm:^4.3.1", "npm:^4.3.1"},
+		{"npm:string-width@^4.2.0", "npm:string-width@^4.2.0"},
+		{"patch:fsevents@~2.3.2#~builtin<compat/fsevents>", "patch:fsevents@npm:~2.3.2#~builtin<compat/fsevents>"},
+		{"patch:fsevents@npm%3A~2.3.2#optional!builtin<compat/fsevents>", "patch:fsevents@npm:~2.3.2#optional!builtin<compat/fsevents>"},
+		{"workspace:.", "workspace:."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			assert.Equal(t, tc.want, normalizeBerryRange(tc.input))
+		})
+	}
+}
+
+func TestNewYarnLockWithWorkspaces(t *testing.T) {
+	p := getWorkspacesFromTestdata(t)
+	require.IsType(t, &packageJSON{}, p)
+
+	lock, err := newYarnLock([]byte(heredoc.Doc(`
+		# yarn lockfile v1
+
+
+		chalk@4.1.2:
+		  version "4.1.2"
+		  resolved "https://registry.yarnpkg.com/chalk/-/chalk-4.1.2.tgz"
+
+		chalk@^5.0.0:
+		  version "5.3.0"
+		  resolved "https://registry.yarnpkg.com/chalk/-/chalk-5.3.0.tgz"
+
+		react@^17.0.0, react@^17.0.2:
+		  version "17.0.2"
+		  resolved "https://registry.yarnpkg.com/react/-/react-17.0.2.tgz"
+	`)), p.(*packageJSON).importers())
+	require.Nil(t, err)
+
+	deps := lock.Deps()
+	assert.Len(t, deps, 3)
+	assert.Equal(t, "5.3.0", deps["chalk"].Version)
+	assert.Equal(t, "4.1.2", deps["packages/a/node_modules/chalk"].Version)
+	assert.Equal(t, "17.0.2", deps["react"].Version)
+}
+
+func TestNewYarnLockFromBytesBerryWorkspaces(t *testing.T) {
+	lock, err := NewYarnLockFromBytes([]byte(heredoc.Doc(`
+		__metadata:
+		  version: 8
+		  cacheKey: 10c0
+
+		"a@workspace:packages/a":
+		  version: 0.0.0-use.local
+		  resolution: "a@workspace:packages/a"
+		  dependencies:
+		    lodash: "npm:^3.10.1"
+		  languageName: unknown
+		  linkType: soft
+
+		"lodash@npm:^3.10.1":
+		  version: 3.10.1
+		  resolution: "lodash@npm:3.10.1"
+		  languageName: node
+		  linkType: hard
+
+		"lodash@npm:^4.17.21":
+		  version: 4.17.21
+		  resolution: "lodash@npm:4.17.21"
+		  languageName: node
+		  linkType: hard
+
+		"root@workspace:.":
+		  version: 0.0.0-use.local
+		  resolution: "root@workspace:."
+		  dependencies:
+		    a: "workspace:^"
+		    lodash: "npm:^4.17.21"
+		  languageName: unknown
+		  linkType: soft
+	`)))
+	require.Nil(t, err)
+
+	deps := lock.Deps()
+	assert.Len(t, deps, 2)
+	assert.Equal(t, "4.17.21", deps["lodash"].Version)
+	assert.Equal(t, "3.10.1", deps["packages/a/node_modules/lodash"].Version)
+}
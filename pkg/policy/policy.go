@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/listendev/pkg/models"
+	"github.com/listendev/pkg/models/severity"
+)
+
+// Rule is a condition making the commands fail.
+type Rule int
+
+const (
+	// SeverityRule fails on the verdicts with a severity equal to or higher than a threshold.
+	SeverityRule Rule = iota + 1
+	// CodesRule fails on the verdicts with some codes or code groups.
+	CodesRule
+	// ProblemsRule fails on the packages with problems.
+	ProblemsRule
+)
+
+var severityRank = map[severity.Severity]int{
+	severity.Low:    1,
+	severity.Medium: 2,
+	severity.High:   3,
+}
+
+// Policy collects the package versions violating the fail-on options.
+//
+// It is safe for concurrent use. A nil Policy does not fail on anything.
+type Policy struct {
+	severity severity.Severity
+	codes    []string
+	problems bool
+
+	mu         sync.Mutex
+	violations map[Rule][]string
+}
+
+// New creates the policy from the input configuration options.
+//
+// It returns nil when the configuration options do not tell to fail on anything.
+func New(cfg *flags.ConfigFlags) *Policy {
+	if cfg == nil || (cfg.FailOn == "" && len(cfg.FailOnCodes) == 0 && !cfg.FailOnProblems) {
+		return nil
+	}
+
+	ret := &Policy{
+		severity:   severity.Severity(cfg.FailOn),
+		problems:   cfg.FailOnProblems,
+		violations: make(map[Rule][]string),
+	}
+	for _, c := range cfg.FailOnCodes {
+		ret.codes = append(ret.codes, strings.ToUpper(c))
+	}
+
+	return ret
+}
+
+// FromContext returns the policy in the input context, if any.
+func FromContext(ctx context.Context) *Policy {
+	if ctx == nil {
+		return nil
+	}
+	p, _ := ctx.Value(pkgcontext.PolicyKey).(*Policy)
+
+	return p
+}
+
+// Check records whether the verdicts and the problems of the input package version violate the policy.
+func (p *Policy) Check(name, version string, verdicts []models.Verdict, problems []models.Problem) {
+	if p == nil {
+		return
+	}
+	nameVersion := name
+	if version != "" {
+		nameVersion += "@" + version
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.severity != "" && anyVerdict(verdicts, p.hasSeverity) {
+		p.record(SeverityRule, nameVersion)
+	}
+	if len(p.codes) > 0 && anyVerdict(verdicts, p.hasCode) {
+		p.record(CodesRule, nameVersion)
+	}
+	if p.problems && len(problems) > 0 {
+		p.record(ProblemsRule, nameVersion)
+	}
+}
+
+// record adds the package version to the violations of the rule, once.
+func (p *Policy) record(rule Rule, nameVersion string) {
+	if !slices.Contains(p.violations[rule], nameVersion) {
+		p.violations[rule] = append(p.violations[rule], nameVersion)
+	}
+}
+
+func (p *Policy) hasSeverity(v models.Verdict) bool {
+	return severityRank[v.Severity] >= severityRank[p.severity]
+}
+
+// hasCode tells whether the verdict code is one of the policy codes, or belongs to one of the policy code groups.
+func (p *Policy) hasCode(v models.Verdict) bool {
+	code := v.Code.String()
+	for _, c := range p.codes {
+		if strings.HasPrefix(code, c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func anyVerdict(verdicts []models.Verdict, f func(models.Verdict) bool) bool {
+	for _, v := range verdicts {
+		if f(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Err returns the violation of the policy, if any.
+//
+// When the package versions violate more rules, it tells the first one among severity, codes, and problems.
+func (p *Policy) Err() error {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, rule := range []Rule{SeverityRule, CodesRule, ProblemsRule} {
+		if packages := p.violations[rule]; len(packages) > 0 {
+			return &ViolationError{Rule: rule, Packages: packages, what: p.describe(rule)}
+		}
+	}
+
+	return nil
+}
+
+func (p *Policy) describe(rule Rule) string {
+	switch rule {
+	case SeverityRule:
+		if p.severity == severity.High {
+			return "verdicts with high severity"
+		}
+
+		return fmt.Sprintf("verdicts with %s severity or higher", p.severity)
+	case CodesRule:
+		return fmt.Sprintf("verdicts with codes %s", strings.Join(p.codes, ", "))
+	case ProblemsRule:
+		return "problems"
+	default:
+		return ""
+	}
+}
+
+// ViolationError tells that some package versions violate the policy.
+type ViolationError struct {
+	// Rule is the rule the package versions violate
+	Rule Rule
+	// Packages are the package versions violating the rule
+	Packages []string
+
+	what string
+}
+
+func (e *ViolationError) Error() string {
+	packagesWord := "package versions have"
+	if len(e.Packages) == 1 {
+		packagesWord = "package version has"
+	}
+
+	return fmt.Sprintf("%d %s %s: %s", len(e.Packages), packagesWord, e.what, strings.Join(e.Packages, ", "))
+}
+
+// Result joins the violation of the policy in the input context, if any, with the input error of a command.
+func Result(ctx context.Context, err error) error {
+	violation := FromContext(ctx).Err()
+	if violation == nil {
+		return err
+	}
+	if err == nil {
+		return violation
+	}
+
+	return errors.Join(violation, err)
+}
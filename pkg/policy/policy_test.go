@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/listendev/pkg/models"
+	"github.com/listendev/pkg/models/severity"
+	"github.com/listendev/pkg/verdictcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	lowSTN  = models.Verdict{Severity: severity.Low, Code: verdictcode.STN001}
+	highTSN = models.Verdict{Severity: severity.High, Code: verdictcode.TSN01}
+)
+
+func TestNewWithoutRules(t *testing.T) {
+	assert.Nil(t, New(nil))
+	assert.Nil(t, New(&flags.ConfigFlags{}))
+
+	var p *Policy
+	p.Check("react", "18.0.0", []models.Verdict{highTSN}, nil)
+	assert.Nil(t, p.Err())
+	assert.Nil(t, FromContext(t.Context()))
+}
+
+func TestSeverity(t *testing.T) {
+	p := New(&flags.ConfigFlags{Policy: flags.Policy{FailOn: "medium"}})
+	require.NotNil(t, p)
+
+	p.Check("chalk", "5.3.0", []models.Verdict{lowSTN}, nil)
+	assert.Nil(t, p.Err())
+
+	p.Check("react", "18.0.0", []models.Verdict{lowSTN, highTSN}, nil)
+	p.Check("react", "18.0.0", []models.Verdict{highTSN}, nil)
+	err := p.Err()
+	var violationErr *ViolationError
+	require.True(t, errors.As(err, &violationErr))
+	assert.Equal(t, SeverityRule, violationErr.Rule)
+	assert.Equal(t, []string{"react@18.0.0"}, violationErr.Packages)
+	assert.Equal(t, "1 package version has verdicts with medium severity or higher: react@18.0.0", err.Error())
+}
+
+func TestCodes(t *testing.T) {
+	// Both code groups and codes, case insensitively
+	p := New(&flags.ConfigFlags{Policy: flags.Policy{FailOnCodes: []string{"stn", "TSN02"}}})
+	require.NotNil(t, p)
+
+	p.Check("react", "18.0.0", []models.Verdict{highTSN}, nil)
+	assert.Nil(t, p.Err())
+
+	p.Check("chalk", "5.3.0", []models.Verdict{lowSTN}, nil)
+	p.Check("tsn", "", []models.Verdict{{Code: verdictcode.TSN02}}, nil)
+	err := p.Err()
+	var violationErr *ViolationError
+	require.True(t, errors.As(err, &violationErr))
+	assert.Equal(t, CodesRule, violationErr.Rule)
+	assert.Equal(t, "2 package versions have verdicts with codes STN, TSN02: chalk@5.3.0, tsn", err.Error())
+}
+
+func TestProblemsAndPrecedence(t *testing.T) {
+	p := New(&flags.ConfigFlags{Policy: flags.Policy{FailOn: "high", FailOnCodes: []string{"STN"}, FailOnProblems: true}})
+	require.NotNil(t, p)
+
+	p.Check("broken", "1.0.0", nil, []models.Problem{{Type: "https://listen.dev/probs/invalid-name", Title: "Package name not valid"}})
+	var violationErr *ViolationError
+	require.True(t, errors.As(p.Err(), &violationErr))
+	assert.Equal(t, ProblemsRule, violationErr.Rule)
+
+	p.Check("chalk", "5.3.0", []models.Verdict{lowSTN}, nil)
+	require.True(t, errors.As(p.Err(), &violationErr))
+	assert.Equal(t, CodesRule, violationErr.Rule)
+
+	p.Check("react", "18.0.0", []models.Verdict{highTSN}, nil)
+	require.True(t, errors.As(p.Err(), &violationErr))
+	assert.Equal(t, SeverityRule, violationErr.Rule)
+}
+
+func TestResult(t *testing.T) {
+	other := errors.New("couldn't get the verdicts of 1 out of 2 packages")
+	assert.Nil(t, Result(t.Context(), nil))
+	assert.Same(t, other, Result(t.Context(), other))
+
+	p := New(&flags.ConfigFlags{Policy: flags.Policy{FailOnProblems: true}})
+	ctx := context.WithValue(t.Context(), pkgcontext.PolicyKey, p)
+	assert.Same(t, p, FromContext(ctx))
+	assert.Nil(t, Result(ctx, nil))
+
+	p.Check("broken", "1.0.0", nil, []models.Problem{{Title: "Package name not valid"}})
+	var violationErr *ViolationError
+	assert.True(t, errors.As(Result(ctx, nil), &violationErr))
+
+	err := Result(ctx, other)
+	assert.True(t, errors.As(err, &violationErr))
+	assert.ErrorIs(t, err, other)
+}
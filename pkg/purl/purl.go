@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package purl
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/listendev/pkg/ecosystem"
+)
+
+// Scheme is the scheme of every package URL.
+const Scheme = "pkg:"
+
+// PackageURL is a package URL (purl) in its components.
+//
+// See https://github.com/package-url/purl-spec.
+type PackageURL struct {
+	Type       string
+	Namespace  string
+	Name       string
+	Version    string
+	Qualifiers map[string]string
+	Subpath    string
+}
+
+// IsPURL tells whether the input string looks like a package URL.
+func IsPURL(s string) bool {
+	return strings.HasPrefix(strings.ToLower(s), Scheme)
+}
+
+// Parse parses the input string as a package URL.
+func Parse(s string) (*PackageURL, error) {
+	if !IsPURL(s) {
+		return nil, fmt.Errorf("missing the %s scheme", Scheme)
+	}
+	remainder := strings.TrimLeft(s[len(Scheme):], "/")
+
+	ret := &PackageURL{Qualifiers: map[string]string{}}
+
+	var err error
+	if i := strings.LastIndex(remainder, "#"); i >= 0 {
+		ret.Subpath, err = decodeSegments(strings.Trim(remainder[i+1:], "/"))
+		if err != nil {
+			return nil, err
+		}
+		remainder = remainder[:i]
+	}
+	if i := strings.LastIndex(remainder, "?"); i >= 0 {
+		for _, pair := range strings.Split(remainder[i+1:], "&") {
+			key, value, found := strings.Cut(pair, "=")
+			if !found || key == "" {
+				return nil, fmt.Errorf("malformed qualifier %q", pair)
+			}
+			value, err = url.PathUnescape(value)
+			if err != nil {
+				return nil, fmt.Errorf("malformed qualifier %q", pair)
+			}
+			// Qualifiers without a value are the same as missing ones
+			if value != "" {
+				ret.Qualifiers[strings.ToLower(key)] = value
+			}
+		}
+		remainder = remainder[:i]
+	}
+
+	typ, remainder, found := strings.Cut(strings.TrimRight(remainder, "/"), "/")
+	if !found || typ == "" {
+		return nil, fmt.Errorf("missing the type")
+	}
+	ret.Type = strings.ToLower(typ)
+
+	if i := strings.LastIndex(remainder, "@"); i >= 0 {
+		ret.Version, err = url.PathUnescape(remainder[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("malformed version %q", remainder[i+1:])
+		}
+		remainder = remainder[:i]
+	}
+
+	segments := strings.Split(strings.Trim(remainder, "/"), "/")
+	ret.Name, err = url.PathUnescape(segments[len(segments)-1])
+	if err != nil || ret.Name == "" {
+		return nil, fmt.Errorf("missing the name")
+	}
+	ret.Namespace, err = decodeSegments(strings.Join(segments[:len(segments)-1], "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	// The PyPi names are case-insensitive, and the underscores are the same as the dashes
+	if ret.Type == "pypi" {
+		ret.Name = strings.ReplaceAll(strings.ToLower(ret.Name), "_", "-")
+	}
+
+	return ret, nil
+}
+
+func decodeSegments(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	segments := strings.Split(s, "/")
+	for i, segment := range segments {
+		decoded, err := url.PathUnescape(segment)
+		if err != nil {
+			return "", fmt.Errorf("malformed segment %q", segment)
+		}
+		segments[i] = decoded
+	}
+
+	return strings.Join(segments, "/"), nil
+}
+
+// Ecosystem returns the ecosystem of the package.
+//
+// It returns ecosystem.None for the package types lstn does not support.
+func (p *PackageURL) Ecosystem() ecosystem.Ecosystem {
+	switch p.Type {
+	case "npm":
+		return ecosystem.Npm
+	case "pypi":
+		return ecosystem.Pypi
+	}
+
+	return ecosystem.None
+}
+
+// FullName returns the name of the package as its ecosystem knows it.
+//
+// For example, the scoped npm packages have their namespace (eg., @vue/devtools).
+func (p *PackageURL) FullName() string {
+	if p.Namespace == "" {
+		return p.Name
+	}
+
+	return p.Namespace + "/" + p.Name
+}
+
+// Checksum returns the hex digest the checksum qualifier contains for the input algorithm (eg., sha1).
+func (p *PackageURL) Checksum(algorithm string) string {
+	for _, checksum := range strings.Split(p.Qualifiers["checksum"], ",") {
+		algo, digest, found := strings.Cut(strings.TrimSpace(checksum), ":")
+		if found && strings.EqualFold(algo, algorithm) {
+			return strings.ToLower(digest)
+		}
+	}
+
+	return ""
+}
+
+// Digest returns the digest listen.dev identifies the package files with.
+//
+// It is the SHA1 shasum for npm packages, and the SHA256 digest for PyPi packages.
+func (p *PackageURL) Digest() string {
+	switch p.Ecosystem() {
+	case ecosystem.Npm:
+		return p.Checksum("sha1")
+	case ecosystem.Pypi:
+		return p.Checksum("sha256")
+	}
+
+	return ""
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package purl
+
+import (
+	"testing"
+
+	"github.com/listendev/pkg/ecosystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input    string
+		want     *PackageURL
+		fullName string
+		eco      ecosystem.Ecosystem
+	}{
+		{
+			input:    "pkg:npm/%40vue/devtools@6.5.0",
+			want:     &PackageURL{Type: "npm", Namespace: "@vue", Name: "devtools", Version: "6.5.0", Qualifiers: map[string]string{}},
+			fullName: "@vue/devtools",
+			eco:      ecosystem.Npm,
+		},
+		{
+			input:    "pkg:npm/react",
+			want:     &PackageURL{Type: "npm", Name: "react", Qualifiers: map[string]string{}},
+			fullName: "react",
+			eco:      ecosystem.Npm,
+		},
+		{
+			input:    "pkg:pypi/Django_Rest@3.15.2?checksum=sha256:AB12,sha1:cd34&repository_url=&file_name=x.whl#sub/path",
+			want:     &PackageURL{Type: "pypi", Name: "django-rest", Version: "3.15.2", Qualifiers: map[string]string{"checksum": "sha256:AB12,sha1:cd34", "file_name": "x.whl"}, Subpath: "sub/path"},
+			fullName: "django-rest",
+			eco:      ecosystem.Pypi,
+		},
+		{
+			input:    "PKG://Cargo/rand@0.8.5",
+			want:     &PackageURL{Type: "cargo", Name: "rand", Version: "0.8.5", Qualifiers: map[string]string{}},
+			fullName: "rand",
+			eco:      ecosystem.None,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := Parse(tc.input)
+			require.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.fullName, got.FullName())
+			assert.Equal(t, tc.eco, got.Ecosystem())
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"npm/react":          "missing the pkg: scheme",
+		"pkg:react":          "missing the type",
+		"pkg:npm/@1.0.0":     "missing the name",
+		"pkg:npm/react?oops": "malformed qualifier \"oops\"",
+	}
+
+	for input, want := range cases {
+		t.Run(input, func(t *testing.T) {
+			_, err := Parse(input)
+			if assert.Error(t, err) {
+				assert.Equal(t, want, err.Error())
+			}
+		})
+	}
+}
+
+func TestDigest(t *testing.T) {
+	p, err := Parse("pkg:npm/debug@4.3.4?checksum=sha512:aaaa,SHA1:B468736D1F4A5891F38585BA8E8FB29F91C3CB96")
+	require.Nil(t, err)
+	assert.Equal(t, "b468736d1f4a5891f38585ba8e8fb29f91c3cb96", p.Digest())
+	assert.Equal(t, "aaaa", p.Checksum("sha512"))
+	assert.Equal(t, "", p.Checksum("sha256"))
+
+	p, err = Parse("pkg:pypi/requests@2.31.0?checksum=sha1:aaaa,sha256:bbbb")
+	require.Nil(t, err)
+	assert.Equal(t, "bbbb", p.Digest())
+}
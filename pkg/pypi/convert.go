@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/listendev/lstn/pkg/lockfile"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// lockedPackage represents a package version pinned by a lock file
+// which is not a poetry.lock (eg., uv.lock).
+type lockedPackage struct {
+	name    string
+	version string
+	groups  []string
+	files   []PoetryFile
+}
+
+type poetryLockPackage struct {
+	Name           string       `toml:"name"`
+	Version        string       `toml:"version"`
+	Description    string       `toml:"description"`
+	Optional       bool         `toml:"optional"`
+	PythonVersions string       `toml:"python-versions"`
+	Groups         []string     `toml:"groups,omitempty"`
+	Files          []PoetryFile `toml:"files,multiline,inline"`
+}
+
+// convertedLock holds the poetry.lock equivalent to a lock file of another format.
+//
+// Notice the PyPI analysis endpoint only understands the poetry.lock format.
+type convertedLock struct {
+	*poetryLock
+}
+
+// setGroups sets the dependency groups of the package from the input set of group names.
+//
+// It sorts them alphabetically, but for the main group (if any) which goes first like poetry does.
+func (p *lockedPackage) setGroups(set map[string]bool, main string) {
+	p.groups = nil
+	for g := range set {
+		p.groups = append(p.groups, g)
+	}
+	sort.Slice(p.groups, func(i, j int) bool {
+		if (p.groups[i] == main) != (p.groups[j] == main) {
+			return p.groups[i] == main
+		}
+
+		return p.groups[i] < p.groups[j]
+	})
+}
+
+// contentHash returns the hex digest of the input content hash when it is a SHA256 one.
+//
+// Otherwise, it returns the SHA256 digest of the input lock file contents.
+func contentHash(hash string, contents []byte) string {
+	hash = strings.TrimPrefix(hash, "sha256:")
+	if _, err := hex.DecodeString(hash); err == nil && len(hash) == sha256.Size*2 {
+		return strings.ToLower(hash)
+	}
+	sum := sha256.Sum256(contents)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// newPoetryLockFromLocked creates a poetry.lock (lock version 2.1)
+// equivalent to the input locked packages.
+//
+// The source lock file is only used for the error messages.
+func newPoetryLockFromLocked(source lockfile.Lockfile, pythonVersions, hash string, pkgs []*lockedPackage) (*poetryLock, error) {
+	sort.SliceStable(pkgs, func(i, j int) bool {
+		if pkgs[i].name != pkgs[j].name {
+			return pkgs[i].name < pkgs[j].name
+		}
+
+		return pkgs[i].version < pkgs[j].version
+	})
+
+	packages := make([]poetryLockPackage, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		files := pkg.files
+		if files == nil {
+			files = []PoetryFile{}
+		}
+		packages = append(packages, poetryLockPackage{
+			Name:           pkg.name,
+			Version:        pkg.version,
+			PythonVersions: "*",
+			Groups:         pkg.groups,
+			Files:          files,
+		})
+	}
+	if pythonVersions == "" {
+		pythonVersions = "*"
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# This file is automatically @generated from a %s and should not be changed by hand.\n\n", source.String())
+	err := toml.NewEncoder(&b).Encode(struct {
+		Package  []poetryLockPackage `toml:"package"`
+		Metadata PoetryMetadata      `toml:"metadata"`
+	}{
+		Package: packages,
+		Metadata: PoetryMetadata{
+			LockVersion:    "2.1",
+			PythonVersions: pythonVersions,
+			ContentHash:    hash,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't convert the %s contents to %s", source.String(), lockfile.PoetryLock.String())
+	}
+
+	ret := &poetryLock{}
+	if err := toml.Unmarshal(b.Bytes(), ret); err != nil {
+		return nil, fmt.Errorf("couldn't convert the %s contents to %s", source.String(), lockfile.PoetryLock.String())
+	}
+	ret.bytes = b.Bytes()
+
+	return ret, nil
+}
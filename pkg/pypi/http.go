@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/listendev/lstn/pkg/ratelimit"
+	"github.com/listendev/lstn/pkg/transport"
+	"github.com/listendev/lstn/pkg/ua"
+)
+
+// GetFromRegistry asks to the PyPi JSON API for the details of a package
+// by name, and optionally, by version.
+func GetFromRegistry(ctx context.Context, name, version string) (io.ReadCloser, string, error) {
+	// Obtain the local options from the context
+	opts, err := pkgcontext.GetOptionsFromContext(ctx, pkgcontext.ConfigKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("couldn't find the registry key in the configuration")
+	}
+	cfgFlags, ok := opts.(*flags.ConfigFlags)
+	if !ok {
+		return nil, "", fmt.Errorf("couldn't find the registry configuration")
+	}
+	pypiRegistryBaseURL := cfgFlags.Registry.PyPi
+
+	if name == "" {
+		return nil, pypiRegistryBaseURL, pkgcontext.OutputError(ctx, fmt.Errorf("the name is mandatory to query the PyPi registry"))
+	}
+	suffix := name
+	if version != "" {
+		suffix += fmt.Sprintf("/%s", version)
+	}
+
+	url := fmt.Sprintf("%s/pypi/%s/json", pypiRegistryBaseURL, suffix)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, pypiRegistryBaseURL, pkgcontext.OutputErrorf(ctx, err, "couldn't prepare the request to %s", url)
+	}
+
+	req.Header.Set("User-Agent", ua.Generate(true))
+	req.Header.Set("Accept", "application/json")
+
+	if err := ratelimit.Wait(ctx); err != nil {
+		return nil, pypiRegistryBaseURL, pkgcontext.OutputError(ctx, err)
+	}
+	res, err := transport.Client(ctx).Do(req)
+	if err != nil {
+		return nil, pypiRegistryBaseURL, pkgcontext.OutputErrorf(ctx, err, "couldn't perform the request to %s", req.URL)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+
+		return nil, pypiRegistryBaseURL, pkgcontext.OutputErrorf(ctx, err, "the PyPi registry response for %s was not ok", req.URL)
+	}
+
+	return res.Body, pypiRegistryBaseURL, nil
+}
+
+// GetVersionsFromRegistry returns the versions of the input package available on the PyPi registry,
+// which satisfy the input constraints.
+func GetVersionsFromRegistry(ctx context.Context, name string, constraints *Constraints) (Versions, error) {
+	body, URL, err := GetFromRegistry(ctx, name, "")
+	if URL == "" {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("package %s doesn't exist on registry %s", name, URL)
+	}
+
+	return GetVersionsFromRegistryResponse(body, constraints)
+}
+
+// GetVersionsFromRegistryResponse returns the versions in the input PyPi JSON API response
+// which satisfy the input constraints.
+//
+// It skips the releases without files and the yanked ones.
+// It skips the pre-releases too, unless the constraints mention one or only pre-releases satisfy them.
+func GetVersionsFromRegistryResponse(body io.ReadCloser, constraints *Constraints) (Versions, error) {
+	defer body.Close()
+	type file struct {
+		Yanked bool `json:"yanked"`
+	}
+	type response struct {
+		Info struct {
+			Name string `json:"name"`
+		} `json:"info"`
+		Releases map[string][]file `json:"releases"`
+	}
+	res := &response{}
+
+	if err := json.NewDecoder(body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("couldn't decode the registry response")
+	}
+
+	final := Versions{}
+	pre := Versions{}
+	for raw, files := range res.Releases {
+		available := false
+		for _, f := range files {
+			if !f.Yanked {
+				available = true
+
+				break
+			}
+		}
+		if !available {
+			continue
+		}
+		// Legacy versions not complying with PEP 440 cannot satisfy any constraint
+		v, err := NewVersion(raw)
+		if err != nil {
+			continue
+		}
+		if constraints != nil && !constraints.Check(v) {
+			continue
+		}
+		if v.IsPrerelease() {
+			pre = append(pre, v)
+		} else {
+			final = append(final, v)
+		}
+	}
+
+	versions := final
+	if len(final) == 0 || (constraints != nil && constraints.AllowsPrereleases()) {
+		versions = append(versions, pre...)
+	}
+	sort.Sort(versions)
+
+	return versions, nil
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const registryResponse = `{
+	"info": {"name": "requests"},
+	"releases": {
+		"2.31.0": [{"yanked": false}],
+		"2.32.0": [{"yanked": true}],
+		"2.32.3": [{"yanked": false}, {"yanked": false}],
+		"2.33.0rc1": [{"yanked": false}],
+		"2.34.0": [],
+		"0.2-legacy-thing": [{"yanked": false}]
+	}
+}`
+
+func TestGetVersionsFromRegistryResponse(t *testing.T) {
+	tests := []struct {
+		constraints string
+		want        []string
+	}{
+		{"", []string{"2.31.0", "2.32.3"}},
+		{">=2.32", []string{"2.32.3"}},
+		{">=2.33.0rc1", []string{"2.33.0rc1"}},
+		{">2.32.3", []string{"2.33.0rc1"}},
+		{">=3", []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.constraints, func(t *testing.T) {
+			c, err := NewConstraints(tc.constraints)
+			require.Nil(t, err)
+			versions, err := GetVersionsFromRegistryResponse(io.NopCloser(strings.NewReader(registryResponse)), c)
+			require.Nil(t, err)
+			got := []string{}
+			for _, v := range versions {
+				got = append(got, v.String())
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := GetVersionsFromRegistryResponse(io.NopCloser(strings.NewReader("<html>")), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"regexp"
+	"strings"
+)
+
+// separatorsRe matches the runs of characters that PEP 503 normalizes to a single dash.
+var separatorsRe = regexp.MustCompile(`[-_.]+`)
+
+// NormalizeName normalizes the input Python package name as PEP 503 mandates.
+//
+// For example, Foo.Bar__baz becomes foo-bar-baz.
+func NormalizeName(name string) string {
+	return separatorsRe.ReplaceAllString(strings.ToLower(name), "-")
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"fmt"
+
+	"github.com/listendev/lstn/pkg/lockfile"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// pdmMainGroup is the group name of the dependencies of the project (ie., not the development ones).
+const pdmMainGroup = "default"
+
+type pdmPackage struct {
+	Name     string       `toml:"name"`
+	Version  string       `toml:"version"`
+	Groups   []string     `toml:"groups"`
+	Files    []PoetryFile `toml:"files"`
+	Path     string       `toml:"path"`
+	URL      string       `toml:"url"`
+	Git      string       `toml:"git"`
+	Editable bool         `toml:"editable"`
+}
+
+type pdmMetadata struct {
+	Groups      []string `toml:"groups"`
+	LockVersion string   `toml:"lock_version"`
+	ContentHash string   `toml:"content_hash"`
+	// Targets are only there since the lock version 4.5
+	Targets []struct {
+		RequiresPython string `toml:"requires_python"`
+	} `toml:"targets"`
+}
+
+type pdmLockFile struct {
+	Metadata pdmMetadata  `toml:"metadata"`
+	Package  []pdmPackage `toml:"package"`
+}
+
+type pdmLock struct {
+	version string
+	convertedLock
+}
+
+// Version returns the version of the pdm.lock format.
+func (p *pdmLock) Version() string {
+	return p.version
+}
+
+func newPdmLock(b []byte) (*pdmLock, error) {
+	file := pdmLockFile{}
+	if err := toml.Unmarshal(b, &file); err != nil {
+		return nil, err
+	}
+	if file.Metadata.LockVersion == "" {
+		return nil, fmt.Errorf("missing lock version")
+	}
+
+	// PDM writes one entry for every set of extras of the same package version
+	type key struct{ name, version string }
+	byKey := map[key]*lockedPackage{}
+	groups := map[*lockedPackage]map[string]bool{}
+	pkgs := []*lockedPackage{}
+	for _, p := range file.Package {
+		// Only the packages coming from a registry can be analysed
+		if p.Version == "" || p.Path != "" || p.URL != "" || p.Git != "" || p.Editable {
+			continue
+		}
+		k := key{NormalizeName(p.Name), p.Version}
+		pkg, ok := byKey[k]
+		if !ok {
+			pkg = &lockedPackage{name: p.Name, version: p.Version, files: []PoetryFile{}}
+			byKey[k] = pkg
+			groups[pkg] = map[string]bool{}
+			pkgs = append(pkgs, pkg)
+		}
+		if len(pkg.files) == 0 {
+			pkg.files = append(pkg.files, p.Files...)
+		}
+		for _, g := range p.Groups {
+			groups[pkg][g] = true
+		}
+	}
+	for _, pkg := range pkgs {
+		if len(groups[pkg]) > 0 {
+			pkg.setGroups(groups[pkg], pdmMainGroup)
+		}
+	}
+
+	pythonVersions := ""
+	if len(file.Metadata.Targets) > 0 {
+		pythonVersions = file.Metadata.Targets[0].RequiresPython
+	}
+
+	lock, err := newPoetryLockFromLocked(lockfile.PdmLock, pythonVersions, contentHash(file.Metadata.ContentHash, b), pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pdmLock{version: file.Metadata.LockVersion, convertedLock: convertedLock{lock}}, nil
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPdmLockFromReader(t *testing.T) {
+	fixture, errFixture := os.Open("testdata/pdm/pdm.lock")
+	require.Nil(t, errFixture)
+	defer fixture.Close()
+
+	lock, err := NewPdmLockFromReader(fixture)
+	require.Nil(t, err)
+	require.IsType(t, &pdmLock{}, lock)
+
+	assert.True(t, lock.Ok())
+	assert.Equal(t, "4.5.0", lock.Version())
+	assert.Equal(t, "3c1a5e7f9b2d4c6e8a0f1b3d5c7e9a2b4d6f8e0c1a3b5d7f9e2c4a6b8d0f1e3c", lock.ContentHash())
+
+	// The extras entry of requests gets merged, the local package gets skipped
+	pkgs := lock.Packages()
+	require.Len(t, pkgs, 3)
+	assert.Equal(t, "requests", pkgs[2].Name)
+	assert.Len(t, pkgs[2].Files, 2)
+	assert.Equal(t, map[string][]string{
+		"certifi":   {"default"},
+		"iniconfig": {"dev"},
+		"requests":  {"default", "dev"},
+	}, lock.Groups())
+
+	require.Nil(t, lock.FilterOutByGroups("dev"))
+	assert.Len(t, lock.Packages(), 2)
+}
+
+func TestNewPdmLockFromBytesErrors(t *testing.T) {
+	res, err := NewPdmLockFromBytes([]byte("[[package]]\nname = \"certifi\"\n"))
+	assert.Nil(t, res)
+	if assert.Error(t, err) {
+		assert.Equal(t, "couldn't decode from the input pdm.lock contents: missing lock version", err.Error())
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/listendev/lstn/pkg/lockfile"
+)
+
+// pipfileMainGroup is the group name of the dependencies of the project (ie., not the development ones).
+const pipfileMainGroup = "default"
+
+type pipfileMeta struct {
+	Hash struct {
+		SHA256 string `json:"sha256"`
+	} `json:"hash"`
+	PipfileSpec int `json:"pipfile-spec"`
+	Requires    struct {
+		PythonVersion string `json:"python_version"`
+	} `json:"requires"`
+}
+
+type pipfilePackage struct {
+	Version  string   `json:"version"`
+	Hashes   []string `json:"hashes"`
+	Path     string   `json:"path"`
+	File     string   `json:"file"`
+	Git      string   `json:"git"`
+	Editable bool     `json:"editable"`
+}
+
+type pipfileLock struct {
+	version int
+	convertedLock
+}
+
+// Version returns the version of the Pipfile.lock specification.
+func (p *pipfileLock) Version() int {
+	return p.version
+}
+
+func newPipfileLock(b []byte) (*pipfileLock, error) {
+	// Every top-level key but _meta is a category of packages (eg., default, develop)
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	metaBytes, ok := raw["_meta"]
+	if !ok {
+		return nil, fmt.Errorf("missing _meta")
+	}
+	meta := pipfileMeta{}
+	if err := json.Unmarshal(metaBytes, &meta); err != nil {
+		return nil, err
+	}
+
+	categories := []string{}
+	for k := range raw {
+		if k != "_meta" {
+			categories = append(categories, k)
+		}
+	}
+	sort.Strings(categories)
+
+	type key struct{ name, version string }
+	byKey := map[key]*lockedPackage{}
+	groups := map[*lockedPackage]map[string]bool{}
+	pkgs := []*lockedPackage{}
+	for _, category := range categories {
+		entries := map[string]pipfilePackage{}
+		if err := json.Unmarshal(raw[category], &entries); err != nil {
+			return nil, err
+		}
+		for name, p := range entries {
+			// Only the packages pinned to a version from a registry can be analysed
+			if !strings.HasPrefix(p.Version, "==") || p.Path != "" || p.File != "" || p.Git != "" || p.Editable {
+				continue
+			}
+			version := strings.TrimPrefix(p.Version, "==")
+			k := key{NormalizeName(name), version}
+			pkg, ok := byKey[k]
+			if !ok {
+				// Pipfile.lock only records the hashes, not the names, of the distribution files
+				pkg = &lockedPackage{name: name, version: version}
+				byKey[k] = pkg
+				groups[pkg] = map[string]bool{}
+				pkgs = append(pkgs, pkg)
+			}
+			groups[pkg][category] = true
+		}
+	}
+	for _, pkg := range pkgs {
+		pkg.setGroups(groups[pkg], pipfileMainGroup)
+	}
+
+	lock, err := newPoetryLockFromLocked(lockfile.PipfileLock, meta.Requires.PythonVersion, contentHash(meta.Hash.SHA256, b), pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pipfileLock{version: meta.PipfileSpec, convertedLock: convertedLock{lock}}, nil
+}
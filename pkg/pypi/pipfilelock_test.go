@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPipfileLockFromReader(t *testing.T) {
+	fixture, errFixture := os.Open("testdata/pipenv/Pipfile.lock")
+	require.Nil(t, errFixture)
+	defer fixture.Close()
+
+	lock, err := NewPipfileLockFromReader(fixture)
+	require.Nil(t, err)
+	require.IsType(t, &pipfileLock{}, lock)
+
+	assert.True(t, lock.Ok())
+	assert.Equal(t, 6, lock.Version())
+	assert.Equal(t, "5f0a2b3c4d6e8f1a9b7c5d3e1f2a4b6c8d0e9f7a5b3c1d2e4f6a8b0c9d7e5f3a", lock.ContentHash())
+
+	pkgs := lock.Packages()
+	require.Len(t, pkgs, 3)
+	assert.Equal(t, "2024.8.30", pkgs[0].Version)
+	assert.Empty(t, pkgs[0].Files)
+	assert.Equal(t, map[string][]string{
+		"certifi":   {"default", "develop"},
+		"iniconfig": {"develop"},
+		"requests":  {"default"},
+	}, lock.Groups())
+
+	require.Nil(t, lock.FilterOutByGroups("develop"))
+	assert.Len(t, lock.Packages(), 2)
+}
+
+func TestNewPipfileLockFromBytesErrors(t *testing.T) {
+	res, err := NewPipfileLockFromBytes([]byte(`{"default": {}}`))
+	assert.Nil(t, res)
+	if assert.Error(t, err) {
+		assert.Equal(t, "couldn't decode from the input Pipfile.lock contents: missing _meta", err.Error())
+	}
+}
@@ -16,13 +16,58 @@
 package pypi
 
 import (
+	"bytes"
 	"encoding/base64"
+	"fmt"
 
+	"github.com/listendev/lstn/pkg/lockfile"
 	"github.com/listendev/lstn/pkg/validate"
+	"github.com/pelletier/go-toml/v2"
 )
 
+// PoetryFile represents a distribution file of a package in a poetry.lock.
+type PoetryFile struct {
+	File string `name:"file" toml:"file" validate:"mandatory"`
+	Hash string `name:"hash" toml:"hash" validate:"mandatory,contains=:"`
+}
+
+// PoetryPackage represents a [[package]] entry of a poetry.lock.
+type PoetryPackage struct {
+	Name     string       `name:"name"     toml:"name"     validate:"mandatory"`
+	Version  string       `name:"version"  toml:"version"  validate:"mandatory"`
+	Optional bool         `name:"optional" toml:"optional"`
+	Category string       `name:"category" toml:"category"`
+	Groups   []string     `name:"groups"   toml:"groups"`
+	Files    []PoetryFile `name:"files"    toml:"files"    validate:"dive"`
+}
+
+// DependencyGroups returns the dependency groups the package belongs to.
+//
+// Poetry versions older than 1.5 write the category (ie., main, dev) of the package,
+// while Poetry 2 writes the list of its groups.
+// It returns nothing when the poetry.lock contains neither.
+func (p PoetryPackage) DependencyGroups() []string {
+	if len(p.Groups) > 0 {
+		return p.Groups
+	}
+	if p.Category != "" {
+		return []string{p.Category}
+	}
+
+	return nil
+}
+
+// PoetryMetadata represents the [metadata] table of a poetry.lock.
+type PoetryMetadata struct {
+	LockVersion    string `name:"lock version"    toml:"lock-version"    validate:"mandatory"`
+	PythonVersions string `name:"python versions" toml:"python-versions"`
+	ContentHash    string `name:"content hash"    toml:"content-hash"    validate:"mandatory,len=64,hexadecimal"`
+}
+
 type poetryLock struct {
-	bytes []byte
+	Package  []PoetryPackage `name:"packages" toml:"package"  validate:"dive"`
+	Metadata PoetryMetadata  `name:"metadata" toml:"metadata"`
+	bytes    []byte
 }
 
 // Encode encodes the receiving PoetryLock instance in a base64 string.
@@ -35,3 +80,97 @@ func (p *poetryLock) Ok() bool {
 
 	return err == nil
 }
+
+// Packages returns the packages locked by the poetry.lock.
+func (p *poetryLock) Packages() []PoetryPackage {
+	return p.Package
+}
+
+// ContentHash returns the hash of the pyproject.toml contents the poetry.lock got generated from.
+func (p *poetryLock) ContentHash() string {
+	return p.Metadata.ContentHash
+}
+
+// HasGroups tells whether the poetry.lock records the dependency groups of its packages.
+func (p *poetryLock) HasGroups() bool {
+	for _, pkg := range p.Package {
+		if len(pkg.DependencyGroups()) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Groups maps the names of the packages to their dependency groups, when known.
+func (p *poetryLock) Groups() map[string][]string {
+	ret := map[string][]string{}
+	for _, pkg := range p.Package {
+		if groups := pkg.DependencyGroups(); len(groups) > 0 {
+			ret[pkg.Name] = groups
+		}
+	}
+
+	return ret
+}
+
+// FilterOutByGroups removes the packages only belonging to the input dependency groups.
+//
+// It keeps the packages whose dependency groups are unknown.
+func (p *poetryLock) FilterOutByGroups(groups ...string) error {
+	if len(groups) == 0 {
+		return nil
+	}
+	ignore := map[string]bool{}
+	for _, g := range groups {
+		ignore[g] = true
+	}
+
+	keep := make([]bool, len(p.Package))
+	removed := false
+	for i, pkg := range p.Package {
+		pkgGroups := pkg.DependencyGroups()
+		keep[i] = len(pkgGroups) == 0
+		for _, g := range pkgGroups {
+			if !ignore[g] {
+				keep[i] = true
+
+				break
+			}
+		}
+		if !keep[i] {
+			removed = true
+		}
+	}
+	if !removed {
+		return nil
+	}
+
+	// Re-encode the poetry.lock without losing the fields we do not decode
+	raw := map[string]any{}
+	if err := toml.Unmarshal(p.bytes, &raw); err != nil {
+		return fmt.Errorf("couldn't decode from the input %s contents", lockfile.PoetryLock.String())
+	}
+	rawPackages, ok := raw["package"].([]any)
+	if !ok || len(rawPackages) != len(p.Package) {
+		return fmt.Errorf("couldn't decode from the input %s contents", lockfile.PoetryLock.String())
+	}
+	packages := []PoetryPackage{}
+	filtered := []any{}
+	for i, pkg := range p.Package {
+		if keep[i] {
+			packages = append(packages, pkg)
+			filtered = append(filtered, rawPackages[i])
+		}
+	}
+	raw["package"] = filtered
+
+	var b bytes.Buffer
+	if err := toml.NewEncoder(&b).Encode(raw); err != nil {
+		return fmt.Errorf("couldn't encode the filtered %s contents", lockfile.PoetryLock.String())
+	}
+	p.Package = packages
+	p.bytes = b.Bytes()
+
+	return nil
+}
@@ -16,8 +16,11 @@
 package pypi
 
 import (
+	"encoding/base64"
+	"path/filepath"
 	"testing"
 
+	"github.com/MakeNowJust/heredoc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -34,3 +37,128 @@ func (suite *PoetryLockSuite) TestEncode() {
 	i := &poetryLock{bytes: []byte("test")}
 	assert.Equal(suite.T(), "dGVzdA==", i.Encode())
 }
+
+func (suite *PoetryLockSuite) TestDecode() {
+	lock, err := GetPoetryLockFromDir(suite.testdata("groups"))
+	suite.Require().Nil(err)
+	suite.True(lock.Ok())
+	suite.Equal("0b9f6d9ed5e2a6f1d4ac5d1d5c7c2e9be0c8b5e7c9a3b1f7b4f2a3e1c5d6f7a8", lock.ContentHash())
+	suite.True(lock.HasGroups())
+
+	pkgs := lock.Packages()
+	suite.Require().Len(pkgs, 4)
+	suite.Equal("click", pkgs[0].Name)
+	suite.Equal("8.1.7", pkgs[0].Version)
+	suite.Equal([]string{"main"}, pkgs[0].DependencyGroups())
+	suite.Equal([]string{"main", "dev"}, pkgs[1].DependencyGroups())
+	suite.Equal(PoetryFile{File: "click-8.1.7.tar.gz", Hash: "sha256:ca9853ad459e787e2192211578cc907e7594e294c7ccc834310722b41b9ca6de"}, pkgs[0].Files[1])
+
+	legacy, err := GetPoetryLockFromDir(suite.testdata("category"))
+	suite.Require().Nil(err)
+	suite.True(legacy.Ok())
+	suite.Equal([]string{"dev"}, legacy.Packages()[1].DependencyGroups())
+
+	withoutGroups, err := GetPoetryLockFromDir(suite.testdata())
+	suite.Require().Nil(err)
+	suite.True(withoutGroups.Ok())
+	suite.False(withoutGroups.HasGroups())
+	suite.Nil(withoutGroups.Packages()[0].DependencyGroups())
+}
+
+func (suite *PoetryLockSuite) TestOk() {
+	cases := []struct {
+		desc  string
+		input string
+	}{
+		{
+			desc: "missing-metadata",
+			input: heredoc.Doc(`
+				[[package]]
+				name = "click"
+				version = "8.1.7"
+			`),
+		},
+		{
+			desc: "invalid-content-hash",
+			input: heredoc.Doc(`
+				[[package]]
+				name = "click"
+				version = "8.1.7"
+
+				[metadata]
+				lock-version = "2.0"
+				content-hash = "xyz"
+			`),
+		},
+		{
+			desc: "missing-version",
+			input: heredoc.Doc(`
+				[[package]]
+				name = "click"
+
+				[metadata]
+				lock-version = "2.0"
+				content-hash = "8e8064caee4be5e8c274c1bc101f33f6fc921f7dfeeba446fe0ad360d42c7db1"
+			`),
+		},
+		{
+			desc: "invalid-file-hash",
+			input: heredoc.Doc(`
+				[[package]]
+				name = "click"
+				version = "8.1.7"
+				files = [
+				    {file = "click-8.1.7.tar.gz", hash = ""},
+				]
+
+				[metadata]
+				lock-version = "2.0"
+				content-hash = "8e8064caee4be5e8c274c1bc101f33f6fc921f7dfeeba446fe0ad360d42c7db1"
+			`),
+		},
+	}
+
+	for _, tc := range cases {
+		suite.Run(tc.desc, func() {
+			lock, err := NewPoetryLockFromBytes([]byte(tc.input))
+			suite.Require().Nil(err)
+			suite.False(lock.Ok())
+		})
+	}
+}
+
+func (suite *PoetryLockSuite) TestFilterOutByGroups() {
+	lock, err := GetPoetryLockFromDir(suite.testdata("groups"))
+	suite.Require().Nil(err)
+
+	// Nothing to filter out
+	before := lock.Encode()
+	suite.Nil(lock.FilterOutByGroups())
+	suite.Nil(lock.FilterOutByGroups("docs"))
+	suite.Equal(before, lock.Encode())
+
+	suite.Nil(lock.FilterOutByGroups("dev", "lint"))
+	names := []string{}
+	for _, p := range lock.Packages() {
+		names = append(names, p.Name)
+	}
+	suite.Equal([]string{"click", "colorama"}, names)
+	suite.True(lock.Ok())
+
+	// The encoded poetry.lock contains the remaining packages only, with all their fields
+	b, err := base64.StdEncoding.DecodeString(lock.Encode())
+	suite.Require().Nil(err)
+	filtered, err := NewPoetryLockFromBytes(b)
+	suite.Require().Nil(err)
+	suite.True(filtered.Ok())
+	suite.Len(filtered.Packages(), 2)
+	suite.Contains(string(b), `platform_system == "Windows"`)
+	suite.Contains(string(b), "0b9f6d9ed5e2a6f1d4ac5d1d5c7c2e9be0c8b5e7c9a3b1f7b4f2a3e1c5d6f7a8")
+}
+
+func (suite *PoetryLockSuite) testdata(elem ...string) string {
+	dir, err := filepath.Abs(filepath.Join(append([]string{"testdata"}, elem...)...))
+	suite.Require().Nil(err)
+
+	return dir
+}
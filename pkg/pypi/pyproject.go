@@ -0,0 +1,283 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/XANi/goneric"
+	"github.com/listendev/lstn/pkg/ratelimit"
+)
+
+// MainGroup is the dependency group of the dependencies of the project (ie., not the development ones).
+const MainGroup = "main"
+
+type poetryGroup struct {
+	Dependencies map[string]any `toml:"dependencies"`
+}
+
+type pyprojectTOML struct {
+	Project struct {
+		Name                 string              `toml:"name"`
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	// DependencyGroups are the PEP 735 dependency groups
+	DependencyGroups map[string][]any `toml:"dependency-groups"`
+	Tool             struct {
+		Poetry struct {
+			Dependencies    map[string]any         `toml:"dependencies"`
+			DevDependencies map[string]any         `toml:"dev-dependencies"`
+			Group           map[string]poetryGroup `toml:"group"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+
+	// deps maps the dependency groups to the names of their dependencies and to their version constraints
+	deps map[string]map[string]string
+}
+
+// parsePEP508 parses the name and the version specifiers of a PEP 508 dependency specification.
+//
+// It returns false for the dependencies not coming from a registry (eg., name @ URL).
+func parsePEP508(spec string) (string, string, bool) {
+	if i := strings.Index(spec, ";"); i >= 0 {
+		spec = spec[:i]
+	}
+	m := requirementRe.FindStringSubmatch(strings.TrimSpace(spec))
+	if m == nil {
+		return "", "", false
+	}
+	constraint := strings.TrimSpace(m[3])
+	if strings.HasPrefix(constraint, "@") {
+		return "", "", false
+	}
+	constraint = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(constraint, "("), ")"))
+
+	return m[1], constraint, true
+}
+
+// poetryConstraint returns the version constraint of a Poetry dependency specification.
+//
+// It returns false for the dependencies not coming from a registry (eg., git, path, url).
+func poetryConstraint(spec any) (string, bool) {
+	switch s := spec.(type) {
+	case string:
+		return s, true
+	case map[string]any:
+		for _, k := range []string{"git", "path", "url"} {
+			if _, ok := s[k]; ok {
+				return "", false
+			}
+		}
+		if v, ok := s["version"].(string); ok {
+			return v, true
+		}
+
+		return "*", true
+	case []any:
+		// Multiple constraints dependencies (eg., depending on the Python version)
+		constraints := []string{}
+		for _, item := range s {
+			c, ok := poetryConstraint(item)
+			if !ok {
+				return "", false
+			}
+			constraints = append(constraints, c)
+		}
+
+		return strings.Join(constraints, " || "), len(constraints) > 0
+	}
+
+	return "", false
+}
+
+func (p *pyprojectTOML) add(group, name, constraint string) {
+	if p.deps[group] == nil {
+		p.deps[group] = map[string]string{}
+	}
+	// The PEP 621 specifications take precedence over the Poetry ones
+	if _, ok := p.deps[group][name]; ok {
+		return
+	}
+	p.deps[group][name] = constraint
+}
+
+// collect gathers the dependencies by group from the PEP 621, PEP 735, and Poetry tables.
+func (p *pyprojectTOML) collect() {
+	p.deps = map[string]map[string]string{}
+
+	addPEP508 := func(group string, specs []string) {
+		for _, spec := range specs {
+			if name, constraint, ok := parsePEP508(spec); ok {
+				p.add(group, name, constraint)
+			}
+		}
+	}
+	addPoetry := func(group string, deps map[string]any) {
+		for name, spec := range deps {
+			if group == MainGroup && name == "python" {
+				continue
+			}
+			if constraint, ok := poetryConstraint(spec); ok {
+				p.add(group, name, constraint)
+			}
+		}
+	}
+
+	addPEP508(MainGroup, p.Project.Dependencies)
+	for extra, specs := range p.Project.OptionalDependencies {
+		addPEP508(extra, specs)
+	}
+	for group, items := range p.DependencyGroups {
+		// Skip the includes of other groups (eg., {include-group = "test"})
+		specs := []string{}
+		for _, item := range items {
+			if spec, ok := item.(string); ok {
+				specs = append(specs, spec)
+			}
+		}
+		addPEP508(group, specs)
+	}
+	addPoetry(MainGroup, p.Tool.Poetry.Dependencies)
+	addPoetry("dev", p.Tool.Poetry.DevDependencies)
+	for group, g := range p.Tool.Poetry.Group {
+		addPoetry(group, g.Dependencies)
+	}
+}
+
+// Groups returns the sorted names of the dependency groups.
+func (p *pyprojectTOML) Groups() []string {
+	ret := make([]string, 0, len(p.deps))
+	for g := range p.deps {
+		ret = append(ret, g)
+	}
+	sort.Strings(ret)
+
+	return ret
+}
+
+func (p *pyprojectTOML) FilterOutByGroups(groups ...string) {
+	for _, g := range groups {
+		delete(p.deps, g)
+	}
+}
+
+func (p *pyprojectTOML) FilterOutByNames(names ...string) {
+	if len(names) == 0 {
+		return
+	}
+	ignore := map[string]bool{}
+	for _, n := range names {
+		ignore[NormalizeName(n)] = true
+	}
+	for group, deps := range p.deps {
+		for name := range deps {
+			if ignore[NormalizeName(name)] {
+				delete(deps, name)
+			}
+		}
+		if len(deps) == 0 {
+			delete(p.deps, group)
+		}
+	}
+}
+
+type pyDep struct {
+	name        string
+	constraint  string
+	version     *Version
+	constraints *Constraints
+	err         error
+}
+
+// ResolutionError is the error resolving a dependency against the PyPi registry.
+type ResolutionError struct {
+	Name string
+	// Constraint is the version constraint of the dependency in the pyproject.toml
+	Constraint string
+	// Group is the dependency group
+	Group string
+	Err   error
+}
+
+func (e *ResolutionError) Error() string {
+	return strings.TrimSpace(fmt.Sprintf("couldn't resolve %s %s", e.Name, e.Constraint)) + ": " + e.Err.Error()
+}
+
+func (e *ResolutionError) Unwrap() error {
+	return e.Err
+}
+
+// Deps resolves the dependencies against the PyPi registry.
+//
+// It returns the resolved versions by dependency group, and the errors resolving the dependencies.
+func (p *pyprojectTOML) Deps(ctx context.Context, resolve VersionResolutionStrategy) (map[string]map[string]*Version, []*ResolutionError) {
+	ret := map[string]map[string]*Version{}
+	errs := []*ResolutionError{}
+	for _, group := range p.Groups() {
+		deps := []*pyDep{}
+		for name, constraint := range p.deps[group] {
+			constraints, err := NewConstraints(constraint)
+			if err != nil {
+				errs = append(errs, &ResolutionError{Name: name, Constraint: constraint, Group: group, Err: err})
+
+				continue
+			}
+			deps = append(deps, &pyDep{name: name, constraint: constraint, constraints: constraints})
+		}
+
+		// Resolve version constraints with parallel requests to the registry
+		resolutions := goneric.ParallelMapSlice(func(input *pyDep) *pyDep {
+			ret := &pyDep{name: input.name, constraint: input.constraint}
+
+			collect, err := GetVersionsFromRegistry(ctx, input.name, input.constraints)
+			if err != nil {
+				ret.err = err
+
+				return ret
+			}
+			if ret.version = resolve(input.name, collect); ret.version == nil {
+				ret.err = fmt.Errorf("no version of %s satisfies %s", input.name, input.constraint)
+			}
+
+			return ret
+		}, ratelimit.Concurrency(ctx), deps)
+
+		for _, res := range resolutions {
+			if res.err != nil {
+				errs = append(errs, &ResolutionError{Name: res.name, Constraint: res.constraint, Group: group, Err: res.err})
+
+				continue
+			}
+			if _, ok := ret[group]; !ok {
+				ret[group] = map[string]*Version{}
+			}
+			ret[group][res.name] = res.version
+		}
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Group != errs[j].Group {
+			return errs[i].Group < errs[j].Group
+		}
+
+		return errs[i].Name < errs[j].Name
+	})
+
+	return ret, errs
+}
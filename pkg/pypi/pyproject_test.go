@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getPyprojectFromTestdata(t *testing.T) *pyprojectTOML {
+	t.Helper()
+
+	dir, err := filepath.Abs("testdata/pyproject")
+	require.Nil(t, err)
+	p, err := GetPyprojectTOMLFromDir(dir)
+	require.Nil(t, err)
+	ret, ok := p.(*pyprojectTOML)
+	require.True(t, ok)
+
+	return ret
+}
+
+func TestPyprojectTOMLCollect(t *testing.T) {
+	p := getPyprojectFromTestdata(t)
+
+	assert.Equal(t, []string{"dev", "docs", "lint", "main", "test"}, p.Groups())
+	assert.Equal(t, map[string]string{
+		"requests": ">=2.31,<3",
+		"Flask":    "~=3.0",
+		"rich":     "",
+		"httpx":    "^0.27",
+		"numpy":    "^1.26 || ^2.1",
+	}, p.deps[MainGroup])
+	assert.Equal(t, map[string]string{"mkdocs": "==1.6.1"}, p.deps["docs"])
+	assert.Equal(t, map[string]string{"ruff": ">=0.5"}, p.deps["lint"])
+	assert.Equal(t, map[string]string{"pytest": ">=8"}, p.deps["test"])
+	assert.Equal(t, map[string]string{"black": "*"}, p.deps["dev"])
+}
+
+func TestPyprojectTOMLFilterOut(t *testing.T) {
+	p := getPyprojectFromTestdata(t)
+
+	p.FilterOutByGroups("dev", "lint", "unknown")
+	assert.Equal(t, []string{"docs", "main", "test"}, p.Groups())
+
+	p.FilterOutByNames("flask", "MkDocs")
+	assert.Equal(t, []string{"main", "test"}, p.Groups())
+	assert.NotContains(t, p.deps[MainGroup], "Flask")
+}
+
+func TestNewPyprojectTOMLFromReaderError(t *testing.T) {
+	_, err := NewPyprojectTOMLFromReader(strings.NewReader("[project"))
+	if assert.Error(t, err) {
+		assert.Equal(t, "couldn't instantiate from the input pyproject.toml contents", err.Error())
+	}
+}
+
+func TestPyprojectTOMLDeps(t *testing.T) {
+	releases := map[string]string{
+		"requests": `"2.30.0": [{}], "2.32.3": [{}], "3.0.0": [{}]`,
+		"flask":    `"2.3.3": [{}], "3.0.3": [{}], "3.1.0": [{}]`,
+		"pytest":   `"8.3.4": [{}], "9.0.0a1": [{}]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/pypi/"), "/json")
+		rel, ok := releases[strings.ToLower(name)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		fmt.Fprintf(w, `{"releases": {%s}}`, rel)
+	}))
+	defer server.Close()
+
+	cfg, err := flags.NewConfigFlags()
+	require.Nil(t, err)
+	cfg.Registry.PyPi = server.URL
+	ctx := context.WithValue(t.Context(), pkgcontext.ConfigKey, cfg)
+
+	p := getPyprojectFromTestdata(t)
+	p.FilterOutByGroups("dev", "docs", "lint")
+	deps, errs := p.Deps(ctx, DefaultVersionResolutionStrategy)
+
+	got := map[string]map[string]string{}
+	for group, versions := range deps {
+		got[group] = map[string]string{}
+		for name, v := range versions {
+			got[group][name] = v.String()
+		}
+	}
+	assert.Equal(t, map[string]map[string]string{
+		MainGroup: {"requests": "2.32.3", "Flask": "3.1.0"},
+		"test":    {"pytest": "8.3.4"},
+	}, got)
+
+	// The packages missing from the registry
+	gotErrs := []string{}
+	for _, e := range errs {
+		assert.Equal(t, MainGroup, e.Group)
+		gotErrs = append(gotErrs, e.Error())
+	}
+	assert.Equal(t, []string{
+		fmt.Sprintf("couldn't resolve httpx ^0.27: package httpx doesn't exist on registry %s", server.URL),
+		fmt.Sprintf("couldn't resolve numpy ^1.26 || ^2.1: package numpy doesn't exist on registry %s", server.URL),
+		fmt.Sprintf("couldn't resolve rich: package rich doesn't exist on registry %s", server.URL),
+	}, gotErrs)
+}
@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/listendev/lstn/pkg/lockfile"
+)
+
+var (
+	requirementRe = regexp.MustCompile(`^([A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?)\s*(\[[^\]]*\])?\s*(.*)$`)
+	hashOptionRe  = regexp.MustCompile(`--hash[=\s]\s*(\S+)`)
+	pinRe         = regexp.MustCompile(`^===?\s*([^\s,*]+)$`)
+)
+
+// requirementsOpener opens the requirements file included by the one in the input directory.
+type requirementsOpener func(dir, name string) (io.Reader, error)
+
+// requirement represents a line of a requirements file.
+type requirement struct {
+	name    string
+	version string
+	// source is the file name and line number of the requirement
+	source string
+	// line is the requirement as written in the requirements file (without comments and hashes)
+	line string
+	// hashes are the hashes (eg., sha256:...) of the distribution files the requirement allows
+	hashes []string
+}
+
+type requirementsTxt struct {
+	warnings []string
+	convertedLock
+}
+
+// Warnings returns the lines of the requirements files that lstn did not process (eg., unpinned requirements).
+func (r *requirementsTxt) Warnings() []string {
+	return r.warnings
+}
+
+// requirementsParser collects the requirements from a requirements file and the ones it includes.
+type requirementsParser struct {
+	open        requirementsOpener
+	visited     map[string]bool
+	requires    []requirement
+	constraints map[string]string
+	warnings    []string
+}
+
+// logicalLines splits the input contents into lines,
+// joining the ones continuing with a trailing backslash, and removing the comments.
+//
+// It returns the lines along with their (first) line numbers.
+func logicalLines(contents string) ([]string, []int) {
+	lines := []string{}
+	numbers := []int{}
+
+	current := ""
+	start := 0
+	for i, line := range strings.Split(strings.ReplaceAll(contents, "\r\n", "\n"), "\n") {
+		if current == "" {
+			start = i + 1
+		}
+		if strings.HasSuffix(line, "\\") {
+			current += strings.TrimSuffix(line, "\\") + " "
+
+			continue
+		}
+		current += line
+
+		// Comments start with a # at the line beginning or after a whitespace
+		if strings.HasPrefix(current, "#") {
+			current = ""
+		} else if i := strings.Index(current, " #"); i >= 0 {
+			current = current[:i]
+		} else if i := strings.Index(current, "\t#"); i >= 0 {
+			current = current[:i]
+		}
+		if trimmed := strings.TrimSpace(current); trimmed != "" {
+			lines = append(lines, trimmed)
+			numbers = append(numbers, start)
+		}
+		current = ""
+	}
+	if trimmed := strings.TrimSpace(current); trimmed != "" {
+		lines = append(lines, trimmed)
+		numbers = append(numbers, start)
+	}
+
+	return lines, numbers
+}
+
+// optionValue returns the value of the input option (eg., -r) when the line starts with it.
+func optionValue(line string, short, long string) (string, bool) {
+	for _, opt := range []string{short, long} {
+		if opt == "" || !strings.HasPrefix(line, opt) {
+			continue
+		}
+		rest := line[len(opt):]
+		switch {
+		case strings.HasPrefix(rest, "="):
+			return strings.TrimSpace(rest[1:]), true
+		case strings.HasPrefix(rest, " "), strings.HasPrefix(rest, "\t"):
+			return strings.TrimSpace(rest), true
+		case rest != "" && len(opt) == 2:
+			// Short options can stick to their value (eg., -rbase.txt)
+			return strings.TrimSpace(rest), true
+		}
+	}
+
+	return "", false
+}
+
+// parseRequirement parses a requirement specifier (eg., requests[socks]==2.32.3 ; python_version >= "3.8").
+//
+// It returns an empty version when the requirement is not pinned to an exact version.
+func parseRequirement(line string) (requirement, bool) {
+	req := requirement{}
+	for _, m := range hashOptionRe.FindAllStringSubmatch(line, -1) {
+		req.hashes = append(req.hashes, m[1])
+	}
+	line = strings.TrimSpace(hashOptionRe.ReplaceAllString(line, ""))
+
+	// Drop the environment markers: we cannot evaluate them, so we keep the requirement anyway
+	spec := line
+	if i := strings.Index(spec, ";"); i >= 0 {
+		spec = strings.TrimSpace(spec[:i])
+	}
+	// Drop the per-requirement options (eg., --config-settings)
+	if i := strings.Index(spec, " --"); i >= 0 {
+		spec = strings.TrimSpace(spec[:i])
+	}
+	req.line = spec
+
+	m := requirementRe.FindStringSubmatch(spec)
+	if m == nil {
+		return req, false
+	}
+	req.name = m[1]
+
+	constraint := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(m[3]), "("), ")"))
+	if pin := pinRe.FindStringSubmatch(constraint); pin != nil {
+		req.version = pin[1]
+	}
+
+	return req, true
+}
+
+// parse collects the requirements of the input file.
+//
+// When constraints is true it collects the version pins as constraints rather than requirements.
+func (p *requirementsParser) parse(dir, name string, contents []byte, constraints bool) {
+	lines, numbers := logicalLines(string(contents))
+	for i, line := range lines {
+		source := fmt.Sprintf("%s:%d", name, numbers[i])
+
+		if include, ok := optionValue(line, "-r", "--requirement"); ok {
+			p.include(dir, name, source, include, constraints)
+
+			continue
+		}
+		if include, ok := optionValue(line, "-c", "--constraint"); ok {
+			p.include(dir, name, source, include, true)
+
+			continue
+		}
+		if editable, ok := optionValue(line, "-e", "--editable"); ok {
+			p.warnings = append(p.warnings, fmt.Sprintf("%s: skipping the editable requirement %s", source, editable))
+
+			continue
+		}
+		if strings.HasPrefix(line, "-") {
+			// Global options (eg., --index-url, --require-hashes) do not affect what gets installed
+			continue
+		}
+
+		req, ok := parseRequirement(line)
+		if !ok || strings.Contains(req.line, "://") {
+			p.warnings = append(p.warnings, fmt.Sprintf("%s: skipping the requirement %s: not from a registry", source, line))
+
+			continue
+		}
+		req.source = source
+		if constraints {
+			if req.version != "" {
+				p.constraints[NormalizeName(req.name)] = req.version
+			}
+
+			continue
+		}
+		p.requires = append(p.requires, req)
+	}
+}
+
+// include parses the requirements file the one in the input directory includes.
+func (p *requirementsParser) include(dir, from, source, name string, constraints bool) {
+	if p.open == nil || dir == "" {
+		p.warnings = append(p.warnings, fmt.Sprintf("%s: skipping the included file %s: unknown directory of %s", source, name, from))
+
+		return
+	}
+	if strings.Contains(name, "://") {
+		p.warnings = append(p.warnings, fmt.Sprintf("%s: skipping the included file %s: not a local file", source, name))
+
+		return
+	}
+
+	full := name
+	if !filepath.IsAbs(full) {
+		full = filepath.Join(dir, name)
+	}
+	if p.visited[full] {
+		return
+	}
+	p.visited[full] = true
+
+	reader, err := p.open(filepath.Dir(full), filepath.Base(full))
+	if err != nil {
+		p.warnings = append(p.warnings, fmt.Sprintf("%s: skipping the included file %s: %s", source, name, err.Error()))
+
+		return
+	}
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		p.warnings = append(p.warnings, fmt.Sprintf("%s: skipping the included file %s: %s", source, name, err.Error()))
+
+		return
+	}
+
+	p.parse(filepath.Dir(full), name, contents, constraints)
+}
+
+// newRequirementsTxt creates a poetry.lock equivalent to the pinned requirements of the input requirements.txt contents.
+//
+// It follows the -r and -c includes relative to the input directory, when the opener is not nil.
+func newRequirementsTxt(b []byte, dir string, open requirementsOpener) (*requirementsTxt, error) {
+	p := &requirementsParser{
+		open:        open,
+		visited:     map[string]bool{},
+		constraints: map[string]string{},
+	}
+	if dir != "" {
+		p.visited[filepath.Join(dir, lockfile.RequirementsTxt.String())] = true
+	}
+	p.parse(dir, lockfile.RequirementsTxt.String(), b, false)
+
+	type key struct{ name, version string }
+	byKey := map[key]*lockedPackage{}
+	pkgs := []*lockedPackage{}
+	for _, req := range p.requires {
+		version := req.version
+		if version == "" {
+			version = p.constraints[NormalizeName(req.name)]
+		}
+		if version == "" {
+			p.warnings = append(p.warnings, fmt.Sprintf("%s: skipping the requirement %s: not pinned to a version", req.source, req.line))
+
+			continue
+		}
+
+		k := key{NormalizeName(req.name), version}
+		pkg, ok := byKey[k]
+		if !ok {
+			pkg = &lockedPackage{name: req.name, version: version}
+			byKey[k] = pkg
+			pkgs = append(pkgs, pkg)
+		}
+		// Requirements files only record the hashes, not the names, of the distribution files:
+		// so the files get named after the package
+		for _, hash := range req.hashes {
+			if !slices.ContainsFunc(pkg.files, func(f PoetryFile) bool { return f.Hash == hash }) {
+				pkg.files = append(pkg.files, PoetryFile{File: fmt.Sprintf("%s-%s", req.name, version), Hash: hash})
+			}
+		}
+	}
+
+	lock, err := newPoetryLockFromLocked(lockfile.RequirementsTxt, "", contentHash("", b), pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &requirementsTxt{warnings: p.warnings, convertedLock: convertedLock{lock}}, nil
+}
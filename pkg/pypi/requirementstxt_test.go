@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRequirementsTxtFromDir(t *testing.T) {
+	dir, err := filepath.Abs("testdata/requirements")
+	require.Nil(t, err)
+
+	lock, err := GetRequirementsTxtFromDir(dir)
+	require.Nil(t, err)
+	require.IsType(t, &requirementsTxt{}, lock)
+
+	assert.True(t, lock.Ok())
+	assert.False(t, lock.HasGroups())
+
+	versions := map[string]string{}
+	for _, p := range lock.Packages() {
+		versions[p.Name] = p.Version
+	}
+	assert.Equal(t, map[string]string{
+		"certifi":  "2024.8.30",
+		"click":    "8.1.7",
+		"colorama": "0.4.6",
+		"requests": "2.32.3",
+		"urllib3":  "2.2.3",
+	}, versions)
+
+	files := map[string][]PoetryFile{}
+	for _, p := range lock.Packages() {
+		files[p.Name] = p.Files
+	}
+	assert.Equal(t, []PoetryFile{
+		{File: "certifi-2024.8.30", Hash: "sha256:922820b53db7a7257ffbda3f597266d435245903d80737e34f8a45ff3e3230d8"},
+		{File: "certifi-2024.8.30", Hash: "sha256:bec941d2aa8195e248a60b31ff9f0558284cf01a52591ceda73ea9afffd69fd9"},
+	}, files["certifi"])
+
+	assert.Equal(t, []string{
+		"requirements.txt:25: skipping the editable requirement ./libs/local-utils",
+		"requirements.txt:26: skipping the requirement mylib @ https://example.com/mylib-1.0.0.tar.gz: not from a registry",
+		"requirements.txt:24: skipping the requirement rich>=13: not pinned to a version",
+	}, lock.Warnings())
+}
+
+func TestNewRequirementsTxtFromBytes(t *testing.T) {
+	lock, err := NewRequirementsTxtFromBytes([]byte(heredoc.Doc(`
+		-r base.txt
+		Django==4.2.16 --hash=sha256:1ddc333a16fc139fd253035a1606bb24261951bbc3a6ca256717fa06cc41a898
+		django_filter === 24.3
+	`)))
+	require.Nil(t, err)
+	assert.True(t, lock.Ok())
+	assert.Len(t, lock.Packages(), 2)
+	assert.Equal(t, []string{"requirements.txt:1: skipping the included file base.txt: unknown directory of requirements.txt"}, lock.Warnings())
+
+	// Unpinned requirements are warnings, not failures
+	lock, err = NewRequirementsTxtFromBytes([]byte("requests>=2\n"))
+	require.Nil(t, err)
+	assert.True(t, lock.Ok())
+	assert.Empty(t, lock.Packages())
+	assert.Equal(t, []string{"requirements.txt:1: skipping the requirement requests>=2: not pinned to a version"}, lock.Warnings())
+}
+
+func TestParseRequirement(t *testing.T) {
+	tests := []struct {
+		input   string
+		name    string
+		version string
+		ok      bool
+	}{
+		{"requests==2.32.3", "requests", "2.32.3", true},
+		{"requests [socks] == 2.32.3 ; python_version >= \"3.8\"", "requests", "2.32.3", true},
+		{"zope.interface===6.4.post2 --hash=sha256:abc", "zope.interface", "6.4.post2", true},
+		{"requests (==2.32.3)", "requests", "2.32.3", true},
+		{"requests==2.*", "requests", "", true},
+		{"requests>=2,<3", "requests", "", true},
+		{"./local", "", "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			req, ok := parseRequirement(tc.input)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.name, req.name)
+			assert.Equal(t, tc.version, req.version)
+		})
+	}
+}
@@ -17,17 +17,63 @@ package pypi
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 
 	"github.com/listendev/lstn/pkg/fs"
 	listentype "github.com/listendev/lstn/pkg/listen/type"
-	"github.com/listendev/pkg/lockfile"
+	"github.com/listendev/lstn/pkg/lockfile"
 	"github.com/pelletier/go-toml/v2"
 )
 
+// PyprojectFilename is the name of the file declaring the Python project and its dependencies.
+const PyprojectFilename = "pyproject.toml"
+
+// PyprojectTOML is a pyproject.toml declaring the direct dependencies of a Python project,
+// both in the PEP 621 (and PEP 735) tables and in the Poetry ones.
+type PyprojectTOML interface {
+	Groups() []string
+	FilterOutByGroups(...string)
+	FilterOutByNames(...string)
+	Deps(context.Context, VersionResolutionStrategy) (map[string]map[string]*Version, []*ResolutionError)
+}
+
+// The VersionResolutionStrategy is a function that, given the name of a package
+// and its versions satisfying some constraints, returns back an exact version.
+type VersionResolutionStrategy func(string, Versions) *Version
+
 type PoetryLock interface {
 	listentype.AnalysisRequester
+	Packages() []PoetryPackage
+	ContentHash() string
+	HasGroups() bool
+	Groups() map[string][]string
+	FilterOutByGroups(...string) error
+}
+
+// UvLock is a uv.lock converted into its equivalent poetry.lock.
+type UvLock interface {
+	PoetryLock
+	Version() int
+}
+
+// PdmLock is a pdm.lock converted into its equivalent poetry.lock.
+type PdmLock interface {
+	PoetryLock
+	Version() string
+}
+
+// PipfileLock is a Pipfile.lock converted into its equivalent poetry.lock.
+type PipfileLock interface {
+	PoetryLock
+	Version() int
+}
+
+// RequirementsTxt is a pinned requirements.txt converted into its equivalent poetry.lock.
+type RequirementsTxt interface {
+	PoetryLock
+	Warnings() []string
 }
 
 func NewPoetryLockFromBytes(b []byte) (PoetryLock, error) {
@@ -45,7 +91,6 @@ func NewPoetryLockFromReader(reader io.Reader) (PoetryLock, error) {
 	ret := &poetryLock{}
 	var b bytes.Buffer
 	r := io.TeeReader(reader, &b)
-	// NOTE: we aren't actually verify the poetry.lock is well-formed, just decoding it from TOML
 	if err := toml.NewDecoder(r).Decode(ret); err != nil {
 		return nil, fmt.Errorf("couldn't decode from the input %s contents", lockfile.PoetryLock.String())
 	}
@@ -63,3 +108,151 @@ func GetPoetryLockFromDir(dir string) (PoetryLock, error) {
 
 	return NewPoetryLockFromReader(reader)
 }
+
+// NewUvLockFromReader creates a UvLock instance from by reading the contents of a uv.lock file.
+func NewUvLockFromReader(reader io.Reader) (UvLock, error) {
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the input %s contents", lockfile.UvLock.String())
+	}
+
+	return NewUvLockFromBytes(b)
+}
+
+func NewUvLockFromBytes(b []byte) (UvLock, error) {
+	ret, err := newUvLock(b)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode from the input %s contents: %w", lockfile.UvLock.String(), err)
+	}
+
+	return ret, nil
+}
+
+// GetUvLockFromDir creates a UvLock instance from the existing uv.lock in dir, if any.
+func GetUvLockFromDir(dir string) (UvLock, error) {
+	reader, err := fs.Read(dir, lockfile.UvLock.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUvLockFromReader(reader)
+}
+
+// NewPdmLockFromReader creates a PdmLock instance from by reading the contents of a pdm.lock file.
+func NewPdmLockFromReader(reader io.Reader) (PdmLock, error) {
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the input %s contents", lockfile.PdmLock.String())
+	}
+
+	return NewPdmLockFromBytes(b)
+}
+
+func NewPdmLockFromBytes(b []byte) (PdmLock, error) {
+	ret, err := newPdmLock(b)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode from the input %s contents: %w", lockfile.PdmLock.String(), err)
+	}
+
+	return ret, nil
+}
+
+// GetPdmLockFromDir creates a PdmLock instance from the existing pdm.lock in dir, if any.
+func GetPdmLockFromDir(dir string) (PdmLock, error) {
+	reader, err := fs.Read(dir, lockfile.PdmLock.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPdmLockFromReader(reader)
+}
+
+// NewPipfileLockFromReader creates a PipfileLock instance from by reading the contents of a Pipfile.lock file.
+func NewPipfileLockFromReader(reader io.Reader) (PipfileLock, error) {
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the input %s contents", lockfile.PipfileLock.String())
+	}
+
+	return NewPipfileLockFromBytes(b)
+}
+
+func NewPipfileLockFromBytes(b []byte) (PipfileLock, error) {
+	ret, err := newPipfileLock(b)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode from the input %s contents: %w", lockfile.PipfileLock.String(), err)
+	}
+
+	return ret, nil
+}
+
+// GetPipfileLockFromDir creates a PipfileLock instance from the existing Pipfile.lock in dir, if any.
+func GetPipfileLockFromDir(dir string) (PipfileLock, error) {
+	reader, err := fs.Read(dir, lockfile.PipfileLock.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPipfileLockFromReader(reader)
+}
+
+// NewRequirementsTxtFromReader creates a RequirementsTxt instance from by reading the contents of a requirements.txt file.
+//
+// Since it does not know the directory of the requirements.txt, it skips the files it includes.
+func NewRequirementsTxtFromReader(reader io.Reader) (RequirementsTxt, error) {
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the input %s contents", lockfile.RequirementsTxt.String())
+	}
+
+	return NewRequirementsTxtFromBytes(b)
+}
+
+func NewRequirementsTxtFromBytes(b []byte) (RequirementsTxt, error) {
+	return newRequirementsTxtFromBytes(b, "")
+}
+
+func newRequirementsTxtFromBytes(b []byte, dir string) (RequirementsTxt, error) {
+	ret, err := newRequirementsTxt(b, dir, fs.Read)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode from the input %s contents: %w", lockfile.RequirementsTxt.String(), err)
+	}
+
+	return ret, nil
+}
+
+// GetRequirementsTxtFromDir creates a RequirementsTxt instance from the existing requirements.txt in dir, if any.
+//
+// It also processes the requirements and the constraints files it includes (-r, -c).
+func GetRequirementsTxtFromDir(dir string) (RequirementsTxt, error) {
+	reader, err := fs.Read(dir, lockfile.RequirementsTxt.String())
+	if err != nil {
+		return nil, err
+	}
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the input %s contents", lockfile.RequirementsTxt.String())
+	}
+
+	return newRequirementsTxtFromBytes(b, dir)
+}
+
+// GetPyprojectTOMLFromDir creates a PyprojectTOML instance from the existing pyproject.toml in dir, if any.
+func GetPyprojectTOMLFromDir(dir string) (PyprojectTOML, error) {
+	reader, err := fs.Read(dir, PyprojectFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPyprojectTOMLFromReader(reader)
+}
+
+func NewPyprojectTOMLFromReader(reader io.Reader) (PyprojectTOML, error) {
+	ret := &pyprojectTOML{}
+	if err := toml.NewDecoder(reader).Decode(ret); err != nil {
+		return nil, fmt.Errorf("couldn't instantiate from the input %s contents", PyprojectFilename)
+	}
+	ret.collect()
+
+	return ret, nil
+}
@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/listendev/lstn/pkg/lockfile"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// uvMainGroup is the group name of the dependencies of the project (ie., not the development ones).
+const uvMainGroup = "main"
+
+type uvDependency struct {
+	Name    string   `toml:"name"`
+	Version string   `toml:"version"`
+	Extra   []string `toml:"extra"`
+}
+
+type uvSource struct {
+	Registry  string `toml:"registry"`
+	Editable  string `toml:"editable"`
+	Virtual   string `toml:"virtual"`
+	Directory string `toml:"directory"`
+	Path      string `toml:"path"`
+	Git       string `toml:"git"`
+	URL       string `toml:"url"`
+}
+
+type uvArtifact struct {
+	URL  string `toml:"url"`
+	Path string `toml:"path"`
+	Hash string `toml:"hash"`
+}
+
+type uvPackage struct {
+	Name                 string                    `toml:"name"`
+	Version              string                    `toml:"version"`
+	Source               uvSource                  `toml:"source"`
+	Dependencies         []uvDependency            `toml:"dependencies"`
+	OptionalDependencies map[string][]uvDependency `toml:"optional-dependencies"`
+	DevDependencies      map[string][]uvDependency `toml:"dev-dependencies"`
+	Sdist                *uvArtifact               `toml:"sdist"`
+	Wheels               []uvArtifact              `toml:"wheels"`
+}
+
+type uvLockFile struct {
+	Version        int         `toml:"version"`
+	RequiresPython string      `toml:"requires-python"`
+	Package        []uvPackage `toml:"package"`
+}
+
+type uvLock struct {
+	version int
+	convertedLock
+}
+
+// Version returns the version of the uv.lock format.
+func (u *uvLock) Version() int {
+	return u.version
+}
+
+// isProject tells whether the package is the project (or a workspace member) rather than a dependency.
+func (p *uvPackage) isProject() bool {
+	return p.Source.Editable != "" || p.Source.Virtual != ""
+}
+
+// files returns the distribution files of the package.
+func (p *uvPackage) files() []PoetryFile {
+	artifacts := p.Wheels
+	if p.Sdist != nil {
+		artifacts = append(append([]uvArtifact{}, p.Wheels...), *p.Sdist)
+	}
+	ret := []PoetryFile{}
+	for _, a := range artifacts {
+		name := a.URL
+		if name == "" {
+			name = a.Path
+		}
+		if name == "" || a.Hash == "" {
+			continue
+		}
+		ret = append(ret, PoetryFile{File: path.Base(name), Hash: a.Hash})
+	}
+
+	return ret
+}
+
+func newUvLock(b []byte) (*uvLock, error) {
+	file := uvLockFile{}
+	if err := toml.Unmarshal(b, &file); err != nil {
+		return nil, err
+	}
+	if file.Version == 0 {
+		return nil, fmt.Errorf("missing version")
+	}
+
+	byName := map[string][]*uvPackage{}
+	for i := range file.Package {
+		p := &file.Package[i]
+		name := NormalizeName(p.Name)
+		byName[name] = append(byName[name], p)
+	}
+	resolve := func(dep uvDependency) *uvPackage {
+		candidates := byName[NormalizeName(dep.Name)]
+		for _, c := range candidates {
+			if dep.Version == "" || c.Version == dep.Version {
+				return c
+			}
+		}
+
+		return nil
+	}
+
+	// Propagate the dependency groups from the project to its transitive dependencies
+	groups := map[*uvPackage]map[string]bool{}
+	seen := map[*uvPackage]map[string]bool{}
+	var visit func(dep uvDependency, group string)
+	visit = func(dep uvDependency, group string) {
+		p := resolve(dep)
+		if p == nil || p.isProject() {
+			return
+		}
+		if groups[p] == nil {
+			groups[p] = map[string]bool{}
+			seen[p] = map[string]bool{}
+		}
+		groups[p][group] = true
+		if !seen[p][group] {
+			seen[p][group] = true
+			for _, d := range p.Dependencies {
+				visit(d, group)
+			}
+		}
+		for _, e := range dep.Extra {
+			if key := group + "[" + e + "]"; !seen[p][key] {
+				seen[p][key] = true
+				for _, d := range p.OptionalDependencies[e] {
+					visit(d, group)
+				}
+			}
+		}
+	}
+	for i := range file.Package {
+		p := &file.Package[i]
+		if !p.isProject() {
+			continue
+		}
+		for _, d := range p.Dependencies {
+			visit(d, uvMainGroup)
+		}
+		extras := make([]string, 0, len(p.OptionalDependencies))
+		for e := range p.OptionalDependencies {
+			extras = append(extras, e)
+		}
+		sort.Strings(extras)
+		for _, e := range extras {
+			for _, d := range p.OptionalDependencies[e] {
+				visit(d, uvMainGroup)
+			}
+		}
+		devGroups := make([]string, 0, len(p.DevDependencies))
+		for g := range p.DevDependencies {
+			devGroups = append(devGroups, g)
+		}
+		sort.Strings(devGroups)
+		for _, g := range devGroups {
+			for _, d := range p.DevDependencies[g] {
+				visit(d, g)
+			}
+		}
+	}
+
+	pkgs := []*lockedPackage{}
+	for i := range file.Package {
+		p := &file.Package[i]
+		// Only the packages coming from a registry can be analysed
+		if p.Source.Registry == "" || p.Version == "" {
+			continue
+		}
+		pkg := &lockedPackage{
+			name:    p.Name,
+			version: p.Version,
+			files:   p.files(),
+		}
+		pkg.setGroups(groups[p], uvMainGroup)
+		pkgs = append(pkgs, pkg)
+	}
+
+	lock, err := newPoetryLockFromLocked(lockfile.UvLock, file.RequiresPython, contentHash("", b), pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &uvLock{version: file.Version, convertedLock: convertedLock{lock}}, nil
+}
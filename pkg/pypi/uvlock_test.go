@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"encoding/base64"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUvLockFromReader(t *testing.T) {
+	fixture, errFixture := os.Open("testdata/uv/uv.lock")
+	require.Nil(t, errFixture)
+	defer fixture.Close()
+
+	lock, err := NewUvLockFromReader(fixture)
+	require.Nil(t, err)
+	require.IsType(t, &uvLock{}, lock)
+
+	assert.True(t, lock.Ok())
+	assert.Equal(t, 1, lock.Version())
+	assert.Len(t, lock.ContentHash(), 64)
+
+	pkgs := lock.Packages()
+	names := []string{}
+	for _, p := range pkgs {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"certifi", "iniconfig", "pysocks", "pytest", "requests"}, names)
+	assert.Equal(t, map[string][]string{
+		"certifi":   {"main"},
+		"iniconfig": {"dev"},
+		"pysocks":   {"main"},
+		"pytest":    {"dev"},
+		"requests":  {"main"},
+	}, lock.Groups())
+	assert.Equal(t, PoetryFile{File: "PySocks-1.7.1-py3-none-any.whl", Hash: "sha256:2725bd0a9925919b9b51739eea5f9e2bae91e83288108a9ad338b2e3a4435ee5"}, pkgs[2].Files[0])
+	assert.Len(t, pkgs[2].Files, 2)
+
+	raw, err := base64.StdEncoding.DecodeString(lock.Encode())
+	require.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(raw), "# This file is automatically @generated from a uv.lock"))
+
+	require.Nil(t, lock.FilterOutByGroups("dev"))
+	assert.Len(t, lock.Packages(), 3)
+
+	b, err := base64.StdEncoding.DecodeString(lock.Encode())
+	require.Nil(t, err)
+	converted, err := NewPoetryLockFromBytes(b)
+	require.Nil(t, err)
+	assert.True(t, converted.Ok())
+	assert.Equal(t, lock.ContentHash(), converted.ContentHash())
+	assert.NotContains(t, string(b), "pytest")
+}
+
+func TestNewUvLockFromBytesErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		input   string
+		wantErr string
+	}{
+		{
+			desc:    "not-toml",
+			input:   "{}",
+			wantErr: "couldn't decode from the input uv.lock contents: toml: ",
+		},
+		{
+			desc:    "missing-version",
+			input:   "requires-python = \">=3.12\"\n",
+			wantErr: "couldn't decode from the input uv.lock contents: missing version",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := NewUvLockFromBytes([]byte(tc.input))
+			assert.Nil(t, res)
+			if assert.Error(t, err) {
+				assert.True(t, strings.HasPrefix(err.Error(), tc.wantErr), err.Error())
+			}
+		})
+	}
+}
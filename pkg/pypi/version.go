@@ -0,0 +1,431 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versionRe is the regular expression from the PEP 440 appendix.
+var versionRe = regexp.MustCompile(`(?i)^v?(?:(?:([0-9]+)!)?([0-9]+(?:\.[0-9]+)*)([-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?([0-9]+)?)?((?:-([0-9]+))|(?:[-_.]?(post|rev|r)[-_.]?([0-9]+)?))?([-_.]?(dev)[-_.]?([0-9]+)?)?)(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// Version is a PEP 440 version.
+type Version struct {
+	epoch   int
+	release []int
+	// preKind is one of a, b, rc (empty when the version is not a pre-release)
+	preKind string
+	preNum  int
+	post    int // -1 when the version is not a post-release
+	dev     int // -1 when the version is not a development release
+	local   string
+
+	original string
+}
+
+// NewVersion parses the input string as a PEP 440 version.
+func NewVersion(s string) (*Version, error) {
+	m := versionRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return nil, fmt.Errorf("invalid PEP 440 version: %s", s)
+	}
+
+	v := &Version{post: -1, dev: -1, original: s}
+	if m[1] != "" {
+		v.epoch, _ = strconv.Atoi(m[1])
+	}
+	for _, r := range strings.Split(m[2], ".") {
+		n, err := strconv.Atoi(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PEP 440 version: %s", s)
+		}
+		v.release = append(v.release, n)
+	}
+	if m[3] != "" {
+		switch strings.ToLower(m[4]) {
+		case "a", "alpha":
+			v.preKind = "a"
+		case "b", "beta":
+			v.preKind = "b"
+		default:
+			v.preKind = "rc"
+		}
+		v.preNum, _ = strconv.Atoi(m[5])
+	}
+	switch {
+	case m[7] != "":
+		v.post, _ = strconv.Atoi(m[7])
+	case m[8] != "":
+		v.post, _ = strconv.Atoi(m[9])
+	}
+	if m[10] != "" {
+		v.dev, _ = strconv.Atoi(m[12])
+	}
+	v.local = strings.ToLower(m[13])
+
+	return v, nil
+}
+
+// String returns the normalized form of the version.
+func (v *Version) String() string {
+	var b strings.Builder
+	if v.epoch != 0 {
+		fmt.Fprintf(&b, "%d!", v.epoch)
+	}
+	for i, r := range v.release {
+		if i > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(strconv.Itoa(r))
+	}
+	if v.preKind != "" {
+		fmt.Fprintf(&b, "%s%d", v.preKind, v.preNum)
+	}
+	if v.post >= 0 {
+		fmt.Fprintf(&b, ".post%d", v.post)
+	}
+	if v.dev >= 0 {
+		fmt.Fprintf(&b, ".dev%d", v.dev)
+	}
+	if v.local != "" {
+		b.WriteString("+" + v.local)
+	}
+
+	return b.String()
+}
+
+// Original returns the version as it was written.
+func (v *Version) Original() string {
+	return v.original
+}
+
+// IsPrerelease tells whether the version is a pre-release or a development release.
+func (v *Version) IsPrerelease() bool {
+	return v.preKind != "" || v.dev >= 0
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareRelease compares the release segments, padding the shortest one with zeros.
+func compareRelease(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		x, y := 0, 0
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if c := compareInts(x, y); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// preKey orders the pre-release segment.
+//
+// Development releases of a final release come before its pre-releases,
+// while the final release comes after its pre-releases.
+func (v *Version) preKey() (int, int) {
+	ranks := map[string]int{"a": 1, "b": 2, "rc": 3}
+	switch {
+	case v.preKind == "" && v.post < 0 && v.dev >= 0:
+		return 0, 0
+	case v.preKind == "":
+		return 4, 0
+	default:
+		return ranks[v.preKind], v.preNum
+	}
+}
+
+// Compare returns -1, 0, or 1 whether the version is lower, equal, or higher than the input one.
+func (v *Version) Compare(o *Version) int {
+	if c := compareInts(v.epoch, o.epoch); c != 0 {
+		return c
+	}
+	if c := compareRelease(v.release, o.release); c != 0 {
+		return c
+	}
+	vk, vn := v.preKey()
+	ok, on := o.preKey()
+	if c := compareInts(vk, ok); c != 0 {
+		return c
+	}
+	if c := compareInts(vn, on); c != 0 {
+		return c
+	}
+	if c := compareInts(v.post, o.post); c != 0 {
+		return c
+	}
+	// Not being a development release sorts after any development release
+	vd, od := v.dev, o.dev
+	if vd < 0 {
+		vd = int(^uint(0) >> 1)
+	}
+	if od < 0 {
+		od = int(^uint(0) >> 1)
+	}
+	if c := compareInts(vd, od); c != 0 {
+		return c
+	}
+
+	return strings.Compare(v.local, o.local)
+}
+
+// public returns the version without its local segment.
+func (v *Version) public() *Version {
+	ret := *v
+	ret.local = ""
+
+	return &ret
+}
+
+// Versions is a sortable collection of versions.
+type Versions []*Version
+
+func (c Versions) Len() int {
+	return len(c)
+}
+
+func (c Versions) Less(i, j int) bool {
+	return c[i].Compare(c[j]) < 0
+}
+
+func (c Versions) Swap(i, j int) {
+	c[i], c[j] = c[j], c[i]
+}
+
+// clause is a single version specifier (eg., >=1.2).
+type clause struct {
+	op       string
+	version  *Version
+	wildcard bool
+	raw      string
+}
+
+// Constraints are PEP 440 version specifiers, eventually written with the Poetry syntax.
+//
+// They are a disjunction of conjunctions of version specifiers.
+type Constraints struct {
+	any [][]clause
+}
+
+var clauseRe = regexp.MustCompile(`^(~=|===|==|!=|<=|>=|<|>|\^|~|=)?\s*(.*)$`)
+
+// NewConstraints parses the input PEP 440 (eg., >=1.2,<2) or Poetry (eg., ^1.2) version constraints.
+//
+// An empty string or a star mean any version.
+func NewConstraints(s string) (*Constraints, error) {
+	ret := &Constraints{}
+	for _, disjunct := range strings.Split(strings.ReplaceAll(s, "||", "|"), "|") {
+		all := []clause{}
+		for _, raw := range strings.Split(disjunct, ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" || raw == "*" {
+				continue
+			}
+			clauses, err := newClauses(raw)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, clauses...)
+		}
+		ret.any = append(ret.any, all)
+	}
+
+	return ret, nil
+}
+
+// bump returns the release segments increasing the one at the input index,
+// and dropping the following ones.
+func bump(release []int, i int) string {
+	parts := make([]string, i+1)
+	for j := 0; j < i; j++ {
+		parts[j] = strconv.Itoa(release[j])
+	}
+	parts[i] = strconv.Itoa(release[i] + 1)
+
+	return strings.Join(parts, ".")
+}
+
+func newClauses(raw string) ([]clause, error) {
+	m := clauseRe.FindStringSubmatch(raw)
+	op, value := m[1], strings.TrimSpace(m[2])
+	if op == "" || op == "=" {
+		op = "=="
+	}
+
+	if op == "===" {
+		return []clause{{op: op, raw: value}}, nil
+	}
+
+	wildcard := false
+	if strings.HasSuffix(value, ".*") {
+		if op != "==" && op != "!=" {
+			return nil, fmt.Errorf("invalid version constraint: %s", raw)
+		}
+		wildcard = true
+		value = strings.TrimSuffix(value, ".*")
+	}
+	v, err := NewVersion(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version constraint: %s", raw)
+	}
+
+	switch op {
+	case "^":
+		// Poetry caret: bump the leftmost non-zero release segment
+		i := len(v.release) - 1
+		for j, r := range v.release {
+			if r != 0 {
+				i = j
+
+				break
+			}
+		}
+		upper, _ := NewVersion(bump(v.release, i))
+
+		return []clause{{op: ">=", version: v}, {op: "<", version: upper}}, nil
+	case "~":
+		// Poetry tilde: bump the minor release segment (or the major one when missing)
+		i := 1
+		if len(v.release) == 1 {
+			i = 0
+		}
+		upper, _ := NewVersion(bump(v.release, i))
+
+		return []clause{{op: ">=", version: v}, {op: "<", version: upper}}, nil
+	case "~=":
+		if len(v.release) < 2 {
+			return nil, fmt.Errorf("invalid version constraint: %s", raw)
+		}
+		prefix := *v
+		prefix.release = v.release[:len(v.release)-1]
+		prefix.preKind, prefix.post, prefix.dev, prefix.local = "", -1, -1, ""
+
+		return []clause{{op: ">=", version: v}, {op: "==", version: &prefix, wildcard: true}}, nil
+	}
+
+	return []clause{{op: op, version: v, wildcard: wildcard}}, nil
+}
+
+// matchesPrefix tells whether the release segments of the input version start with the ones of the prefix.
+func matchesPrefix(v, prefix *Version) bool {
+	if v.epoch != prefix.epoch {
+		return false
+	}
+	for i, r := range prefix.release {
+		x := 0
+		if i < len(v.release) {
+			x = v.release[i]
+		}
+		if x != r {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c clause) check(v *Version) bool {
+	switch c.op {
+	case "===":
+		return strings.EqualFold(v.original, c.raw)
+	case "==":
+		if c.wildcard {
+			return matchesPrefix(v, c.version)
+		}
+		if c.version.local == "" {
+			return v.public().Compare(c.version) == 0
+		}
+
+		return v.Compare(c.version) == 0
+	case "!=":
+		return !clause{op: "==", version: c.version, wildcard: c.wildcard}.check(v)
+	case "<=":
+		return v.public().Compare(c.version) <= 0
+	case ">=":
+		return v.public().Compare(c.version) >= 0
+	case "<":
+		if v.public().Compare(c.version) >= 0 {
+			return false
+		}
+		// The pre-releases of the specified version do not satisfy it, unless it is a pre-release too
+		if !c.version.IsPrerelease() && v.IsPrerelease() && compareRelease(v.release, c.version.release) == 0 && v.epoch == c.version.epoch {
+			return false
+		}
+
+		return true
+	case ">":
+		if v.public().Compare(c.version) <= 0 {
+			return false
+		}
+		// The post-releases of the specified version do not satisfy it, unless it is a post-release too
+		if c.version.post < 0 && v.post >= 0 && compareRelease(v.release, c.version.release) == 0 && v.epoch == c.version.epoch {
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// Check tells whether the input version satisfies the constraints.
+func (c *Constraints) Check(v *Version) bool {
+	for _, all := range c.any {
+		ok := true
+		for _, cl := range all {
+			if !cl.check(v) {
+				ok = false
+
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsPrereleases tells whether the constraints explicitly mention a pre-release.
+func (c *Constraints) AllowsPrereleases() bool {
+	for _, all := range c.any {
+		for _, cl := range all {
+			if cl.version != nil && cl.version.IsPrerelease() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"sort"
+)
+
+// DefaultVersionResolutionStrategy returns the highest PEP 440 version in a collection of versions.
+func DefaultVersionResolutionStrategy(_ string, versions Versions) *Version {
+	sort.Sort(versions)
+
+	if versions.Len() == 0 {
+		return nil
+	}
+
+	return versions[versions.Len()-1]
+}
+
+// LowestVersionResolutionStrategy returns the lowest PEP 440 version in a collection of versions.
+func LowestVersionResolutionStrategy(_ string, versions Versions) *Version {
+	sort.Sort(versions)
+
+	if versions.Len() == 0 {
+		return nil
+	}
+
+	return versions[0]
+}
+
+// LockfileVersionResolutionStrategy returns a strategy that picks the version a lock file pins for a package,
+// when it is in the collection of versions (ie., it satisfies the version constraints).
+//
+// Otherwise, it falls back to the input strategy.
+func LockfileVersionResolutionStrategy(pkgs []PoetryPackage, fallback VersionResolutionStrategy) VersionResolutionStrategy {
+	locked := map[string]*Version{}
+	for _, p := range pkgs {
+		if v, err := NewVersion(p.Version); err == nil {
+			locked[NormalizeName(p.Name)] = v
+		}
+	}
+
+	return func(name string, versions Versions) *Version {
+		if l, ok := locked[NormalizeName(name)]; ok {
+			for _, v := range versions {
+				if v.Compare(l) == 0 {
+					return v
+				}
+			}
+		}
+
+		return fallback(name, versions)
+	}
+}
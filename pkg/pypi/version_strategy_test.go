@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getVersions(t *testing.T, versions ...string) Versions {
+	t.Helper()
+
+	ret := Versions{}
+	for _, v := range versions {
+		ver, err := NewVersion(v)
+		require.Nil(t, err)
+		ret = append(ret, ver)
+	}
+
+	return ret
+}
+
+func TestVersionResolutionStrategies(t *testing.T) {
+	assert.Equal(t, "2.0", DefaultVersionResolutionStrategy("pkg", getVersions(t, "1.2", "2.0", "1.0.post1")).String())
+	assert.Equal(t, "1.0.post1", LowestVersionResolutionStrategy("pkg", getVersions(t, "1.2", "2.0", "1.0.post1")).String())
+	assert.Nil(t, DefaultVersionResolutionStrategy("pkg", Versions{}))
+	assert.Nil(t, LowestVersionResolutionStrategy("pkg", Versions{}))
+}
+
+func TestLockfileVersionResolutionStrategy(t *testing.T) {
+	resolve := LockfileVersionResolutionStrategy([]PoetryPackage{
+		{Name: "Django_Rest", Version: "3.15.2"},
+		{Name: "requests", Version: "2.30.0"},
+	}, DefaultVersionResolutionStrategy)
+
+	// The locked version, matching the normalized names
+	assert.Equal(t, "3.15.2", resolve("django-rest", getVersions(t, "3.14.0", "3.15.2", "3.16.0")).String())
+	// The locked version does not satisfy the constraints: fallback
+	assert.Equal(t, "2.32.3", resolve("requests", getVersions(t, "2.31.0", "2.32.3")).String())
+	// The package is not locked: fallback
+	assert.Equal(t, "14.0.0", resolve("rich", getVersions(t, "13.7.1", "14.0.0")).String())
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pypi
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVersion(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		pre   bool
+	}{
+		{"1.0", "1.0", false},
+		{"v1.2.3", "1.2.3", false},
+		{"1.0RC1", "1.0rc1", true},
+		{"1.0-alpha.2", "1.0a2", true},
+		{"1.0c1", "1.0rc1", true},
+		{"2!1.0.post1", "2!1.0.post1", false},
+		{"1.0-1", "1.0.post1", false},
+		{"1.0.dev", "1.0.dev0", true},
+		{"1.0+Ubuntu-1", "1.0+ubuntu-1", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := NewVersion(tc.input)
+			require.Nil(t, err)
+			assert.Equal(t, tc.want, v.String())
+			assert.Equal(t, tc.input, v.Original())
+			assert.Equal(t, tc.pre, v.IsPrerelease())
+		})
+	}
+
+	_, err := NewVersion("1..0")
+	assert.Error(t, err)
+}
+
+func TestVersionsSort(t *testing.T) {
+	raw := []string{"1.0.post1", "1.0", "1.0rc1", "1.0.dev1", "1.0a1", "1.0a1.dev1", "1.0b2", "0.9", "1!0.1", "1.0.post1.dev1", "1.0.1"}
+	versions := Versions{}
+	for _, r := range raw {
+		v, err := NewVersion(r)
+		require.Nil(t, err)
+		versions = append(versions, v)
+	}
+	sort.Sort(versions)
+
+	sorted := []string{}
+	for _, v := range versions {
+		sorted = append(sorted, v.Original())
+	}
+	assert.Equal(t, []string{"0.9", "1.0.dev1", "1.0a1.dev1", "1.0a1", "1.0b2", "1.0rc1", "1.0", "1.0.post1.dev1", "1.0.post1", "1.0.1", "1!0.1"}, sorted)
+}
+
+func TestConstraints(t *testing.T) {
+	tests := []struct {
+		constraints string
+		version     string
+		want        bool
+	}{
+		{"", "3.1", true},
+		{"*", "3.1", true},
+		{">=1.2,<2", "1.9.9", true},
+		{">=1.2, <2", "2.0", false},
+		{"==1.2.*", "1.2.7", true},
+		{"==1.2.*", "1.3", false},
+		{"!=1.2.*", "1.3", true},
+		{"==1.2", "1.2.0", true},
+		{"==1.2", "1.2+local", true},
+		{"~=1.4.5", "1.4.9", true},
+		{"~=1.4.5", "1.5.0", false},
+		{"~=2.2", "2.9", true},
+		{"~=2.2", "3.0", false},
+		{"<2.0", "2.0rc1", false},
+		{"<2.0rc2", "2.0rc1", true},
+		{">1.7", "1.7.post1", false},
+		{">1.7", "1.7.1", true},
+		{"===1.0", "1.0", true},
+		{"===1.0", "1.0.0", false},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.4", false},
+		{"^0", "0.9", true},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1", "1.9", true},
+		{"~1", "2.0", false},
+		{"1.2.3", "1.2.3", true},
+		{"1.2.*", "1.2.5", true},
+		{"^1.0 || ^3.0", "3.4", true},
+		{"^1.0 || ^3.0", "2.4", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.constraints+" "+tc.version, func(t *testing.T) {
+			c, err := NewConstraints(tc.constraints)
+			require.Nil(t, err)
+			v, err := NewVersion(tc.version)
+			require.Nil(t, err)
+			assert.Equal(t, tc.want, c.Check(v))
+		})
+	}
+}
+
+func TestConstraintsErrors(t *testing.T) {
+	for _, input := range []string{">=1.*", "~=1", ">=foo"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := NewConstraints(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConstraintsAllowsPrereleases(t *testing.T) {
+	c, err := NewConstraints(">=2.0rc1")
+	require.Nil(t, err)
+	assert.True(t, c.AllowsPrereleases())
+
+	c, err = NewConstraints(">=2.0")
+	require.Nil(t, err)
+	assert.False(t, c.AllowsPrereleases())
+}
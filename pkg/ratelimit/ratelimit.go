@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+)
+
+// DefaultConcurrency is the maximum number of concurrent requests when the configuration options do not tell it.
+const DefaultConcurrency = 8
+
+// Limiter spaces out the requests so that they do not exceed a number of requests per second.
+//
+// A nil Limiter does not limit anything.
+type Limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// New creates a limiter allowing the input number of requests per second.
+//
+// It returns nil, meaning no limit, when the input is not positive.
+func New(rps int) *Limiter {
+	if rps <= 0 {
+		return nil
+	}
+
+	return &Limiter{interval: time.Second / time.Duration(rps)}
+}
+
+// reserve books the next free slot and returns how long to wait for it.
+func (l *Limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+
+	return wait
+}
+
+// Wait blocks until the limiter allows one more request, or until the context is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	d := l.reserve(time.Now())
+	if d == 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// FromContext returns the limiter in the input context, if any.
+func FromContext(ctx context.Context) *Limiter {
+	l, _ := ctx.Value(pkgcontext.RateLimiterKey).(*Limiter)
+
+	return l
+}
+
+// Wait blocks until the limiter in the input context allows one more request.
+//
+// It does not block at all when the context has no limiter.
+func Wait(ctx context.Context) error {
+	return FromContext(ctx).Wait(ctx)
+}
+
+// Concurrency returns the maximum number of concurrent requests from the configuration options in the input context.
+func Concurrency(ctx context.Context) int {
+	if cfg, ok := ctx.Value(pkgcontext.ConfigKey).(*flags.ConfigFlags); ok && cfg.Concurrency > 0 {
+		return cfg.Concurrency
+	}
+
+	return DefaultConcurrency
+}
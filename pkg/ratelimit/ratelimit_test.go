@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWithoutLimit(t *testing.T) {
+	assert.Nil(t, New(0))
+	assert.Nil(t, New(-1))
+
+	var l *Limiter
+	assert.Nil(t, l.Wait(t.Context()))
+	assert.Nil(t, Wait(t.Context()))
+}
+
+func TestReserve(t *testing.T) {
+	l := New(4)
+	require.NotNil(t, l)
+
+	now := time.Now()
+	assert.Equal(t, time.Duration(0), l.reserve(now))
+	assert.Equal(t, 250*time.Millisecond, l.reserve(now))
+	assert.Equal(t, 500*time.Millisecond, l.reserve(now))
+	// The unused slots do not pile up
+	assert.Equal(t, time.Duration(0), l.reserve(now.Add(2*time.Second)))
+	assert.Equal(t, 250*time.Millisecond, l.reserve(now.Add(2*time.Second)))
+}
+
+func TestWait(t *testing.T) {
+	l := New(20)
+	ctx := context.WithValue(t.Context(), pkgcontext.RateLimiterKey, l)
+	assert.Same(t, l, FromContext(ctx))
+
+	start := time.Now()
+	for range 3 {
+		require.Nil(t, Wait(ctx))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestWaitCancelled(t *testing.T) {
+	l := New(1)
+	ctx, cancel := context.WithCancel(t.Context())
+
+	require.Nil(t, l.Wait(ctx))
+	cancel()
+	assert.ErrorIs(t, l.Wait(ctx), context.Canceled)
+}
+
+func TestConcurrency(t *testing.T) {
+	assert.Equal(t, DefaultConcurrency, Concurrency(t.Context()))
+
+	cfg, err := flags.NewConfigFlags()
+	require.Nil(t, err)
+	cfg.Concurrency = 2
+	assert.Equal(t, 2, Concurrency(context.WithValue(t.Context(), pkgcontext.ConfigKey, cfg)))
+
+	cfg.Concurrency = 0
+	assert.Equal(t, DefaultConcurrency, Concurrency(context.WithValue(t.Context(), pkgcontext.ConfigKey, cfg)))
+}
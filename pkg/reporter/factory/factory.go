@@ -49,8 +49,8 @@ var (
 // it returns a true value for the canRun return value.
 //
 // Last but not least, this function takes care of configuring
-// everything the reporter being instantiated needs.
-func makeReporter(ctx context.Context, reportType cmd.ReportType) (r reporter.Reporter, canRun bool, err error) {
+// everything the reporter being instantiated needs, along with the input options.
+func makeReporter(ctx context.Context, reportType cmd.ReportType, opts ...reporter.Option) (r reporter.Reporter, canRun bool, err error) {
 	switch reportType {
 	case cmd.ListenPro:
 		info, infoErr := ci.NewInfo()
@@ -64,7 +64,7 @@ func makeReporter(ctx context.Context, reportType cmd.ReportType) (r reporter.Re
 			return nil, false, ErrReporterOnFork
 		}
 
-		r, err := pro.New(ctx, reporter.WithContinuousIntegrationInfo(info))
+		r, err := pro.New(ctx, append([]reporter.Option{reporter.WithContinuousIntegrationInfo(info)}, opts...)...)
 		if err != nil {
 			return nil, true, err
 		}
@@ -72,7 +72,7 @@ func makeReporter(ctx context.Context, reportType cmd.ReportType) (r reporter.Re
 		return r, true, nil
 
 	case cmd.GitHubPullCommentReport:
-		r, err := ghcomment.New(ctx)
+		r, err := ghcomment.New(ctx, opts...)
 		if err != nil {
 			return nil, true, err
 		}
@@ -102,7 +102,7 @@ func makeReporter(ctx context.Context, reportType cmd.ReportType) (r reporter.Re
 	}
 }
 
-func Exec(c *cobra.Command, reportingOpts flags.Reporting, resp interface{}, source *string) error {
+func Exec(c *cobra.Command, reportingOpts flags.Reporting, resp interface{}, source *string, opts ...reporter.Option) error {
 	ctx := c.Context()
 	var cs *iostreams.ColorScheme
 	io, ok := ctx.Value(pkgcontext.IOStreamsKey).(*iostreams.IOStreams)
@@ -122,7 +122,7 @@ func Exec(c *cobra.Command, reportingOpts flags.Reporting, resp interface{}, sou
 			fallthrough
 
 		case cmd.GitHubPullCommentReport:
-			rep, runnable, err := makeReporter(c.Context(), r)
+			rep, runnable, err := makeReporter(c.Context(), r, opts...)
 			if runnable && err != nil {
 				return err
 			}
@@ -27,8 +27,12 @@ import (
 	"github.com/listendev/lstn/pkg/cmd/flags"
 	"github.com/listendev/lstn/pkg/cmd/report"
 	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/listendev/lstn/pkg/diff"
 	"github.com/listendev/lstn/pkg/listen"
 	"github.com/listendev/lstn/pkg/reporter"
+	"github.com/listendev/lstn/pkg/suppress"
+	"github.com/listendev/lstn/pkg/transport"
+	"golang.org/x/oauth2"
 )
 
 const stickyReviewCommentAnnotation = "<!--@lstn-sticky-review-comment-->"
@@ -37,6 +41,8 @@ type rep struct {
 	ctx      context.Context
 	ghClient *github.Client
 	opts     *flags.ConfigFlags
+	// known is how many verdicts are already in the baseline, when comparing with one
+	known *uint
 }
 
 func New(ctx context.Context, opts ...reporter.Option) (reporter.Reporter, error) {
@@ -47,10 +53,13 @@ func New(ctx context.Context, opts ...reporter.Option) (reporter.Reporter, error
 		return nil, fmt.Errorf("couldn't retrieve the config options")
 	}
 
+	// Make the GitHub client use the shared HTTP transport
+	httpCtx := context.WithValue(ctx, oauth2.HTTPClient, transport.Client(ctx))
+
 	ret := &rep{
 		ctx:      ctx,
 		opts:     cfgOpts,
-		ghClient: github.NewTokenClient(ctx, cfgOpts.Token.GitHub),
+		ghClient: github.NewTokenClient(httpCtx, cfgOpts.Token.GitHub),
 	}
 
 	for _, opt := range opts {
@@ -72,6 +81,10 @@ func (r *rep) WithContinuousIntegrationInfo(_ *ci.Info) {
 	// Do Nothing
 }
 
+func (r *rep) WithBaseline(known uint) {
+	r.known = &known
+}
+
 func (r *rep) stickyComment(owner string, repo string, id int, comment io.Reader) error {
 	buf := bytes.Buffer{}
 	_, err := buf.WriteString(stickyReviewCommentAnnotation)
@@ -127,11 +140,28 @@ func (r *rep) Run(res interface{}, _ *string) error {
 	case listen.Response:
 		fullMarkdownReport := report.NewFullMarkdwonReport()
 		fullMarkdownReport.WithOutput(&buf)
+		if suppressed, ok := r.ctx.Value(pkgcontext.SuppressedKey).([]suppress.Suppressed); ok {
+			fullMarkdownReport.WithSuppressed(suppressed)
+		}
+		if r.known != nil {
+			fullMarkdownReport.WithBaseline(*r.known)
+		}
 
 		if err := fullMarkdownReport.Render(v); err != nil {
 			return err
 		}
 
+	case diff.Changes:
+		changesMarkdownReport := report.NewChangesMarkdownReport()
+		changesMarkdownReport.WithOutput(&buf)
+		if suppressed, ok := r.ctx.Value(pkgcontext.SuppressedKey).([]suppress.Suppressed); ok {
+			changesMarkdownReport.WithSuppressed(suppressed)
+		}
+
+		if err := changesMarkdownReport.Render(v); err != nil {
+			return err
+		}
+
 	case string:
 		buf.WriteString(v)
 	default:
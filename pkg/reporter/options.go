@@ -46,3 +46,11 @@ func WithContinuousIntegrationInfo(info *ci.Info) Option {
 		return r
 	}
 }
+
+func WithBaseline(known uint) Option {
+	return func(r Reporter) Reporter {
+		r.WithBaseline(known)
+
+		return r
+	}
+}
@@ -19,15 +19,17 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"runtime"
 
 	"github.com/XANi/goneric"
 	"github.com/google/go-github/v53/github"
 	"github.com/listendev/lstn/pkg/ci"
 	"github.com/listendev/lstn/pkg/cmd/flags"
 	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/listendev/lstn/pkg/diff"
 	"github.com/listendev/lstn/pkg/listen"
+	"github.com/listendev/lstn/pkg/ratelimit"
 	"github.com/listendev/lstn/pkg/reporter"
+	"github.com/listendev/lstn/pkg/transport"
 	"github.com/listendev/pkg/apispec"
 	"github.com/listendev/pkg/type/int64string"
 )
@@ -49,7 +51,7 @@ func New(ctx context.Context, opts ...reporter.Option) (reporter.Reporter, error
 		return nil, fmt.Errorf("couldn't retrieve the config options")
 	}
 
-	proClient, err := apispec.NewClientWithResponses(proBaseURL)
+	proClient, err := apispec.NewClientWithResponses(proBaseURL, apispec.WithHTTPClient(transport.Client(ctx)))
 	if err != nil {
 		return nil, fmt.Errorf("couldn't setup the client for our pro APIs: %w", err)
 	}
@@ -85,6 +87,9 @@ func (r *rep) Run(res interface{}, source *string) error {
 			err error
 		}
 		cb := func(v listen.Verdict) wrap {
+			if err := ratelimit.Wait(r.ctx); err != nil {
+				return wrap{nil, err}
+			}
 			res, err := r.proClient.PostApiV1DependenciesEventWithResponse(
 				r.ctx,
 				getDependencyEvent(v, *r.info, source),
@@ -93,7 +98,7 @@ func (r *rep) Run(res interface{}, source *string) error {
 
 			return wrap{res, err}
 		}
-		returns := goneric.ParallelMapSlice(cb, runtime.NumCPU(), verdicts)
+		returns := goneric.ParallelMapSlice(cb, ratelimit.Concurrency(r.ctx), verdicts)
 
 		// Check the number of responses matches the number of requests
 		numReturns := len(returns)
@@ -109,6 +114,9 @@ func (r *rep) Run(res interface{}, source *string) error {
 		}
 
 		return nil
+	case diff.Changes:
+		// Only the new package versions have verdicts
+		return r.Run(response.Response(), source)
 	default:
 		return fmt.Errorf("unsupported type: %T", res)
 	}
@@ -180,3 +188,7 @@ func getDependencyEvent(v listen.Verdict, i ci.Info, src *string) apispec.Depend
 	// Branch           string // Pull (merge) request branch
 	// Fork             bool
 }
+
+func (r *rep) WithBaseline(_ uint) {
+	// Do nothing
+}
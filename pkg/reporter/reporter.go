@@ -25,5 +25,6 @@ type Reporter interface {
 	WithGitHubClient(client *github.Client)
 	WithConfigOptions(opts *flags.ConfigFlags)
 	WithContinuousIntegrationInfo(info *ci.Info)
+	WithBaseline(known uint)
 	Run(res interface{}, source *string) error
 }
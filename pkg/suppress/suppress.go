@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package suppress
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/listendev/lstn/pkg/pypi"
+	"github.com/listendev/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Filename is the name of the file containing the suppression rules.
+const Filename = ".lstnignore.yaml"
+
+// dateLayout is the layout of the expiry dates.
+const dateLayout = "2006-01-02"
+
+// Rule suppresses the verdicts it matches.
+//
+// Its empty matchers match anything.
+type Rule struct {
+	// Ecosystem is the ecosystem (npm, pypi) of the package
+	Ecosystem string `yaml:"ecosystem"`
+	// Name is the name of the package
+	Name string `yaml:"name"`
+	// Versions is the version range (eg., >=1.2.0 <2) of the package
+	Versions string `yaml:"versions"`
+	// Code is the verdict code (eg., STN001) or code group (eg., STN)
+	Code string `yaml:"code"`
+	// Metadata are the values that some verdict metadata fields must have
+	Metadata map[string]string `yaml:"metadata"`
+	// Reason tells why the verdicts are false positives
+	Reason string `yaml:"reason"`
+	// Owner is who triaged the verdicts
+	Owner string `yaml:"owner"`
+	// Expires is the last day (eg., 2025-12-31) the rule is active
+	Expires string `yaml:"expires"`
+
+	expires     time.Time
+	npmRange    *semver.Constraints
+	pypiRange   *pypi.Constraints
+	description string
+}
+
+// Suppressed is a verdict that a rule suppressed.
+type Suppressed struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+	Owner   string `json:"owner"`
+}
+
+// Rules are the suppression rules of a file.
+//
+// It is safe for concurrent use. A nil Rules does not suppress anything.
+type Rules struct {
+	Rules []*Rule `yaml:"rules"`
+
+	path       string
+	mu         sync.Mutex
+	suppressed []Suppressed
+}
+
+// Load reads the suppression rules from the input file.
+//
+// It returns nil, without errors, when the file does not exist.
+func Load(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the suppression rules: %w", err)
+	}
+
+	ret := &Rules{path: path}
+	if err := yaml.Unmarshal(data, ret); err != nil {
+		return nil, fmt.Errorf("couldn't decode the suppression rules in %s: %w", path, err)
+	}
+	for i, r := range ret.Rules {
+		if err := r.parse(); err != nil {
+			return nil, fmt.Errorf("the suppression rule %d in %s is not valid: %w", i+1, path, err)
+		}
+	}
+
+	return ret, nil
+}
+
+func (r *Rule) parse() error {
+	if strings.TrimSpace(r.Reason) == "" {
+		return fmt.Errorf("the reason is mandatory")
+	}
+	if strings.TrimSpace(r.Owner) == "" {
+		return fmt.Errorf("the owner is mandatory")
+	}
+	if r.Expires != "" {
+		expires, err := time.Parse(dateLayout, r.Expires)
+		if err != nil {
+			return fmt.Errorf("the expiry date %q is not in the YYYY-MM-DD format", r.Expires)
+		}
+		r.expires = expires
+	}
+
+	// Suppressing all the verdicts is not triaging them
+	if r.Name == "" && r.Code == "" {
+		return fmt.Errorf("the package name or the verdict code is mandatory")
+	}
+
+	r.Ecosystem = strings.ToLower(r.Ecosystem)
+	r.Code = strings.ToUpper(r.Code)
+	switch r.Ecosystem {
+	case "", "npm", "pypi":
+	default:
+		return fmt.Errorf("the ecosystem %q is not supported", r.Ecosystem)
+	}
+	if r.Versions != "" {
+		// Without the ecosystem, the range must make sense for both of them
+		if r.Ecosystem != "pypi" {
+			c, err := semver.NewConstraint(r.Versions)
+			if err != nil && r.Ecosystem == "npm" {
+				return fmt.Errorf("the versions %q are not a valid npm version range", r.Versions)
+			}
+			r.npmRange = c
+		}
+		if r.Ecosystem != "npm" {
+			c, err := pypi.NewConstraints(r.Versions)
+			if err != nil && r.Ecosystem == "pypi" {
+				return fmt.Errorf("the versions %q are not a valid PEP 440 version range", r.Versions)
+			}
+			r.pypiRange = c
+		}
+		if r.npmRange == nil && r.pypiRange == nil {
+			return fmt.Errorf("the versions %q are not a valid version range", r.Versions)
+		}
+	}
+
+	r.description = describe(r)
+
+	return nil
+}
+
+func describe(r *Rule) string {
+	parts := []string{}
+	for _, p := range []string{r.Ecosystem, r.Name, r.Versions, r.Code} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// String describes what the rule matches.
+func (r *Rule) String() string {
+	return r.description
+}
+
+// Expired tells whether the rule expired at the input time.
+func (r *Rule) Expired(now time.Time) bool {
+	return !r.expires.IsZero() && !now.Before(r.expires.AddDate(0, 0, 1))
+}
+
+// Expired returns the rules that expired at the input time.
+//
+// The expired rules do not suppress any verdict.
+func (r *Rules) Expired(now time.Time) []*Rule {
+	if r == nil {
+		return nil
+	}
+	ret := []*Rule{}
+	for _, rule := range r.Rules {
+		if rule.Expired(now) {
+			ret = append(ret, rule)
+		}
+	}
+
+	return ret
+}
+
+// ExpiredWarnings returns a warning for every rule that expired at the input time.
+func (r *Rules) ExpiredWarnings(now time.Time) []string {
+	ret := []string{}
+	for _, rule := range r.Expired(now) {
+		ret = append(ret, fmt.Sprintf("the suppression rule for %s (owned by %s) in %s expired on %s: it does not suppress its verdicts anymore", rule, rule.Owner, r.path, rule.Expires))
+	}
+
+	return ret
+}
+
+// Suppress tells whether a rule suppresses the input verdict of the input package version, and records it.
+func (r *Rules) Suppress(eco, name, version string, v models.Verdict) bool {
+	if r == nil {
+		return false
+	}
+	now := time.Now()
+	for _, rule := range r.Rules {
+		if rule.Expired(now) || !rule.matches(eco, name, version, v) {
+			continue
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		s := Suppressed{Name: name, Version: version, Code: v.Code.String(), Message: v.Message, Reason: rule.Reason, Owner: rule.Owner}
+		if !slices.Contains(r.suppressed, s) {
+			r.suppressed = append(r.suppressed, s)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (r *Rule) matches(eco, name, version string, v models.Verdict) bool {
+	if r.Ecosystem != "" && r.Ecosystem != eco {
+		return false
+	}
+	if r.Name != "" && r.Name != name {
+		return false
+	}
+	if r.Code != "" && !strings.HasPrefix(v.Code.String(), r.Code) {
+		return false
+	}
+	for k, want := range r.Metadata {
+		got, ok := v.Metadata[k]
+		if !ok || fmt.Sprint(got) != want {
+			return false
+		}
+	}
+	if r.Versions == "" {
+		return true
+	}
+
+	return r.inRange(eco, version)
+}
+
+func (r *Rule) inRange(eco, version string) bool {
+	switch eco {
+	case "npm":
+		v, err := semver.NewVersion(version)
+
+		return err == nil && r.npmRange != nil && r.npmRange.Check(v)
+	case "pypi":
+		v, err := pypi.NewVersion(version)
+
+		return err == nil && r.pypiRange != nil && r.pypiRange.Check(v)
+	default:
+		return false
+	}
+}
+
+// Take returns the verdicts suppressed since the previous call.
+func (r *Rules) Take() []Suppressed {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ret := r.suppressed
+	r.suppressed = nil
+
+	return ret
+}
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package suppress
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/listendev/pkg/models"
+	"github.com/listendev/pkg/verdictcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	stn001 = models.Verdict{Code: verdictcode.STN001, Message: "unexpected outbound connection"}
+	tsn01  = models.Verdict{Code: verdictcode.TSN01, Message: "typosquatting"}
+)
+
+func write(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), Filename)
+	require.Nil(t, os.WriteFile(path, []byte(contents), 0o600))
+
+	return path
+}
+
+func TestLoadMissing(t *testing.T) {
+	r, err := Load(filepath.Join(t.TempDir(), Filename))
+	require.Nil(t, err)
+	assert.Nil(t, r)
+
+	// A nil Rules does not suppress anything
+	assert.False(t, r.Suppress("npm", "react", "18.0.0", stn001))
+	assert.Nil(t, r.Take())
+	assert.Empty(t, r.ExpiredWarnings(time.Now()))
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		desc     string
+		contents string
+		wantErr  string
+	}{
+		{
+			"invalid yaml",
+			"rules: {",
+			"couldn't decode the suppression rules",
+		},
+		{
+			"missing reason",
+			"rules:\n- name: react\n  owner: security",
+			"the suppression rule 1 in %s is not valid: the reason is mandatory",
+		},
+		{
+			"missing owner",
+			"rules:\n- name: react\n  reason: known",
+			"the suppression rule 1 in %s is not valid: the owner is mandatory",
+		},
+		{
+			"invalid expiry",
+			"rules:\n- name: react\n  reason: known\n  owner: security\n  expires: 31/12/2025",
+			`the expiry date "31/12/2025" is not in the YYYY-MM-DD format`,
+		},
+		{
+			"neither name nor code",
+			"rules:\n- ecosystem: npm\n  reason: known\n  owner: security",
+			"the package name or the verdict code is mandatory",
+		},
+		{
+			"unsupported ecosystem",
+			"rules:\n- ecosystem: cargo\n  name: serde\n  reason: known\n  owner: security",
+			`the ecosystem "cargo" is not supported`,
+		},
+		{
+			"invalid npm range",
+			"rules:\n- ecosystem: npm\n  name: react\n  versions: '~=1.4'\n  reason: known\n  owner: security",
+			`the versions "~=1.4" are not a valid npm version range`,
+		},
+		{
+			"invalid pypi range",
+			"rules:\n- ecosystem: pypi\n  name: flask\n  versions: '>=foo'\n  reason: known\n  owner: security",
+			`the versions ">=foo" are not a valid PEP 440 version range`,
+		},
+		{
+			"second rule",
+			"rules:\n- code: STN\n  reason: known\n  owner: security\n- name: react\n  reason: known",
+			"the suppression rule 2 in %s is not valid: the owner is mandatory",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			path := write(t, tc.contents)
+			r, err := Load(path)
+			assert.Nil(t, r)
+			require.Error(t, err)
+			want := tc.wantErr
+			if strings.Contains(want, "%s") {
+				want = fmt.Sprintf(want, path)
+			}
+			assert.Contains(t, err.Error(), want)
+		})
+	}
+}
+
+func TestSuppress(t *testing.T) {
+	r, err := Load(write(t, `rules:
+- ecosystem: npm
+  name: react
+  versions: ">=18.0.0, <19"
+  code: stn
+  reason: the outbound connection is the telemetry
+  owner: frontend
+- ecosystem: pypi
+  name: flask
+  versions: "~=2.2"
+  code: TSN01
+  reason: it is the real one
+  owner: backend
+- code: STN001
+  metadata:
+    executable_path: /usr/bin/node
+  reason: node is expected
+  owner: security
+`))
+	require.Nil(t, err)
+	require.NotNil(t, r)
+	assert.Len(t, r.Rules, 3)
+	assert.Equal(t, "npm react >=18.0.0, <19 STN", r.Rules[0].String())
+
+	// Ecosystem, name, version range, and code group
+	assert.True(t, r.Suppress("npm", "react", "18.2.0", stn001))
+	assert.True(t, r.Suppress("npm", "react", "18.2.0", stn001))
+	assert.False(t, r.Suppress("npm", "react", "19.0.0", stn001))
+	assert.False(t, r.Suppress("npm", "react", "18.2.0", tsn01))
+	assert.False(t, r.Suppress("pypi", "react", "18.2.0", stn001))
+
+	// PEP 440 version ranges
+	assert.True(t, r.Suppress("pypi", "flask", "2.9", tsn01))
+	assert.False(t, r.Suppress("pypi", "flask", "3.0", tsn01))
+
+	// Metadata
+	withNode := models.Verdict{Code: verdictcode.STN001, Metadata: map[string]interface{}{"executable_path": "/usr/bin/node"}}
+	withCurl := models.Verdict{Code: verdictcode.STN001, Metadata: map[string]interface{}{"executable_path": "/usr/bin/curl"}}
+	assert.True(t, r.Suppress("npm", "chalk", "5.3.0", withNode))
+	assert.False(t, r.Suppress("npm", "chalk", "5.3.0", withCurl))
+
+	// Recorded once each, and drained
+	assert.Equal(t, []Suppressed{
+		{Name: "react", Version: "18.2.0", Code: "STN001", Message: "unexpected outbound connection", Reason: "the outbound connection is the telemetry", Owner: "frontend"},
+		{Name: "flask", Version: "2.9", Code: "TSN01", Message: "typosquatting", Reason: "it is the real one", Owner: "backend"},
+		{Name: "chalk", Version: "5.3.0", Code: "STN001", Reason: "node is expected", Owner: "security"},
+	}, r.Take())
+	assert.Nil(t, r.Take())
+}
+
+func TestExpired(t *testing.T) {
+	path := write(t, `rules:
+- name: react
+  reason: known
+  owner: frontend
+  expires: 2025-06-30
+- name: chalk
+  reason: known
+  owner: frontend
+`)
+	r, err := Load(path)
+	require.Nil(t, err)
+
+	// Active through the expiry day
+	lastDay := time.Date(2025, 6, 30, 23, 59, 0, 0, time.UTC)
+	assert.Empty(t, r.Expired(lastDay))
+	assert.Empty(t, r.ExpiredWarnings(lastDay))
+
+	nextDay := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []*Rule{r.Rules[0]}, r.Expired(nextDay))
+	assert.Equal(t, []string{
+		"the suppression rule for react (owned by frontend) in " + path + " expired on 2025-06-30: it does not suppress its verdicts anymore",
+	}, r.ExpiredWarnings(nextDay))
+
+	// The expired rules do not suppress anything
+	assert.False(t, r.Suppress("npm", "react", "18.2.0", stn001))
+	assert.True(t, r.Suppress("npm", "chalk", "5.3.0", stn001))
+}
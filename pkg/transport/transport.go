@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+)
+
+// defaultTransport is the HTTP transport used when the context does not carry one.
+var defaultTransport, _ = New(nil)
+
+// New creates the HTTP transport of all the outgoing requests from the input configuration options.
+//
+// On top of the defaults of the Go standard library (eg., the HTTPS_PROXY environment variable),
+// it uses the proxy, the additional CA certificates, and the client certificate the configuration options tell.
+func New(cfg *flags.ConfigFlags) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	// The bulk requests hit the same host concurrently
+	t.MaxIdleConns = 100
+	t.MaxIdleConnsPerHost = 32
+
+	if cfg == nil {
+		return t, nil
+	}
+
+	if cfg.Proxy != "" {
+		proxy, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse the proxy URL: %w", err)
+		}
+		t.Proxy = http.ProxyURL(proxy)
+	}
+
+	if cfg.CACert == "" && cfg.ClientCert == "" {
+		return t, nil
+	}
+
+	// The cloned transport can already have a TLS configuration (eg., for HTTP/2)
+	tlsConfig := t.TLSClientConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if cfg.CACert != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(cfg.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read the CA certificates: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("couldn't find any PEM certificate in %s", cfg.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.ClientCert != "" {
+		// The private key can live in the same PEM file of the client certificate
+		key := cfg.ClientKey
+		if key == "" {
+			key = cfg.ClientCert
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCert, key)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't load the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	t.TLSClientConfig = tlsConfig
+
+	return t, nil
+}
+
+// FromContext returns the HTTP transport in the input context, or the default one.
+//
+// Sharing it among the HTTP clients, they reuse the connections.
+func FromContext(ctx context.Context) http.RoundTripper {
+	if t, ok := ctx.Value(pkgcontext.HTTPTransportKey).(http.RoundTripper); ok && t != nil {
+		return t
+	}
+
+	return defaultTransport
+}
+
+// Client returns an HTTP client using the HTTP transport in the input context.
+func Client(ctx context.Context) *http.Client {
+	return &http.Client{Transport: FromContext(ctx)}
+}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package transport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/listendev/lstn/pkg/cmd/flags"
+	pkgcontext "github.com/listendev/lstn/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDefaults(t *testing.T) {
+	for _, cfg := range []*flags.ConfigFlags{nil, {}} {
+		tr, err := New(cfg)
+		require.Nil(t, err)
+		assert.Equal(t, 32, tr.MaxIdleConnsPerHost)
+		assert.NotNil(t, tr.Proxy)
+		if tr.TLSClientConfig != nil {
+			assert.Nil(t, tr.TLSClientConfig.RootCAs)
+			assert.Empty(t, tr.TLSClientConfig.Certificates)
+		}
+	}
+}
+
+func TestNewProxy(t *testing.T) {
+	tr, err := New(&flags.ConfigFlags{Network: flags.Network{Proxy: "http://proxy.local:8080"}})
+	require.Nil(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "https://npm.listen.dev", nil)
+	proxy, err := tr.Proxy(req)
+	require.Nil(t, err)
+	assert.Equal(t, "http://proxy.local:8080", proxy.String())
+}
+
+func TestNewCACert(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	// Without the CA certificate of the server the request fails
+	tr, err := New(&flags.ConfigFlags{})
+	require.Nil(t, err)
+	_, err = (&http.Client{Transport: tr}).Get(srv.URL)
+	assert.NotNil(t, err)
+
+	caFile := writePEM(t, "ca.pem", &pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	tr, err = New(&flags.ConfigFlags{Network: flags.Network{CACert: caFile}})
+	require.Nil(t, err)
+	res, err := (&http.Client{Transport: tr}).Get(srv.URL)
+	require.Nil(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestNewCACertInvalid(t *testing.T) {
+	junk := filepath.Join(t.TempDir(), "junk.pem")
+	require.Nil(t, os.WriteFile(junk, []byte("not a certificate"), 0o600))
+
+	_, err := New(&flags.ConfigFlags{Network: flags.Network{CACert: junk}})
+	assert.ErrorContains(t, err, "couldn't find any PEM certificate")
+
+	_, err = New(&flags.ConfigFlags{Network: flags.Network{CACert: filepath.Join(t.TempDir(), "unexistent.pem")}})
+	assert.ErrorContains(t, err, "couldn't read the CA certificates")
+}
+
+func TestNewClientCert(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "lstn" {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	defer srv.Close()
+
+	caFile := writePEM(t, "ca.pem", &pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	certBlock, keyBlock := newClientCert(t)
+	certFile := writePEM(t, "client.pem", certBlock)
+	keyFile := writePEM(t, "client-key.pem", keyBlock)
+	bothFile := writePEM(t, "client-both.pem", certBlock, keyBlock)
+
+	// Without the client certificate the TLS handshake fails
+	tr, err := New(&flags.ConfigFlags{Network: flags.Network{CACert: caFile}})
+	require.Nil(t, err)
+	_, err = (&http.Client{Transport: tr}).Get(srv.URL)
+	assert.NotNil(t, err)
+
+	cases := []flags.Network{
+		{CACert: caFile, ClientCert: certFile, ClientKey: keyFile},
+		// The private key in the same file of the certificate
+		{CACert: caFile, ClientCert: bothFile},
+	}
+	for _, network := range cases {
+		tr, err := New(&flags.ConfigFlags{Network: network})
+		require.Nil(t, err)
+		res, err := (&http.Client{Transport: tr}).Get(srv.URL)
+		require.Nil(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	}
+
+	_, err = New(&flags.ConfigFlags{Network: flags.Network{ClientCert: certFile}})
+	assert.ErrorContains(t, err, "couldn't load the client certificate")
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Same(t, defaultTransport, FromContext(t.Context()))
+
+	tr, err := New(nil)
+	require.Nil(t, err)
+	ctx := context.WithValue(t.Context(), pkgcontext.HTTPTransportKey, tr)
+	assert.Same(t, tr, FromContext(ctx))
+	assert.Same(t, tr, Client(ctx).Transport)
+}
+
+func newClientCert(t *testing.T) (*pem.Block, *pem.Block) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "lstn"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+
+	return &pem.Block{Type: "CERTIFICATE", Bytes: der}, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}
+}
+
+func writePEM(t *testing.T, name string, blocks ...*pem.Block) string {
+	t.Helper()
+
+	var data []byte
+	for _, b := range blocks {
+		data = append(data, pem.EncodeToMemory(b)...)
+	}
+	path := filepath.Join(t.TempDir(), name)
+	require.Nil(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
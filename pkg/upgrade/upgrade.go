@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package upgrade
+
+import (
+	"sort"
+
+	"github.com/listendev/lstn/pkg/compare"
+	"github.com/listendev/lstn/pkg/listen"
+	"github.com/listendev/pkg/ecosystem"
+	"github.com/listendev/pkg/models/severity"
+	"github.com/listendev/pkg/verdictcode"
+)
+
+// Version is a version of a package, along with its verdicts.
+type Version struct {
+	Version  string           `json:"version"`
+	Verdicts []listen.Verdict `json:"verdicts"`
+	// High tells whether some verdicts have high severity
+	High bool `json:"high"`
+}
+
+// Step is the change of the verdicts between two consecutive versions of a package.
+//
+// The verdicts are the same when they have the same code and message.
+type Step struct {
+	From        string           `json:"from"`
+	To          string           `json:"to"`
+	Appeared    []listen.Verdict `json:"appeared,omitempty"`
+	Disappeared []listen.Verdict `json:"disappeared,omitempty"`
+}
+
+// Advice compares the verdicts of the versions of a package.
+type Advice struct {
+	Name string `json:"name"`
+	// Versions are the versions of the package, from the lowest one
+	Versions []*Version `json:"versions"`
+	// Steps are the changes of the verdicts between the consecutive versions
+	Steps []*Step `json:"steps"`
+	// Recommended is the lowest version without high severity verdicts, if any
+	Recommended string `json:"recommended,omitempty"`
+}
+
+// Compare returns the advice about the versions of the package in the input response.
+//
+// It does not return any advice when the response is empty.
+func Compare(eco ecosystem.Ecosystem, res listen.Response) *Advice {
+	if len(res) == 0 {
+		return nil
+	}
+
+	ret := &Advice{Name: res[0].Name, Versions: []*Version{}, Steps: []*Step{}}
+	seen := map[string]bool{}
+	for _, pkg := range res {
+		if pkg.Version == nil || seen[*pkg.Version] {
+			continue
+		}
+		seen[*pkg.Version] = true
+
+		v := &Version{Version: *pkg.Version, Verdicts: []listen.Verdict{}}
+		for _, verdict := range pkg.Verdicts {
+			if verdict.Code == verdictcode.UNK {
+				continue
+			}
+			v.Verdicts = append(v.Verdicts, verdict)
+			if verdict.Severity == severity.High {
+				v.High = true
+			}
+		}
+		ret.Versions = append(ret.Versions, v)
+	}
+	sort.SliceStable(ret.Versions, func(i, j int) bool {
+		return compare.Lower(eco, ret.Versions[i].Version, ret.Versions[j].Version)
+	})
+
+	for i, v := range ret.Versions {
+		if ret.Recommended == "" && !v.High {
+			ret.Recommended = v.Version
+		}
+		if i == 0 {
+			continue
+		}
+		prev := ret.Versions[i-1]
+		ret.Steps = append(ret.Steps, &Step{
+			From:        prev.Version,
+			To:          v.Version,
+			Appeared:    subtractVerdicts(v.Verdicts, prev.Verdicts),
+			Disappeared: subtractVerdicts(prev.Verdicts, v.Verdicts),
+		})
+	}
+
+	return ret
+}
+
+// Changed tells whether some verdicts appeared or disappeared.
+func (s *Step) Changed() bool {
+	return len(s.Appeared) > 0 || len(s.Disappeared) > 0
+}
+
+// subtractVerdicts returns the verdicts in a that are not in b.
+func subtractVerdicts(a, b []listen.Verdict) []listen.Verdict {
+	ret := []listen.Verdict{}
+	for _, va := range a {
+		found := false
+		for _, vb := range b {
+			if va.Code == vb.Code && va.Message == vb.Message {
+				found = true
+
+				break
+			}
+		}
+		if !found {
+			ret = append(ret, va)
+		}
+	}
+
+	return ret
+}
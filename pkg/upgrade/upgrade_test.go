@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/listendev/lstn/pkg/listen"
+	"github.com/listendev/pkg/ecosystem"
+	"github.com/listendev/pkg/models/severity"
+	"github.com/listendev/pkg/verdictcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+var (
+	installScript = listen.Verdict{Code: verdictcode.STN001, Severity: severity.High, Message: "install script"}
+	shellAccess   = listen.Verdict{Code: verdictcode.DDN01, Severity: severity.Medium, Message: "shell access"}
+	unknown       = listen.Verdict{Code: verdictcode.UNK, Severity: severity.High, Message: "unknown"}
+)
+
+func TestCompareEmpty(t *testing.T) {
+	assert.Nil(t, Compare(ecosystem.Npm, listen.Response{}))
+}
+
+func TestCompare(t *testing.T) {
+	res := listen.Response{
+		{Name: "chalk", Version: strPtr("1.10.0"), Verdicts: []listen.Verdict{shellAccess}},
+		{Name: "chalk", Version: strPtr("1.2.0"), Verdicts: []listen.Verdict{installScript, unknown}},
+		{Name: "chalk", Version: strPtr("1.9.0"), Verdicts: []listen.Verdict{installScript, shellAccess}},
+		{Name: "chalk", Version: strPtr("1.2.0"), Verdicts: []listen.Verdict{}},
+		{Name: "chalk"},
+	}
+
+	advice := Compare(ecosystem.Npm, res)
+	require.NotNil(t, advice)
+	assert.Equal(t, "chalk", advice.Name)
+	assert.Equal(t, []*Version{
+		{Version: "1.2.0", Verdicts: []listen.Verdict{installScript}, High: true},
+		{Version: "1.9.0", Verdicts: []listen.Verdict{installScript, shellAccess}, High: true},
+		{Version: "1.10.0", Verdicts: []listen.Verdict{shellAccess}, High: false},
+	}, advice.Versions)
+	assert.Equal(t, []*Step{
+		{From: "1.2.0", To: "1.9.0", Appeared: []listen.Verdict{shellAccess}, Disappeared: []listen.Verdict{}},
+		{From: "1.9.0", To: "1.10.0", Appeared: []listen.Verdict{}, Disappeared: []listen.Verdict{installScript}},
+	}, advice.Steps)
+	assert.Equal(t, "1.10.0", advice.Recommended)
+	assert.True(t, advice.Steps[0].Changed())
+}
+
+func TestComparePypi(t *testing.T) {
+	res := listen.Response{
+		{Name: "requests", Version: strPtr("2.10"), Verdicts: []listen.Verdict{}},
+		{Name: "requests", Version: strPtr("2.9.1"), Verdicts: []listen.Verdict{}},
+		{Name: "requests", Version: strPtr("2.10rc1"), Verdicts: []listen.Verdict{installScript}},
+	}
+
+	advice := Compare(ecosystem.Pypi, res)
+	require.NotNil(t, advice)
+	assert.Equal(t, "2.9.1", advice.Versions[0].Version)
+	assert.Equal(t, "2.10rc1", advice.Versions[1].Version)
+	assert.Equal(t, "2.10", advice.Versions[2].Version)
+	assert.Equal(t, "2.9.1", advice.Recommended)
+	assert.False(t, (&Step{}).Changed())
+}
+
+func TestCompareNoRecommendation(t *testing.T) {
+	res := listen.Response{
+		{Name: "chalk", Version: strPtr("1.0.0"), Verdicts: []listen.Verdict{installScript}},
+		{Name: "chalk", Version: strPtr("1.0.1"), Verdicts: []listen.Verdict{installScript}},
+	}
+
+	advice := Compare(ecosystem.Npm, res)
+	require.NotNil(t, advice)
+	assert.Empty(t, advice.Recommended)
+	assert.False(t, advice.Steps[0].Changed())
+}
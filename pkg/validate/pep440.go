@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright © 2025 The listen.dev team <engineering@garnet.ai>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/listendev/pkg/ecosystem"
+)
+
+// pep440Re is the regular expression from the PEP 440 appendix.
+var pep440Re = regexp.MustCompile(`(?i)^v?(?:(?:[0-9]+!)?[0-9]+(?:\.[0-9]+)*(?:[-_.]?(?:a|b|c|rc|alpha|beta|pre|preview)[-_.]?[0-9]*)?(?:-[0-9]+|[-_.]?(?:post|rev|r)[-_.]?[0-9]*)?(?:[-_.]?dev[-_.]?[0-9]*)?)(?:\+[a-z0-9]+(?:[-_.][a-z0-9]+)*)?$`)
+
+func isPEP440Version(fl validator.FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() == reflect.String {
+		return pep440Re.MatchString(field.String())
+	}
+
+	panic(fmt.Sprintf("bad field type: %T", field.Interface()))
+}
+
+// isEcosystemVersion tells whether the field is a version of the ecosystem the sibling field named by the param holds.
+//
+// PyPi versions follow PEP 440, while the npm ones (and the ones of an unknown ecosystem) follow semver.
+func isEcosystemVersion(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("bad field type: %T", field.Interface()))
+	}
+
+	eco, _, _, found := fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param())
+	if found && eco.Interface() == ecosystem.Pypi {
+		return pep440Re.MatchString(field.String())
+	}
+
+	return Singleton.Var(field.String(), "semver") == nil
+}
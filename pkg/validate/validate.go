@@ -22,6 +22,7 @@ import (
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	"github.com/go-playground/validator/v10/non-standard/validators"
+	"github.com/iancoleman/strcase"
 	"github.com/listendev/pkg/validate"
 )
 
@@ -59,6 +60,15 @@ func init() {
 	if err := Singleton.RegisterValidation("version_constraint", isVersionConstraint); err != nil {
 		panic(err)
 	}
+	if err := Singleton.RegisterValidation("pep440", isPEP440Version); err != nil {
+		panic(err)
+	}
+	if err := Singleton.RegisterValidation("ecosystem_version", isEcosystemVersion); err != nil {
+		panic(err)
+	}
+	if err := Singleton.RegisterValidation("pypi_package_name", isPyPiPackageName); err != nil {
+		panic(err)
+	}
 	if err := Singleton.RegisterValidation("notblank", validators.NotBlank); err != nil {
 		panic(err)
 	}
@@ -82,6 +92,57 @@ func init() {
 		panic(err)
 	}
 
+	if err := Singleton.RegisterTranslation(
+		"excluded_with",
+		Translator,
+		func(ut ut.Translator) error {
+			return ut.Add("excluded_with", "cannot use --{0} with --{1}", true)
+		},
+		func(ut ut.Translator, fe validator.FieldError) string {
+			// NOTE > Assuming that the flag is the lowercase of the struct field name we are depending on
+			excluding := strings.ToLower(fe.Param())
+			t, _ := ut.T("excluded_with", fe.Field(), excluding)
+
+			return t
+		},
+	); err != nil {
+		panic(err)
+	}
+
+	if err := Singleton.RegisterTranslation(
+		"required_if",
+		Translator,
+		func(ut ut.Translator) error {
+			return ut.Add("required_if", "{0} is mandatory when using --{1}", true)
+		},
+		func(ut ut.Translator, fe validator.FieldError) string {
+			// NOTE > Assuming that the flag is the lowercase of the struct field name we are depending on
+			dependingOn := strings.ToLower(strings.Fields(fe.Param())[0])
+			t, _ := ut.T("required_if", fe.Field(), dependingOn)
+
+			return t
+		},
+	); err != nil {
+		panic(err)
+	}
+
+	if err := Singleton.RegisterTranslation(
+		"required_with",
+		Translator,
+		func(ut ut.Translator) error {
+			return ut.Add("required_with", "{0} is mandatory when using --{1}", true)
+		},
+		func(ut ut.Translator, fe validator.FieldError) string {
+			// NOTE > Assuming that the flag is the kebab case of the struct field name we are depending on
+			dependingOn := strcase.ToKebab(fe.Param())
+			t, _ := ut.T("required_with", fe.Field(), dependingOn)
+
+			return t
+		},
+	); err != nil {
+		panic(err)
+	}
+
 	if err := Singleton.RegisterTranslation(
 		"endpoint",
 		Translator,
@@ -171,4 +232,19 @@ func init() {
 	); err != nil {
 		panic(err)
 	}
+
+	if err := Singleton.RegisterTranslation(
+		"file",
+		Translator,
+		func(ut ut.Translator) error {
+			return ut.Add("file", "{0} is not a valid existing file", true)
+		},
+		func(ut ut.Translator, fe validator.FieldError) string {
+			t, _ := ut.T("file", fe.Field())
+
+			return t
+		},
+	); err != nil {
+		panic(err)
+	}
 }